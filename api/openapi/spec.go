@@ -0,0 +1,8 @@
+// Package openapi embeds the OpenAPI specification of the HTTP API, the source of truth for its routes,
+// the request validation and the generated client in pkg/taskclient.
+package openapi
+
+import _ "embed"
+
+//go:embed task.yaml
+var Spec []byte
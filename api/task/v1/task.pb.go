@@ -0,0 +1,1685 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: api/task/v1/task.proto
+
+package taskv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Task struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Payload  string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Category string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	Version  int64                  `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	// author_id is empty for templates created without a user.
+	AuthorId      string `protobuf:"bytes,6,opt,name=author_id,json=authorId,proto3" json:"author_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Task) Reset() {
+	*x = Task{}
+	mi := &file_api_task_v1_task_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Task) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Task) ProtoMessage() {}
+
+func (x *Task) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Task.ProtoReflect.Descriptor instead.
+func (*Task) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Task) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Task) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *Task) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *Task) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Task) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Task) GetAuthorId() string {
+	if x != nil {
+		return x.AuthorId
+	}
+	return ""
+}
+
+type CreateTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Payload       string                 `protobuf:"bytes,1,opt,name=payload,proto3" json:"payload,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,2,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Category      string                 `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskRequest) Reset() {
+	*x = CreateTaskRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskRequest) ProtoMessage() {}
+
+func (x *CreateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *CreateTaskRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *CreateTaskRequest) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *CreateTaskRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type CreateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskResponse) Reset() {
+	*x = CreateTaskResponse{}
+	mi := &file_api_task_v1_task_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskResponse) ProtoMessage() {}
+
+func (x *CreateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateTaskResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CreateTaskResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type GetTaskRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetTaskRequest) Reset() {
+	*x = GetTaskRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetTaskRequest) ProtoMessage() {}
+
+func (x *GetTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetTaskRequest.ProtoReflect.Descriptor instead.
+func (*GetTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GetTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksRequest) Reset() {
+	*x = ListTasksRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksRequest) ProtoMessage() {}
+
+func (x *ListTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{4}
+}
+
+type ListTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*Task                `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTasksResponse) Reset() {
+	*x = ListTasksResponse{}
+	mi := &file_api_task_v1_task_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTasksResponse) ProtoMessage() {}
+
+func (x *ListTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListTasksResponse) GetTasks() []*Task {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type UpdateTaskRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Id       string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Payload  string                 `protobuf:"bytes,2,opt,name=payload,proto3" json:"payload,omitempty"`
+	Deadline *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Category string                 `protobuf:"bytes,4,opt,name=category,proto3" json:"category,omitempty"`
+	// version the caller expects to change, zero skips the check.
+	Version       int64 `protobuf:"varint,5,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskRequest) Reset() {
+	*x = UpdateTaskRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskRequest) ProtoMessage() {}
+
+func (x *UpdateTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskRequest.ProtoReflect.Descriptor instead.
+func (*UpdateTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *UpdateTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *UpdateTaskRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *UpdateTaskRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateTaskResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Version       int64                  `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateTaskResponse) Reset() {
+	*x = UpdateTaskResponse{}
+	mi := &file_api_task_v1_task_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateTaskResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateTaskResponse) ProtoMessage() {}
+
+func (x *UpdateTaskResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateTaskResponse.ProtoReflect.Descriptor instead.
+func (*UpdateTaskResponse) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *UpdateTaskResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateTaskResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteTaskRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// version the caller expects to delete, zero skips the check.
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteTaskRequest) Reset() {
+	*x = DeleteTaskRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteTaskRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteTaskRequest) ProtoMessage() {}
+
+func (x *DeleteTaskRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteTaskRequest.ProtoReflect.Descriptor instead.
+func (*DeleteTaskRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteTaskRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteTaskRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ClassLesson struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Class         string                 `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	LessonId      string                 `protobuf:"bytes,2,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ClassLesson) Reset() {
+	*x = ClassLesson{}
+	mi := &file_api_task_v1_task_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ClassLesson) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClassLesson) ProtoMessage() {}
+
+func (x *ClassLesson) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClassLesson.ProtoReflect.Descriptor instead.
+func (*ClassLesson) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ClassLesson) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *ClassLesson) GetLessonId() string {
+	if x != nil {
+		return x.LessonId
+	}
+	return ""
+}
+
+type CreateAssignmentsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TemplateTaskId string                 `protobuf:"bytes,1,opt,name=template_task_id,json=templateTaskId,proto3" json:"template_task_id,omitempty"`
+	AssignTo       []*ClassLesson         `protobuf:"bytes,2,rep,name=assign_to,json=assignTo,proto3" json:"assign_to,omitempty"`
+	// draft or publish_at in the future create drafts that are published later.
+	Draft         bool                   `protobuf:"varint,3,opt,name=draft,proto3" json:"draft,omitempty"`
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateAssignmentsRequest) Reset() {
+	*x = CreateAssignmentsRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAssignmentsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAssignmentsRequest) ProtoMessage() {}
+
+func (x *CreateAssignmentsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAssignmentsRequest.ProtoReflect.Descriptor instead.
+func (*CreateAssignmentsRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *CreateAssignmentsRequest) GetTemplateTaskId() string {
+	if x != nil {
+		return x.TemplateTaskId
+	}
+	return ""
+}
+
+func (x *CreateAssignmentsRequest) GetAssignTo() []*ClassLesson {
+	if x != nil {
+		return x.AssignTo
+	}
+	return nil
+}
+
+func (x *CreateAssignmentsRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *CreateAssignmentsRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+type Assignment struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Class         string                 `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+	LessonId      string                 `protobuf:"bytes,3,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	Status        string                 `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Assignment) Reset() {
+	*x = Assignment{}
+	mi := &file_api_task_v1_task_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Assignment) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Assignment) ProtoMessage() {}
+
+func (x *Assignment) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Assignment.ProtoReflect.Descriptor instead.
+func (*Assignment) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Assignment) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Assignment) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *Assignment) GetLessonId() string {
+	if x != nil {
+		return x.LessonId
+	}
+	return ""
+}
+
+func (x *Assignment) GetStatus() string {
+	if x != nil {
+		return x.Status
+	}
+	return ""
+}
+
+type CreateAssignmentsResponse struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	TemplateTaskId string                 `protobuf:"bytes,1,opt,name=template_task_id,json=templateTaskId,proto3" json:"template_task_id,omitempty"`
+	Assignments    []*Assignment          `protobuf:"bytes,2,rep,name=assignments,proto3" json:"assignments,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *CreateAssignmentsResponse) Reset() {
+	*x = CreateAssignmentsResponse{}
+	mi := &file_api_task_v1_task_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateAssignmentsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateAssignmentsResponse) ProtoMessage() {}
+
+func (x *CreateAssignmentsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateAssignmentsResponse.ProtoReflect.Descriptor instead.
+func (*CreateAssignmentsResponse) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *CreateAssignmentsResponse) GetTemplateTaskId() string {
+	if x != nil {
+		return x.TemplateTaskId
+	}
+	return ""
+}
+
+func (x *CreateAssignmentsResponse) GetAssignments() []*Assignment {
+	if x != nil {
+		return x.Assignments
+	}
+	return nil
+}
+
+type CreateTaskWithAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Class         string                 `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	LessonId      string                 `protobuf:"bytes,2,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	Payload       string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Deadline      *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	Draft         bool                   `protobuf:"varint,5,opt,name=draft,proto3" json:"draft,omitempty"`
+	PublishAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=publish_at,json=publishAt,proto3" json:"publish_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskWithAssignmentRequest) Reset() {
+	*x = CreateTaskWithAssignmentRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskWithAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskWithAssignmentRequest) ProtoMessage() {}
+
+func (x *CreateTaskWithAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskWithAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*CreateTaskWithAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateTaskWithAssignmentRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *CreateTaskWithAssignmentRequest) GetLessonId() string {
+	if x != nil {
+		return x.LessonId
+	}
+	return ""
+}
+
+func (x *CreateTaskWithAssignmentRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *CreateTaskWithAssignmentRequest) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *CreateTaskWithAssignmentRequest) GetDraft() bool {
+	if x != nil {
+		return x.Draft
+	}
+	return false
+}
+
+func (x *CreateTaskWithAssignmentRequest) GetPublishAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PublishAt
+	}
+	return nil
+}
+
+type CreateTaskWithAssignmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateTaskWithAssignmentResponse) Reset() {
+	*x = CreateTaskWithAssignmentResponse{}
+	mi := &file_api_task_v1_task_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateTaskWithAssignmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateTaskWithAssignmentResponse) ProtoMessage() {}
+
+func (x *CreateTaskWithAssignmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateTaskWithAssignmentResponse.ProtoReflect.Descriptor instead.
+func (*CreateTaskWithAssignmentResponse) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *CreateTaskWithAssignmentResponse) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+type ListClassTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Class         string                 `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListClassTasksRequest) Reset() {
+	*x = ListClassTasksRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListClassTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListClassTasksRequest) ProtoMessage() {}
+
+func (x *ListClassTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListClassTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListClassTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *ListClassTasksRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+type ListStudentTasksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Class         string                 `protobuf:"bytes,1,opt,name=class,proto3" json:"class,omitempty"`
+	StudentId     string                 `protobuf:"bytes,2,opt,name=student_id,json=studentId,proto3" json:"student_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListStudentTasksRequest) Reset() {
+	*x = ListStudentTasksRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListStudentTasksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListStudentTasksRequest) ProtoMessage() {}
+
+func (x *ListStudentTasksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListStudentTasksRequest.ProtoReflect.Descriptor instead.
+func (*ListStudentTasksRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ListStudentTasksRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *ListStudentTasksRequest) GetStudentId() string {
+	if x != nil {
+		return x.StudentId
+	}
+	return ""
+}
+
+type LessonTask struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	LessonId string                 `protobuf:"bytes,1,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	// task_id is the ID of the assignment.
+	TaskId         string                 `protobuf:"bytes,2,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	Payload        string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	Deadline       *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=deadline,proto3" json:"deadline,omitempty"`
+	TaskTemplateId string                 `protobuf:"bytes,5,opt,name=task_template_id,json=taskTemplateId,proto3" json:"task_template_id,omitempty"`
+	Version        int64                  `protobuf:"varint,6,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *LessonTask) Reset() {
+	*x = LessonTask{}
+	mi := &file_api_task_v1_task_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LessonTask) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LessonTask) ProtoMessage() {}
+
+func (x *LessonTask) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LessonTask.ProtoReflect.Descriptor instead.
+func (*LessonTask) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LessonTask) GetLessonId() string {
+	if x != nil {
+		return x.LessonId
+	}
+	return ""
+}
+
+func (x *LessonTask) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *LessonTask) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *LessonTask) GetDeadline() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Deadline
+	}
+	return nil
+}
+
+func (x *LessonTask) GetTaskTemplateId() string {
+	if x != nil {
+		return x.TaskTemplateId
+	}
+	return ""
+}
+
+func (x *LessonTask) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type ListLessonTasksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tasks         []*LessonTask          `protobuf:"bytes,1,rep,name=tasks,proto3" json:"tasks,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLessonTasksResponse) Reset() {
+	*x = ListLessonTasksResponse{}
+	mi := &file_api_task_v1_task_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLessonTasksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLessonTasksResponse) ProtoMessage() {}
+
+func (x *ListLessonTasksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLessonTasksResponse.ProtoReflect.Descriptor instead.
+func (*ListLessonTasksResponse) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ListLessonTasksResponse) GetTasks() []*LessonTask {
+	if x != nil {
+		return x.Tasks
+	}
+	return nil
+}
+
+type UpdateAssignmentRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Class   string                 `protobuf:"bytes,2,opt,name=class,proto3" json:"class,omitempty"`
+	Payload string                 `protobuf:"bytes,3,opt,name=payload,proto3" json:"payload,omitempty"`
+	// version the caller expects to change, zero skips the check.
+	Version       int64 `protobuf:"varint,4,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAssignmentRequest) Reset() {
+	*x = UpdateAssignmentRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssignmentRequest) ProtoMessage() {}
+
+func (x *UpdateAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*UpdateAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *UpdateAssignmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentRequest) GetClass() string {
+	if x != nil {
+		return x.Class
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentRequest) GetPayload() string {
+	if x != nil {
+		return x.Payload
+	}
+	return ""
+}
+
+func (x *UpdateAssignmentRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type UpdateAssignmentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateAssignmentResponse) Reset() {
+	*x = UpdateAssignmentResponse{}
+	mi := &file_api_task_v1_task_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateAssignmentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateAssignmentResponse) ProtoMessage() {}
+
+func (x *UpdateAssignmentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateAssignmentResponse.ProtoReflect.Descriptor instead.
+func (*UpdateAssignmentResponse) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *UpdateAssignmentResponse) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type DeleteAssignmentRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// version the caller expects to delete, zero skips the check.
+	Version       int64 `protobuf:"varint,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteAssignmentRequest) Reset() {
+	*x = DeleteAssignmentRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteAssignmentRequest) ProtoMessage() {}
+
+func (x *DeleteAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*DeleteAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteAssignmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeleteAssignmentRequest) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+type PublishAssignmentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PublishAssignmentRequest) Reset() {
+	*x = PublishAssignmentRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PublishAssignmentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PublishAssignmentRequest) ProtoMessage() {}
+
+func (x *PublishAssignmentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PublishAssignmentRequest.ProtoReflect.Descriptor instead.
+func (*PublishAssignmentRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *PublishAssignmentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type UserResult struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Mark   int32                  `protobuf:"varint,2,opt,name=mark,proto3" json:"mark,omitempty"`
+	// submitted_at is when the student handed the work in, now when empty. It is required once the deadline
+	// has passed under a penalty or reject policy; a corrected mark keeps the time of the first one.
+	SubmittedAt   *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=submitted_at,json=submittedAt,proto3" json:"submitted_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserResult) Reset() {
+	*x = UserResult{}
+	mi := &file_api_task_v1_task_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserResult) ProtoMessage() {}
+
+func (x *UserResult) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserResult.ProtoReflect.Descriptor instead.
+func (*UserResult) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *UserResult) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserResult) GetMark() int32 {
+	if x != nil {
+		return x.Mark
+	}
+	return 0
+}
+
+func (x *UserResult) GetSubmittedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.SubmittedAt
+	}
+	return nil
+}
+
+type SetTaskResultsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	TaskId        string                 `protobuf:"bytes,1,opt,name=task_id,json=taskId,proto3" json:"task_id,omitempty"`
+	LessonId      string                 `protobuf:"bytes,2,opt,name=lesson_id,json=lessonId,proto3" json:"lesson_id,omitempty"`
+	UsersResult   []*UserResult          `protobuf:"bytes,3,rep,name=users_result,json=usersResult,proto3" json:"users_result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetTaskResultsRequest) Reset() {
+	*x = SetTaskResultsRequest{}
+	mi := &file_api_task_v1_task_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetTaskResultsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetTaskResultsRequest) ProtoMessage() {}
+
+func (x *SetTaskResultsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_task_v1_task_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetTaskResultsRequest.ProtoReflect.Descriptor instead.
+func (*SetTaskResultsRequest) Descriptor() ([]byte, []int) {
+	return file_api_task_v1_task_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetTaskResultsRequest) GetTaskId() string {
+	if x != nil {
+		return x.TaskId
+	}
+	return ""
+}
+
+func (x *SetTaskResultsRequest) GetLessonId() string {
+	if x != nil {
+		return x.LessonId
+	}
+	return ""
+}
+
+func (x *SetTaskResultsRequest) GetUsersResult() []*UserResult {
+	if x != nil {
+		return x.UsersResult
+	}
+	return nil
+}
+
+var File_api_task_v1_task_proto protoreflect.FileDescriptor
+
+const file_api_task_v1_task_proto_rawDesc = "" +
+	"\n" +
+	"\x16api/task/v1/task.proto\x12\atask.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\xbb\x01\n" +
+	"\x04Task\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload\x126\n" +
+	"\bdeadline\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\x12\x1b\n" +
+	"\tauthor_id\x18\x06 \x01(\tR\bauthorId\"\x81\x01\n" +
+	"\x11CreateTaskRequest\x12\x18\n" +
+	"\apayload\x18\x01 \x01(\tR\apayload\x126\n" +
+	"\bdeadline\x18\x02 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\">\n" +
+	"\x12CreateTaskResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\" \n" +
+	"\x0eGetTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x12\n" +
+	"\x10ListTasksRequest\"8\n" +
+	"\x11ListTasksResponse\x12#\n" +
+	"\x05tasks\x18\x01 \x03(\v2\r.task.v1.TaskR\x05tasks\"\xab\x01\n" +
+	"\x11UpdateTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\apayload\x18\x02 \x01(\tR\apayload\x126\n" +
+	"\bdeadline\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12\x1a\n" +
+	"\bcategory\x18\x04 \x01(\tR\bcategory\x12\x18\n" +
+	"\aversion\x18\x05 \x01(\x03R\aversion\">\n" +
+	"\x12UpdateTaskResponse\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"=\n" +
+	"\x11DeleteTaskRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"@\n" +
+	"\vClassLesson\x12\x14\n" +
+	"\x05class\x18\x01 \x01(\tR\x05class\x12\x1b\n" +
+	"\tlesson_id\x18\x02 \x01(\tR\blessonId\"\xc8\x01\n" +
+	"\x18CreateAssignmentsRequest\x12(\n" +
+	"\x10template_task_id\x18\x01 \x01(\tR\x0etemplateTaskId\x121\n" +
+	"\tassign_to\x18\x02 \x03(\v2\x14.task.v1.ClassLessonR\bassignTo\x12\x14\n" +
+	"\x05draft\x18\x03 \x01(\bR\x05draft\x129\n" +
+	"\n" +
+	"publish_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\"g\n" +
+	"\n" +
+	"Assignment\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05class\x18\x02 \x01(\tR\x05class\x12\x1b\n" +
+	"\tlesson_id\x18\x03 \x01(\tR\blessonId\x12\x16\n" +
+	"\x06status\x18\x04 \x01(\tR\x06status\"|\n" +
+	"\x19CreateAssignmentsResponse\x12(\n" +
+	"\x10template_task_id\x18\x01 \x01(\tR\x0etemplateTaskId\x125\n" +
+	"\vassignments\x18\x02 \x03(\v2\x13.task.v1.AssignmentR\vassignments\"\xf7\x01\n" +
+	"\x1fCreateTaskWithAssignmentRequest\x12\x14\n" +
+	"\x05class\x18\x01 \x01(\tR\x05class\x12\x1b\n" +
+	"\tlesson_id\x18\x02 \x01(\tR\blessonId\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x126\n" +
+	"\bdeadline\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12\x14\n" +
+	"\x05draft\x18\x05 \x01(\bR\x05draft\x129\n" +
+	"\n" +
+	"publish_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tpublishAt\";\n" +
+	" CreateTaskWithAssignmentResponse\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\"-\n" +
+	"\x15ListClassTasksRequest\x12\x14\n" +
+	"\x05class\x18\x01 \x01(\tR\x05class\"N\n" +
+	"\x17ListStudentTasksRequest\x12\x14\n" +
+	"\x05class\x18\x01 \x01(\tR\x05class\x12\x1d\n" +
+	"\n" +
+	"student_id\x18\x02 \x01(\tR\tstudentId\"\xd8\x01\n" +
+	"\n" +
+	"LessonTask\x12\x1b\n" +
+	"\tlesson_id\x18\x01 \x01(\tR\blessonId\x12\x17\n" +
+	"\atask_id\x18\x02 \x01(\tR\x06taskId\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x126\n" +
+	"\bdeadline\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\bdeadline\x12(\n" +
+	"\x10task_template_id\x18\x05 \x01(\tR\x0etaskTemplateId\x12\x18\n" +
+	"\aversion\x18\x06 \x01(\x03R\aversion\"D\n" +
+	"\x17ListLessonTasksResponse\x12)\n" +
+	"\x05tasks\x18\x01 \x03(\v2\x13.task.v1.LessonTaskR\x05tasks\"s\n" +
+	"\x17UpdateAssignmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x14\n" +
+	"\x05class\x18\x02 \x01(\tR\x05class\x12\x18\n" +
+	"\apayload\x18\x03 \x01(\tR\apayload\x12\x18\n" +
+	"\aversion\x18\x04 \x01(\x03R\aversion\"4\n" +
+	"\x18UpdateAssignmentResponse\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\"C\n" +
+	"\x17DeleteAssignmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\x03R\aversion\"*\n" +
+	"\x18PublishAssignmentRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"x\n" +
+	"\n" +
+	"UserResult\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x12\n" +
+	"\x04mark\x18\x02 \x01(\x05R\x04mark\x12=\n" +
+	"\fsubmitted_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\vsubmittedAt\"\x85\x01\n" +
+	"\x15SetTaskResultsRequest\x12\x17\n" +
+	"\atask_id\x18\x01 \x01(\tR\x06taskId\x12\x1b\n" +
+	"\tlesson_id\x18\x02 \x01(\tR\blessonId\x126\n" +
+	"\fusers_result\x18\x03 \x03(\v2\x13.task.v1.UserResultR\vusersResult2\x8e\b\n" +
+	"\vTaskService\x12E\n" +
+	"\n" +
+	"CreateTask\x12\x1a.task.v1.CreateTaskRequest\x1a\x1b.task.v1.CreateTaskResponse\x121\n" +
+	"\aGetTask\x12\x17.task.v1.GetTaskRequest\x1a\r.task.v1.Task\x12B\n" +
+	"\tListTasks\x12\x19.task.v1.ListTasksRequest\x1a\x1a.task.v1.ListTasksResponse\x12E\n" +
+	"\n" +
+	"UpdateTask\x12\x1a.task.v1.UpdateTaskRequest\x1a\x1b.task.v1.UpdateTaskResponse\x12@\n" +
+	"\n" +
+	"DeleteTask\x12\x1a.task.v1.DeleteTaskRequest\x1a\x16.google.protobuf.Empty\x12Z\n" +
+	"\x11CreateAssignments\x12!.task.v1.CreateAssignmentsRequest\x1a\".task.v1.CreateAssignmentsResponse\x12o\n" +
+	"\x18CreateTaskWithAssignment\x12(.task.v1.CreateTaskWithAssignmentRequest\x1a).task.v1.CreateTaskWithAssignmentResponse\x12R\n" +
+	"\x0eListClassTasks\x12\x1e.task.v1.ListClassTasksRequest\x1a .task.v1.ListLessonTasksResponse\x12V\n" +
+	"\x10ListStudentTasks\x12 .task.v1.ListStudentTasksRequest\x1a .task.v1.ListLessonTasksResponse\x12W\n" +
+	"\x10UpdateAssignment\x12 .task.v1.UpdateAssignmentRequest\x1a!.task.v1.UpdateAssignmentResponse\x12L\n" +
+	"\x10DeleteAssignment\x12 .task.v1.DeleteAssignmentRequest\x1a\x16.google.protobuf.Empty\x12N\n" +
+	"\x11PublishAssignment\x12!.task.v1.PublishAssignmentRequest\x1a\x16.google.protobuf.Empty\x12H\n" +
+	"\x0eSetTaskResults\x12\x1e.task.v1.SetTaskResultsRequest\x1a\x16.google.protobuf.EmptyB\x19Z\x17task/api/task/v1;taskv1b\x06proto3"
+
+var (
+	file_api_task_v1_task_proto_rawDescOnce sync.Once
+	file_api_task_v1_task_proto_rawDescData []byte
+)
+
+func file_api_task_v1_task_proto_rawDescGZIP() []byte {
+	file_api_task_v1_task_proto_rawDescOnce.Do(func() {
+		file_api_task_v1_task_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_task_v1_task_proto_rawDesc), len(file_api_task_v1_task_proto_rawDesc)))
+	})
+	return file_api_task_v1_task_proto_rawDescData
+}
+
+var file_api_task_v1_task_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_api_task_v1_task_proto_goTypes = []any{
+	(*Task)(nil),                             // 0: task.v1.Task
+	(*CreateTaskRequest)(nil),                // 1: task.v1.CreateTaskRequest
+	(*CreateTaskResponse)(nil),               // 2: task.v1.CreateTaskResponse
+	(*GetTaskRequest)(nil),                   // 3: task.v1.GetTaskRequest
+	(*ListTasksRequest)(nil),                 // 4: task.v1.ListTasksRequest
+	(*ListTasksResponse)(nil),                // 5: task.v1.ListTasksResponse
+	(*UpdateTaskRequest)(nil),                // 6: task.v1.UpdateTaskRequest
+	(*UpdateTaskResponse)(nil),               // 7: task.v1.UpdateTaskResponse
+	(*DeleteTaskRequest)(nil),                // 8: task.v1.DeleteTaskRequest
+	(*ClassLesson)(nil),                      // 9: task.v1.ClassLesson
+	(*CreateAssignmentsRequest)(nil),         // 10: task.v1.CreateAssignmentsRequest
+	(*Assignment)(nil),                       // 11: task.v1.Assignment
+	(*CreateAssignmentsResponse)(nil),        // 12: task.v1.CreateAssignmentsResponse
+	(*CreateTaskWithAssignmentRequest)(nil),  // 13: task.v1.CreateTaskWithAssignmentRequest
+	(*CreateTaskWithAssignmentResponse)(nil), // 14: task.v1.CreateTaskWithAssignmentResponse
+	(*ListClassTasksRequest)(nil),            // 15: task.v1.ListClassTasksRequest
+	(*ListStudentTasksRequest)(nil),          // 16: task.v1.ListStudentTasksRequest
+	(*LessonTask)(nil),                       // 17: task.v1.LessonTask
+	(*ListLessonTasksResponse)(nil),          // 18: task.v1.ListLessonTasksResponse
+	(*UpdateAssignmentRequest)(nil),          // 19: task.v1.UpdateAssignmentRequest
+	(*UpdateAssignmentResponse)(nil),         // 20: task.v1.UpdateAssignmentResponse
+	(*DeleteAssignmentRequest)(nil),          // 21: task.v1.DeleteAssignmentRequest
+	(*PublishAssignmentRequest)(nil),         // 22: task.v1.PublishAssignmentRequest
+	(*UserResult)(nil),                       // 23: task.v1.UserResult
+	(*SetTaskResultsRequest)(nil),            // 24: task.v1.SetTaskResultsRequest
+	(*timestamppb.Timestamp)(nil),            // 25: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),                    // 26: google.protobuf.Empty
+}
+var file_api_task_v1_task_proto_depIdxs = []int32{
+	25, // 0: task.v1.Task.deadline:type_name -> google.protobuf.Timestamp
+	25, // 1: task.v1.CreateTaskRequest.deadline:type_name -> google.protobuf.Timestamp
+	0,  // 2: task.v1.ListTasksResponse.tasks:type_name -> task.v1.Task
+	25, // 3: task.v1.UpdateTaskRequest.deadline:type_name -> google.protobuf.Timestamp
+	9,  // 4: task.v1.CreateAssignmentsRequest.assign_to:type_name -> task.v1.ClassLesson
+	25, // 5: task.v1.CreateAssignmentsRequest.publish_at:type_name -> google.protobuf.Timestamp
+	11, // 6: task.v1.CreateAssignmentsResponse.assignments:type_name -> task.v1.Assignment
+	25, // 7: task.v1.CreateTaskWithAssignmentRequest.deadline:type_name -> google.protobuf.Timestamp
+	25, // 8: task.v1.CreateTaskWithAssignmentRequest.publish_at:type_name -> google.protobuf.Timestamp
+	25, // 9: task.v1.LessonTask.deadline:type_name -> google.protobuf.Timestamp
+	17, // 10: task.v1.ListLessonTasksResponse.tasks:type_name -> task.v1.LessonTask
+	25, // 11: task.v1.UserResult.submitted_at:type_name -> google.protobuf.Timestamp
+	23, // 12: task.v1.SetTaskResultsRequest.users_result:type_name -> task.v1.UserResult
+	1,  // 13: task.v1.TaskService.CreateTask:input_type -> task.v1.CreateTaskRequest
+	3,  // 14: task.v1.TaskService.GetTask:input_type -> task.v1.GetTaskRequest
+	4,  // 15: task.v1.TaskService.ListTasks:input_type -> task.v1.ListTasksRequest
+	6,  // 16: task.v1.TaskService.UpdateTask:input_type -> task.v1.UpdateTaskRequest
+	8,  // 17: task.v1.TaskService.DeleteTask:input_type -> task.v1.DeleteTaskRequest
+	10, // 18: task.v1.TaskService.CreateAssignments:input_type -> task.v1.CreateAssignmentsRequest
+	13, // 19: task.v1.TaskService.CreateTaskWithAssignment:input_type -> task.v1.CreateTaskWithAssignmentRequest
+	15, // 20: task.v1.TaskService.ListClassTasks:input_type -> task.v1.ListClassTasksRequest
+	16, // 21: task.v1.TaskService.ListStudentTasks:input_type -> task.v1.ListStudentTasksRequest
+	19, // 22: task.v1.TaskService.UpdateAssignment:input_type -> task.v1.UpdateAssignmentRequest
+	21, // 23: task.v1.TaskService.DeleteAssignment:input_type -> task.v1.DeleteAssignmentRequest
+	22, // 24: task.v1.TaskService.PublishAssignment:input_type -> task.v1.PublishAssignmentRequest
+	24, // 25: task.v1.TaskService.SetTaskResults:input_type -> task.v1.SetTaskResultsRequest
+	2,  // 26: task.v1.TaskService.CreateTask:output_type -> task.v1.CreateTaskResponse
+	0,  // 27: task.v1.TaskService.GetTask:output_type -> task.v1.Task
+	5,  // 28: task.v1.TaskService.ListTasks:output_type -> task.v1.ListTasksResponse
+	7,  // 29: task.v1.TaskService.UpdateTask:output_type -> task.v1.UpdateTaskResponse
+	26, // 30: task.v1.TaskService.DeleteTask:output_type -> google.protobuf.Empty
+	12, // 31: task.v1.TaskService.CreateAssignments:output_type -> task.v1.CreateAssignmentsResponse
+	14, // 32: task.v1.TaskService.CreateTaskWithAssignment:output_type -> task.v1.CreateTaskWithAssignmentResponse
+	18, // 33: task.v1.TaskService.ListClassTasks:output_type -> task.v1.ListLessonTasksResponse
+	18, // 34: task.v1.TaskService.ListStudentTasks:output_type -> task.v1.ListLessonTasksResponse
+	20, // 35: task.v1.TaskService.UpdateAssignment:output_type -> task.v1.UpdateAssignmentResponse
+	26, // 36: task.v1.TaskService.DeleteAssignment:output_type -> google.protobuf.Empty
+	26, // 37: task.v1.TaskService.PublishAssignment:output_type -> google.protobuf.Empty
+	26, // 38: task.v1.TaskService.SetTaskResults:output_type -> google.protobuf.Empty
+	26, // [26:39] is the sub-list for method output_type
+	13, // [13:26] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
+}
+
+func init() { file_api_task_v1_task_proto_init() }
+func file_api_task_v1_task_proto_init() {
+	if File_api_task_v1_task_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_task_v1_task_proto_rawDesc), len(file_api_task_v1_task_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   25,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_task_v1_task_proto_goTypes,
+		DependencyIndexes: file_api_task_v1_task_proto_depIdxs,
+		MessageInfos:      file_api_task_v1_task_proto_msgTypes,
+	}.Build()
+	File_api_task_v1_task_proto = out.File
+	file_api_task_v1_task_proto_goTypes = nil
+	file_api_task_v1_task_proto_depIdxs = nil
+}
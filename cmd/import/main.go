@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+	"task/internal/app"
+	"task/internal/config"
+	"task/internal/domain"
+	"task/internal/ports/httpServer/response"
+	"task/pkg/spreadsheet"
+
+	"github.com/google/uuid"
+)
+
+// Imports task templates or marks from a CSV or XLSX file:
+//
+//	import -config config.yaml -env .env -kind marks -file marks.xlsx -tenant <school id> -dry-run
+func main() {
+	kind := flag.String("kind", "tasks", "what the file contains: tasks or marks")
+	path := flag.String("file", "", "path to the CSV or XLSX file")
+	dryRun := flag.Bool("dry-run", false, "only validate the file and its references, nothing is written")
+	tenant := flag.String("tenant", "", "ID of the school to import into, the default school when empty")
+	flag.Parse()
+
+	cfg, err := config.InitConfig()
+	if err != nil {
+		log.Println(err.Error())
+		os.Exit(1)
+	}
+
+	tenantID := domain.DefaultTenant
+	if *tenant != "" {
+		tenantID, err = uuid.Parse(*tenant)
+		if err != nil {
+			log.Println(fmt.Errorf("invalid tenant id = %s with error: %w", *tenant, err).Error())
+			os.Exit(1)
+		}
+	}
+
+	table, err := readTable(*path)
+	if err != nil {
+		log.Println(err.Error())
+		os.Exit(1)
+	}
+
+	logger := app.InitLogger()
+
+	tools, err := app.InitTools(cfg, logger)
+	if err != nil {
+		log.Println(err.Error())
+		os.Exit(1)
+	}
+	defer tools.Shutdown()
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	ctx = domain.ContextWithTenant(ctx, tenantID)
+
+	var report *domain.ImportReport
+	switch *kind {
+	case "tasks":
+		report, err = tools.TaskService.ImportTasks(ctx, table, *dryRun)
+	case "marks":
+		report, err = tools.TaskService.ImportMarks(ctx, table, *dryRun)
+	default:
+		err = fmt.Errorf("unknown kind %s, expected tasks or marks", *kind)
+	}
+	if err != nil {
+		log.Println(err.Error())
+		os.Exit(1)
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(response.NewImportReportResponse(report)); err != nil {
+		log.Println(err.Error())
+		os.Exit(1)
+	}
+
+	if len(report.Errors) > 0 {
+		os.Exit(1)
+	}
+}
+
+func readTable(path string) ([][]string, error) {
+	if path == "" {
+		return nil, fmt.Errorf("file path is empty")
+	}
+
+	format, err := spreadsheet.DetectFormat(path, "")
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return spreadsheet.Read(file, format)
+}
@@ -11,6 +11,9 @@ import (
 	"task/internal/app"
 	"task/internal/config"
 
+	// Recurrences are expanded in the teacher's timezone; the runtime image ships without tzdata.
+	_ "time/tzdata"
+
 	"golang.org/x/sync/errgroup"
 )
 
@@ -38,6 +41,26 @@ func main() {
 		return application.Server.Run(ctx)
 	})
 
+	eg.Go(func() error {
+		return application.GRPCServer.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		return application.Publisher.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		return application.Reminder.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		return application.Materializer.Run(ctx)
+	})
+
+	eg.Go(func() error {
+		return application.Purger.Run(ctx)
+	})
+
 	eg.Go(func() error {
 		select {
 		case <-ctx.Done():
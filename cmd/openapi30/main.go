@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Rewrites the OpenAPI 3.1 specification as OpenAPI 3.0 for code generators that do not support 3.1 yet:
+//
+//	openapi30 -in api/openapi/task.yaml -out task.openapi30.yaml
+//
+// Nullable types written as type: [string, "null"] become type: string with nullable: true,
+// everything else is copied as is.
+func main() {
+	in := flag.String("in", "api/openapi/task.yaml", "path to the OpenAPI 3.1 specification")
+	out := flag.String("out", "", "path to write the OpenAPI 3.0 specification to, stdout when empty")
+	flag.Parse()
+
+	data, err := os.ReadFile(*in)
+	if err != nil {
+		log.Println(err.Error())
+		os.Exit(1)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		log.Println(fmt.Errorf("failed parse %s with error: %w", *in, err).Error())
+		os.Exit(1)
+	}
+
+	root := doc.Content[0]
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if root.Content[i].Value == "openapi" {
+			root.Content[i+1].Value = "3.0.3"
+		}
+	}
+	downgrade(root)
+
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(&doc); err != nil {
+		log.Println(err.Error())
+		os.Exit(1)
+	}
+
+	if *out == "" {
+		_, err = os.Stdout.Write(buf.Bytes())
+	} else {
+		err = os.WriteFile(*out, buf.Bytes(), 0o644)
+	}
+	if err != nil {
+		log.Println(err.Error())
+		os.Exit(1)
+	}
+}
+
+func downgrade(node *yaml.Node) {
+	for _, child := range node.Content {
+		downgrade(child)
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		if key.Value != "type" || value.Kind != yaml.SequenceNode || !removeNull(value) {
+			continue
+		}
+		if len(value.Content) == 1 {
+			*value = *value.Content[0]
+		}
+		node.Content = append(node.Content,
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "nullable"},
+			&yaml.Node{Kind: yaml.ScalarNode, Tag: "!!bool", Value: "true"},
+		)
+	}
+}
+
+func removeNull(types *yaml.Node) bool {
+	for i, t := range types.Content {
+		if t.Value == "null" {
+			types.Content = append(types.Content[:i], types.Content[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -1,11 +1,14 @@
 package kafka
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
 	"task/internal/config"
 	"task/internal/domain"
+	"task/pkg/metrics"
 	"time"
 
 	"github.com/IBM/sarama"
@@ -13,8 +16,11 @@ import (
 
 type KafkaProducer struct {
 	client sarama.AsyncProducer
-	topic  string
-	logger *slog.Logger
+	// syncClient delivers the events whose sender has to know they reached Kafka, see DeliverBatch.
+	syncClient sarama.SyncProducer
+	brokers    []string
+	topic      string
+	logger     *slog.Logger
 }
 
 func NewProducer(cfg *config.KafkaConfig, logger *slog.Logger) (*KafkaProducer, error) {
@@ -28,51 +34,181 @@ func NewProducer(cfg *config.KafkaConfig, logger *slog.Logger) (*KafkaProducer,
 	kafkaConfig.Producer.RequiredAcks = sarama.WaitForLocal       // Only wait for the leader to ack
 	kafkaConfig.Producer.Compression = sarama.CompressionSnappy   // Compress messages
 	kafkaConfig.Producer.Flush.Frequency = 500 * time.Millisecond // Flush batches every 500ms
+	kafkaConfig.Producer.Return.Successes = true                  // Count acknowledged messages
 
 	client, err := sarama.NewAsyncProducer(cfg.BrokerList, kafkaConfig)
 	if err != nil {
 		return nil, fmt.Errorf("broker.kafka.New: %w", err)
 	}
 
+	syncConfig := sarama.NewConfig()
+	syncConfig.Version = sarama.DefaultVersion
+	syncConfig.Producer.RequiredAcks = sarama.WaitForAll // Delivered means stored by every in-sync replica
+	syncConfig.Producer.Compression = sarama.CompressionSnappy
+	syncConfig.Producer.Return.Successes = true // Required by the sync producer
+
+	syncClient, err := sarama.NewSyncProducer(cfg.BrokerList, syncConfig)
+	if err != nil {
+		client.Close()
+		return nil, fmt.Errorf("broker.kafka.New: %w", err)
+	}
+
 	// We will just log to STDOUT if we're not able to produce messages.
 	// Note: messages will only be returned here after all retry attempts are exhausted.
 	go func() {
 		for err := range client.Errors() {
+			endSpan(err.Msg, err.Err)
+			metrics.ProducerFailed()
 			logger.Error("producer error:", slog.String("error", err.Error()))
 		}
 	}()
 
+	// Successes must be drained once they are returned, or the producer blocks.
+	go func() {
+		for message := range client.Successes() {
+			endSpan(message, nil)
+			metrics.ProducerSucceeded()
+		}
+	}()
+
 	return &KafkaProducer{
-		client: client,
-		topic:  cfg.Topic,
-		logger: logger,
+		client:     client,
+		syncClient: syncClient,
+		brokers:    cfg.BrokerList,
+		topic:      cfg.Topic,
+		logger:     logger,
 	}, nil
 }
 
-func (kp *KafkaProducer) Produce(msg domain.Event) error {
+func (kp *KafkaProducer) Produce(ctx context.Context, msg domain.Event) error {
 	jsonEvent, err := json.Marshal(msg)
 	if err != nil {
 		return fmt.Errorf("broker.kafka.Produce: %w", err)
 	}
 
-	kp.client.Input() <- &sarama.ProducerMessage{
+	message := &sarama.ProducerMessage{
 		Topic: kp.topic,
 		Key:   sarama.ByteEncoder(msg.Type()),
 		Value: sarama.ByteEncoder(jsonEvent),
 	}
+	startSpan(ctx, message, msg.Type())
+
+	metrics.ProducerQueued(1)
+	kp.client.Input() <- message
+
+	return nil
+}
+
+// ProduceBatch encodes all events before sending any of them, so an event that can't be encoded
+// doesn't leave the batch half sent.
+func (kp *KafkaProducer) ProduceBatch(ctx context.Context, events []domain.Event) error {
+	messages, err := kp.encodeBatch(ctx, events)
+	if err != nil {
+		return fmt.Errorf("broker.kafka.ProduceBatch: %w", err)
+	}
+
+	metrics.ProducerQueued(len(messages))
+	for _, message := range messages {
+		kp.client.Input() <- message
+	}
+
+	return nil
+}
+
+// DeliverBatch sends the events and waits until Kafka acknowledges all of them. Unlike ProduceBatch it fails
+// when any event isn't delivered, so the caller can keep its own record of them uncommitted and retry.
+func (kp *KafkaProducer) DeliverBatch(ctx context.Context, events []domain.Event) error {
+	messages, err := kp.encodeBatch(ctx, events)
+	if err != nil {
+		return fmt.Errorf("broker.kafka.DeliverBatch: %w", err)
+	}
+
+	metrics.ProducerQueued(len(messages))
+	sendErr := kp.syncClient.SendMessages(messages)
+
+	failed := make(map[*sarama.ProducerMessage]error, len(messages))
+	var producerErrs sarama.ProducerErrors
+	switch {
+	case errors.As(sendErr, &producerErrs):
+		for _, producerErr := range producerErrs {
+			failed[producerErr.Msg] = producerErr.Err
+		}
+	case sendErr != nil:
+		for _, message := range messages {
+			failed[message] = sendErr
+		}
+	}
+
+	for _, message := range messages {
+		endSpan(message, failed[message])
+		if failed[message] != nil {
+			metrics.ProducerFailed()
+			continue
+		}
+		metrics.ProducerSucceeded()
+	}
+
+	if sendErr != nil {
+		return fmt.Errorf("broker.kafka.DeliverBatch: %w", sendErr)
+	}
 
 	return nil
 }
 
+func (kp *KafkaProducer) encodeBatch(ctx context.Context, events []domain.Event) ([]*sarama.ProducerMessage, error) {
+	messages := make([]*sarama.ProducerMessage, 0, len(events))
+	for _, event := range events {
+		jsonEvent, err := json.Marshal(event)
+		if err != nil {
+			return nil, err
+		}
+
+		messages = append(messages, &sarama.ProducerMessage{
+			Topic: kp.topic,
+			Key:   sarama.ByteEncoder(event.Type()),
+			Value: sarama.ByteEncoder(jsonEvent),
+		})
+	}
+
+	for i, message := range messages {
+		startSpan(ctx, message, events[i].Type())
+	}
+
+	return messages, nil
+}
+
+// Ping checks that the brokers are reachable and the topic exists. Sarama doesn't take a context,
+// so the dial and read timeouts are taken from its deadline.
+func (kp *KafkaProducer) Ping(ctx context.Context) error {
+	config := sarama.NewConfig()
+	if deadline, ok := ctx.Deadline(); ok {
+		timeout := time.Until(deadline)
+		config.Net.DialTimeout = timeout
+		config.Net.ReadTimeout = timeout
+		config.Net.WriteTimeout = timeout
+	}
+
+	return describeTopic(kp.brokers, kp.topic, config)
+}
+
 func (kp *KafkaProducer) Close() {
 	err := kp.client.Close()
 	if err != nil {
 		kp.logger.Error("broker.kafka.Close", slog.String("error", err.Error()))
 	}
+
+	err = kp.syncClient.Close()
+	if err != nil {
+		kp.logger.Error("broker.kafka.Close", slog.String("error", err.Error()))
+	}
 }
 
 func pingKafka(brokerList []string, topic string) error {
-	admin, err := sarama.NewClusterAdmin(brokerList, sarama.NewConfig())
+	return describeTopic(brokerList, topic, sarama.NewConfig())
+}
+
+func describeTopic(brokerList []string, topic string, config *sarama.Config) error {
+	admin, err := sarama.NewClusterAdmin(brokerList, config)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,89 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/IBM/sarama"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "task/internal/adapters/brokers/kafka"
+
+// headerCarrier lets the propagator write the trace context into the message headers,
+// so consumers can continue the trace of the request that produced the event.
+type headerCarrier struct {
+	message *sarama.ProducerMessage
+}
+
+func (c headerCarrier) Get(key string) string {
+	for _, header := range c.message.Headers {
+		if string(header.Key) == key {
+			return string(header.Value)
+		}
+	}
+
+	return ""
+}
+
+func (c headerCarrier) Set(key, value string) {
+	for i, header := range c.message.Headers {
+		if string(header.Key) == key {
+			c.message.Headers[i].Value = []byte(value)
+			return
+		}
+	}
+
+	c.message.Headers = append(c.message.Headers, sarama.RecordHeader{Key: []byte(key), Value: []byte(value)})
+}
+
+func (c headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(c.message.Headers))
+	for _, header := range c.message.Headers {
+		keys = append(keys, string(header.Key))
+	}
+
+	return keys
+}
+
+var _ propagation.TextMapCarrier = headerCarrier{}
+
+// startSpan starts the span of the message and injects its context into the headers. The span
+// is kept in the metadata and ends when Kafka acknowledges the message or the producer gives up.
+func startSpan(ctx context.Context, message *sarama.ProducerMessage, eventType string) {
+	_, span := otel.Tracer(tracerName).Start(ctx, message.Topic+" publish",
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			semconv.MessagingSystemKafka,
+			semconv.MessagingOperationTypePublish,
+			semconv.MessagingDestinationName(message.Topic),
+			attribute.String("event.type", eventType),
+		),
+	)
+
+	otel.GetTextMapPropagator().Inject(trace.ContextWithSpan(ctx, span), headerCarrier{message: message})
+	message.Metadata = span
+}
+
+func endSpan(message *sarama.ProducerMessage, err error) {
+	span, ok := message.Metadata.(trace.Span)
+	if !ok {
+		return
+	}
+
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	} else {
+		span.SetAttributes(
+			semconv.MessagingDestinationPartitionID(strconv.Itoa(int(message.Partition))),
+			semconv.MessagingKafkaMessageOffset(int(message.Offset)),
+		)
+	}
+	span.End()
+}
@@ -0,0 +1,283 @@
+package pgrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// CreateTasks copies the tasks in with COPY, all of them or none.
+func (pg *RepositoryPG) CreateTasks(ctx context.Context, tasks []*domain.Task) error {
+	tenantID := domain.TenantFromContext(ctx)
+	rows := make([][]any, 0, len(tasks))
+	for _, task := range tasks {
+		rows = append(rows, []any{task.ID, tenantID, task.Payload, task.Deadline, task.Category, task.AuthorID})
+	}
+
+	_, err := pg.conn.CopyFrom(ctx, pgx.Identifier{"task"}, []string{"id", "tenant_id", "payload", "deadline", "category", "author_id"}, pgx.CopyFromRows(rows))
+	if err != nil {
+		return fmt.Errorf("can't copy tasks: %w", err)
+	}
+
+	for _, task := range tasks {
+		task.Version = 1
+	}
+
+	return nil
+}
+
+// CreateAssignmentsBulk assigns every item's task to its class and lesson. The payload and deadline
+// are copied from the task. Items whose task doesn't exist or is already assigned to the lesson fail.
+func (pg *RepositoryPG) CreateAssignmentsBulk(ctx context.Context, bulk *domain.BulkAssignments) ([]domain.Assignment, []error, error) {
+	sql := `INSERT INTO assignment (id, tenant_id, class, task_id, lesson_id, task_payload, deadline, status, publish_at, class_id)
+		SELECT $1, t.tenant_id, $2, t.id, $3, t.payload, t.deadline, $4, $5, ` + classID("$7", "$2") + ` FROM task t WHERE t.id = $6 AND t.tenant_id = $7 AND t.deleted_at IS NULL
+		ON CONFLICT DO NOTHING RETURNING id`
+
+	tenantID := domain.TenantFromContext(ctx)
+	batch := &pgx.Batch{}
+	for _, item := range bulk.Items {
+		batch.Queue(sql, uuid.New(), item.Class, item.LessonID, bulk.Status, bulk.PublishAt, item.TaskID, tenantID)
+	}
+
+	assignments := make([]domain.Assignment, len(bulk.Items))
+	itemErrs, err := pg.execBulk(ctx, batch, bulk.Mode, func(i int, row pgx.Row) error {
+		item := bulk.Items[i]
+		assignments[i] = domain.Assignment{Class: item.Class, LessonID: item.LessonID, Status: bulk.Status}
+		return row.Scan(&assignments[i].AssignmentID)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = pg.explainAssignFailures(ctx, bulk.Items, itemErrs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return assignments, itemErrs, nil
+}
+
+// explainAssignFailures tells apart items that inserted nothing because of a missing task from duplicates.
+func (pg *RepositoryPG) explainAssignFailures(ctx context.Context, items []domain.BulkAssignment, itemErrs []error) error {
+	for i, itemErr := range itemErrs {
+		if !errors.Is(itemErr, pgx.ErrNoRows) {
+			continue
+		}
+
+		var exists bool
+		err := pg.conn.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM task WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL)", items[i].TaskID, domain.TenantFromContext(ctx)).Scan(&exists)
+		if err != nil {
+			return err
+		}
+
+		itemErrs[i] = domain.ErrTaskNotFound
+		if exists {
+			itemErrs[i] = domain.ErrAssignmentExists
+		}
+	}
+
+	return nil
+}
+
+func (pg *RepositoryPG) DeleteAssignments(ctx context.Context, mode domain.BulkMode, assignmentIDs []uuid.UUID, deletedBy *uuid.UUID) ([]error, error) {
+	tenantID := domain.TenantFromContext(ctx)
+	batch := &pgx.Batch{}
+	for _, id := range assignmentIDs {
+		batch.Queue("UPDATE assignment SET deleted_at = now(), deleted_by = $2 WHERE id = $1 AND tenant_id = $3 AND deleted_at IS NULL RETURNING id", id, deletedBy, tenantID)
+	}
+
+	itemErrs, err := pg.execBulk(ctx, batch, mode, func(_ int, row pgx.Row) error {
+		var id uuid.UUID
+		return row.Scan(&id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return notFoundErrs(itemErrs, domain.ErrAssignmentNotFound), nil
+}
+
+// UpdateDeadlines moves the deadlines and forgets the reminders sent for the old ones.
+// The returned assignments carry their new version.
+func (pg *RepositoryPG) UpdateDeadlines(ctx context.Context, mode domain.BulkMode, updates []domain.DeadlineUpdate) ([]domain.TaskAsignment, []error, error) {
+	sql := `WITH updated AS (
+		UPDATE assignment SET deadline = $2, version = version + 1 WHERE id = $1 AND tenant_id = $3 AND deleted_at IS NULL
+		RETURNING id, class, lesson_id, task_payload, deadline, version
+	), reset AS (
+		DELETE FROM deadline_notification d USING updated WHERE d.assignment_id = updated.id
+	)
+	SELECT id, class, lesson_id, task_payload, deadline, version FROM updated`
+
+	tenantID := domain.TenantFromContext(ctx)
+	batch := &pgx.Batch{}
+	for _, update := range updates {
+		batch.Queue(sql, update.AssignmentID, update.Deadline, tenantID)
+	}
+
+	assignments := make([]domain.TaskAsignment, len(updates))
+	itemErrs, err := pg.execBulk(ctx, batch, mode, func(i int, row pgx.Row) error {
+		assignment := &assignments[i]
+		return row.Scan(&assignment.AssignmentID, &assignment.Class, &assignment.LessonID, &assignment.Payload, &assignment.Deadline, &assignment.Version)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return assignments, notFoundErrs(itemErrs, domain.ErrAssignmentNotFound), nil
+}
+
+// bulkSavepoint isolates every item of a best effort bulk, see execBulk.
+const bulkSavepoint = "bulk_item"
+
+// execBulk runs the statement of every item in a single transaction and scans the row it returns.
+// The statements are pipelined in one batch. An item whose statement returns no row (pgx.ErrNoRows) fails on its own.
+// In best effort mode every statement runs in its own savepoint, so an item the database rejects with a violated
+// constraint fails on its own too: its savepoint is rolled back and the items after it are sent in a new batch.
+// In atomic mode a failed item rolls the whole transaction back. Any other error, e.g. a timeout, a serialization
+// failure or a lost connection, fails them all.
+func (pg *RepositoryPG) execBulk(ctx context.Context, batch *pgx.Batch, mode domain.BulkMode, scan func(i int, row pgx.Row) error) ([]error, error) {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	savepoints := mode == domain.BulkModeBestEffort
+	itemErrs := make([]error, batch.Len())
+	for start := 0; start < batch.Len(); {
+		rejected, err := sendBulk(ctx, tx, batch.QueuedQueries, start, savepoints, scan, itemErrs)
+		if err != nil {
+			return nil, err
+		}
+		if rejected < 0 {
+			break
+		}
+		if !savepoints {
+			return itemErrs, nil
+		}
+
+		// Only the rejected item is undone, the savepoints of the items before it are released already.
+		if _, err := tx.Exec(ctx, "ROLLBACK TO SAVEPOINT "+bulkSavepoint+"; RELEASE SAVEPOINT "+bulkSavepoint); err != nil {
+			return nil, fmt.Errorf("rolling back savepoint: %w", err)
+		}
+		start = rejected + 1
+	}
+
+	if mode == domain.BulkModeAtomic && slices.ContainsFunc(itemErrs, func(err error) bool { return err != nil }) {
+		return itemErrs, nil
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return itemErrs, nil
+}
+
+// sendBulk sends the items from start on in one batch and returns the index of the item the database rejected,
+// or -1. The database skips the statements after a rejected one and leaves its savepoint open.
+func sendBulk(ctx context.Context, tx pgx.Tx, queries []*pgx.QueuedQuery, start int, savepoints bool,
+	scan func(i int, row pgx.Row) error, itemErrs []error) (int, error) {
+	batch := &pgx.Batch{}
+	for _, query := range queries[start:] {
+		if savepoints {
+			batch.Queue("SAVEPOINT " + bulkSavepoint)
+		}
+		batch.Queue(query.SQL, query.Arguments...)
+		if savepoints {
+			batch.Queue("RELEASE SAVEPOINT " + bulkSavepoint)
+		}
+	}
+
+	results := tx.SendBatch(ctx, batch)
+	defer results.Close()
+
+	exec := func(what string) error {
+		if _, err := results.Exec(); err != nil {
+			return fmt.Errorf("%s savepoint: %w", what, err)
+		}
+		return nil
+	}
+
+	for i := start; i < len(queries); i++ {
+		if savepoints {
+			if err := exec("creating"); err != nil {
+				return -1, err
+			}
+		}
+
+		err := scan(i, results.QueryRow())
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			itemErr, ok := rejectedErr(err, domain.ErrInvalidBulkItem)
+			if !ok {
+				return -1, fmt.Errorf("bulk item %d: %w", i, err)
+			}
+			itemErrs[i] = itemErr
+			return i, nil
+		}
+		itemErrs[i] = err
+
+		if savepoints {
+			if err := exec("releasing"); err != nil {
+				return -1, err
+			}
+		}
+	}
+
+	if err := results.Close(); err != nil {
+		return -1, fmt.Errorf("closing batch: %w", err)
+	}
+
+	return -1, nil
+}
+
+// inSavepoint runs fn in a savepoint of tx, which is rolled back when fn fails.
+func inSavepoint(ctx context.Context, tx pgx.Tx, fn func(savepoint pgx.Tx) error) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("creating savepoint: %w", err)
+	}
+
+	defer savepoint.Rollback(ctx)
+
+	if err := fn(savepoint); err != nil {
+		return err
+	}
+
+	return savepoint.Commit(ctx)
+}
+
+// rejectedErr tells whether the database rejected the statement for its data: only a violated constraint
+// is reported as invalid, pointing at the column when the database names it. Other errors, e.g. timeouts,
+// serialization failures or lock errors, aren't caused by the statement and aren't its own.
+func rejectedErr(err error, invalid *domain.Error) (error, bool) {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) || !pgerrcode.IsIntegrityConstraintViolation(pgErr.Code) {
+		return nil, false
+	}
+
+	if pgErr.ColumnName == "" {
+		return invalid, true
+	}
+
+	return invalid.WithField(pgErr.ColumnName, "violates a constraint"), true
+}
+
+// notFoundErrs reports the items whose statement matched no row as missing.
+func notFoundErrs(itemErrs []error, notFound error) []error {
+	for i, err := range itemErrs {
+		if errors.Is(err, pgx.ErrNoRows) {
+			itemErrs[i] = notFound
+		}
+	}
+
+	return itemErrs
+}
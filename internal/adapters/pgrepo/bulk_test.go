@@ -0,0 +1,205 @@
+package pgrepo_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"task/internal/adapters/pgrepo"
+	"task/internal/domain"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newRepository migrates a schema of its own in the database of TEST_POSTGRES_URL, the test is skipped without it.
+func newRepository(t *testing.T) (*pgrepo.RepositoryPG, *pgxpool.Pool) {
+	t.Helper()
+
+	url := os.Getenv("TEST_POSTGRES_URL")
+	if url == "" {
+		t.Skip("TEST_POSTGRES_URL is not set")
+	}
+
+	ctx := context.Background()
+	schema := "test_" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	admin, err := pgx.Connect(ctx, url)
+	require.NoError(t, err)
+	_, err = admin.Exec(ctx, "CREATE SCHEMA "+schema)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		_, _ = admin.Exec(ctx, "DROP SCHEMA "+schema+" CASCADE")
+		_ = admin.Close(ctx)
+	})
+
+	config, err := pgxpool.ParseConfig(url)
+	require.NoError(t, err)
+	config.ConnConfig.RuntimeParams["search_path"] = schema
+	pool, err := pgxpool.NewWithConfig(ctx, config)
+	require.NoError(t, err)
+	t.Cleanup(pool.Close)
+
+	migrations, err := filepath.Glob("../../../migrations/*.up.sql")
+	require.NoError(t, err)
+	slices.Sort(migrations)
+	for _, migration := range migrations {
+		sql, err := os.ReadFile(migration)
+		require.NoError(t, err)
+		_, err = pool.Exec(ctx, string(sql))
+		require.NoError(t, err, migration)
+	}
+
+	return pgrepo.NewRepositoruPG(pool), pool
+}
+
+func TestCreateAssignmentsBulkBestEffortKeepsItemsNextToConstraintViolation(t *testing.T) {
+	repo, pool := newRepository(t)
+	ctx := domain.ContextWithTenant(context.Background(), uuid.New())
+
+	require.NoError(t, repo.CreateClass(ctx, &domain.Class{ID: uuid.New(), Name: "9A"}))
+	lessons := []uuid.UUID{uuid.New(), uuid.New()}
+	for _, lessonID := range lessons {
+		require.NoError(t, repo.CreateLesson(ctx, &domain.Lesson{ID: lessonID, Class: "9A"}))
+	}
+	task := &domain.Task{ID: uuid.New(), Payload: "5+5 = ?"}
+	_, err := repo.CreateTask(ctx, task)
+	require.NoError(t, err)
+
+	// The lesson of the second item doesn't exist, so its insert violates the foreign key of the lesson.
+	bulk := &domain.BulkAssignments{
+		Mode: domain.BulkModeBestEffort,
+		Items: []domain.BulkAssignment{
+			{TaskID: task.ID, Class: "9A", LessonID: lessons[0]},
+			{TaskID: task.ID, Class: "9A", LessonID: uuid.New()},
+			{TaskID: task.ID, Class: "9A", LessonID: lessons[1]},
+		},
+		Status: domain.AssignmentStatusPublished,
+	}
+
+	assignments, itemErrs, err := repo.CreateAssignmentsBulk(ctx, bulk)
+
+	require.NoError(t, err)
+	assert.NoError(t, itemErrs[0])
+	assert.ErrorIs(t, itemErrs[1], domain.ErrInvalidBulkItem)
+	assert.NoError(t, itemErrs[2])
+
+	rows, err := pool.Query(ctx, "SELECT id FROM assignment WHERE task_id = $1 ORDER BY lesson_id = $2 DESC", task.ID, lessons[0])
+	require.NoError(t, err)
+	created, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{assignments[0].AssignmentID, assignments[2].AssignmentID}, created)
+}
+
+func TestCreateAssignmentsBulkAtomicRollsBackOnConstraintViolation(t *testing.T) {
+	repo, pool := newRepository(t)
+	ctx := domain.ContextWithTenant(context.Background(), uuid.New())
+
+	require.NoError(t, repo.CreateClass(ctx, &domain.Class{ID: uuid.New(), Name: "9A"}))
+	lessonID := uuid.New()
+	require.NoError(t, repo.CreateLesson(ctx, &domain.Lesson{ID: lessonID, Class: "9A"}))
+	task := &domain.Task{ID: uuid.New(), Payload: "5+5 = ?"}
+	_, err := repo.CreateTask(ctx, task)
+	require.NoError(t, err)
+
+	bulk := &domain.BulkAssignments{
+		Mode: domain.BulkModeAtomic,
+		Items: []domain.BulkAssignment{
+			{TaskID: task.ID, Class: "9A", LessonID: lessonID},
+			{TaskID: task.ID, Class: "9A", LessonID: uuid.New()},
+		},
+		Status: domain.AssignmentStatusPublished,
+	}
+
+	_, itemErrs, err := repo.CreateAssignmentsBulk(ctx, bulk)
+
+	require.NoError(t, err)
+	assert.ErrorIs(t, itemErrs[1], domain.ErrInvalidBulkItem)
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, "SELECT count(*) FROM assignment WHERE task_id = $1", task.ID).Scan(&count))
+	assert.Zero(t, count)
+}
+
+func TestBulkBestEffortKeepsGoingAfterSeveralFailures(t *testing.T) {
+	repo, pool := newRepository(t)
+	ctx := domain.ContextWithTenant(context.Background(), uuid.New())
+
+	require.NoError(t, repo.CreateClass(ctx, &domain.Class{ID: uuid.New(), Name: "9A"}))
+	lessons := []uuid.UUID{uuid.New(), uuid.New()}
+	for _, lessonID := range lessons {
+		require.NoError(t, repo.CreateLesson(ctx, &domain.Lesson{ID: lessonID, Class: "9A"}))
+	}
+	task := &domain.Task{ID: uuid.New(), Payload: "5+5 = ?"}
+	_, err := repo.CreateTask(ctx, task)
+	require.NoError(t, err)
+
+	// Two rejected items in a row make the second one the first statement of the batch sent after the first.
+	bulk := &domain.BulkAssignments{
+		Mode: domain.BulkModeBestEffort,
+		Items: []domain.BulkAssignment{
+			{TaskID: task.ID, Class: "9A", LessonID: uuid.New()},
+			{TaskID: task.ID, Class: "9A", LessonID: uuid.New()},
+			{TaskID: task.ID, Class: "9A", LessonID: lessons[0]},
+			{TaskID: task.ID, Class: "9A", LessonID: lessons[0]},
+			{TaskID: task.ID, Class: "9A", LessonID: lessons[1]},
+		},
+		Status: domain.AssignmentStatusPublished,
+	}
+
+	assignments, itemErrs, err := repo.CreateAssignmentsBulk(ctx, bulk)
+
+	require.NoError(t, err)
+	assert.ErrorIs(t, itemErrs[0], domain.ErrInvalidBulkItem)
+	assert.ErrorIs(t, itemErrs[1], domain.ErrInvalidBulkItem)
+	assert.NoError(t, itemErrs[2])
+	assert.ErrorIs(t, itemErrs[3], domain.ErrAssignmentExists)
+	assert.NoError(t, itemErrs[4])
+
+	deleteErrs, err := repo.DeleteAssignments(ctx, domain.BulkModeBestEffort,
+		[]uuid.UUID{assignments[2].AssignmentID, uuid.New(), assignments[4].AssignmentID}, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []error{nil, domain.ErrAssignmentNotFound, nil}, deleteErrs)
+
+	var count int
+	require.NoError(t, pool.QueryRow(ctx, "SELECT count(*) FROM assignment WHERE task_id = $1 AND deleted_at IS NULL", task.ID).Scan(&count))
+	assert.Zero(t, count)
+}
+
+func TestCreateAssignmentsReturnsOnlyInsertedRows(t *testing.T) {
+	repo, _ := newRepository(t)
+	ctx := domain.ContextWithTenant(context.Background(), uuid.New())
+
+	require.NoError(t, repo.CreateClass(ctx, &domain.Class{ID: uuid.New(), Name: "9A"}))
+	lessons := []uuid.UUID{uuid.New(), uuid.New()}
+	for _, lessonID := range lessons {
+		require.NoError(t, repo.CreateLesson(ctx, &domain.Lesson{ID: lessonID, Class: "9A"}))
+	}
+	task := &domain.Task{ID: uuid.New(), Payload: "5+5 = ?"}
+	_, err := repo.CreateTask(ctx, task)
+	require.NoError(t, err)
+
+	first, err := repo.CreateAssignments(ctx, &domain.TaskAsignments{
+		TaskID:   task.ID,
+		ToAssign: []domain.ClassLesson{{Class: "9A", LessonID: lessons[0]}},
+		Status:   domain.AssignmentStatusPublished,
+	})
+	require.NoError(t, err)
+	require.Len(t, first, 1)
+
+	second, err := repo.CreateAssignments(ctx, &domain.TaskAsignments{
+		TaskID:   task.ID,
+		ToAssign: []domain.ClassLesson{{Class: "9A", LessonID: lessons[0]}, {Class: "9A", LessonID: lessons[1]}},
+		Status:   domain.AssignmentStatusPublished,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, second, 1)
+	assert.Equal(t, lessons[1], second[0].LessonID)
+	assert.NotEqual(t, first[0].AssignmentID, second[0].AssignmentID)
+}
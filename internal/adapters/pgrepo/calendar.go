@@ -0,0 +1,55 @@
+package pgrepo
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetStudentExtensions returns the deadline extensions of the student on assignments of the class by assignment ID.
+func (pg *RepositoryPG) GetStudentExtensions(ctx context.Context, class string, studentID uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	sql := `SELECT e.assignment_id, e.deadline
+		FROM deadline_extension e
+		JOIN assignment a ON a.id = e.assignment_id
+		WHERE e.user_id = $1 AND a.class = $2 AND a.tenant_id = $3 AND a.deleted_at IS NULL`
+	rows, err := pg.conn.Query(ctx, sql, studentID, class, domain.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+	defer rows.Close()
+
+	extensions := make(map[uuid.UUID]time.Time)
+	for rows.Next() {
+		var assignmentID uuid.UUID
+		var deadline time.Time
+		if err := rows.Scan(&assignmentID, &deadline); err != nil {
+			return nil, fmt.Errorf("error scanning extension row: %w", err)
+		}
+		extensions[assignmentID] = deadline
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating extension rows: %w", err)
+	}
+
+	return extensions, nil
+}
+
+// TeachesClass tells whether the user teaches the class, that is authored a template assigned to it
+// or was personally given a template assigned to it for use. Shares with the whole school don't count.
+func (pg *RepositoryPG) TeachesClass(ctx context.Context, class string, userID uuid.UUID) (bool, error) {
+	sql := `SELECT EXISTS(SELECT 1 FROM assignment a JOIN task t ON t.id = a.task_id
+		WHERE a.class = $1 AND a.tenant_id = $2 AND a.deleted_at IS NULL AND (t.author_id = $3 OR EXISTS(
+			SELECT 1 FROM task_share s WHERE s.task_id = t.id AND s.user_id = $3 AND s.permission = ANY($4))))`
+
+	var teaches bool
+	err := pg.conn.QueryRow(ctx, sql, class, domain.TenantFromContext(ctx), userID, []domain.Permission{domain.PermissionUse, domain.PermissionEdit}).Scan(&teaches)
+	if err != nil {
+		return false, fmt.Errorf("error checking class teacher: %w", err)
+	}
+
+	return teaches, nil
+}
@@ -0,0 +1,156 @@
+package pgrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// classID is a subquery for the ID of the class named by the name parameter in the school of the tenant parameter.
+// Assignments keep the class name for reading and the ID as the reference.
+func classID(tenantParam, nameParam string) string {
+	return "(SELECT id FROM class WHERE tenant_id = " + tenantParam + " AND name = " + nameParam + ")"
+}
+
+func (pg *RepositoryPG) CreateClass(ctx context.Context, class *domain.Class) error {
+	_, err := pg.conn.Exec(ctx, "INSERT INTO class (id, tenant_id, name) VALUES($1, $2, $3)", class.ID, domain.TenantFromContext(ctx), class.Name)
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrClassExists
+		}
+		return fmt.Errorf("can't create class: %w", err)
+	}
+
+	return nil
+}
+
+func (pg *RepositoryPG) GetClasses(ctx context.Context) ([]domain.Class, error) {
+	rows, err := pg.conn.Query(ctx, "SELECT id, name FROM class WHERE tenant_id = $1 ORDER BY name", domain.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	classes, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Class, error) {
+		var class domain.Class
+		err := row.Scan(&class.ID, &class.Name)
+		return class, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning class row: %w", err)
+	}
+
+	return classes, nil
+}
+
+func (pg *RepositoryPG) CreateLesson(ctx context.Context, lesson *domain.Lesson) error {
+	tag, err := pg.conn.Exec(ctx, `INSERT INTO lesson (id, tenant_id, class_id, subject, starts_at)
+		SELECT $1, tenant_id, id, $3, $4 FROM class WHERE tenant_id = $5 AND name = $2`,
+		lesson.ID, lesson.Class, lesson.Subject, lesson.StartsAt, domain.TenantFromContext(ctx))
+	if err != nil {
+		if isUniqueViolation(err) {
+			return domain.ErrLessonExists
+		}
+		return fmt.Errorf("can't create lesson: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrClassNotFound
+	}
+
+	return nil
+}
+
+func (pg *RepositoryPG) GetLessons(ctx context.Context, class string) ([]domain.Lesson, error) {
+	rows, err := pg.conn.Query(ctx, `SELECT l.id, c.name, l.subject, l.starts_at FROM lesson l JOIN class c ON c.id = l.class_id
+		WHERE c.tenant_id = $1 AND c.name = $2 ORDER BY l.starts_at NULLS LAST, l.created_at`, domain.TenantFromContext(ctx), class)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	lessons, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Lesson, error) {
+		var lesson domain.Lesson
+		err := row.Scan(&lesson.ID, &lesson.Class, &lesson.Subject, &lesson.StartsAt)
+		return lesson, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning lesson row: %w", err)
+	}
+
+	return lessons, nil
+}
+
+// GetLessonsByClasses returns the lessons of every class in one query, in the order of GetLessons within a class.
+func (pg *RepositoryPG) GetLessonsByClasses(ctx context.Context, classes []string) ([]domain.Lesson, error) {
+	rows, err := pg.conn.Query(ctx, `SELECT l.id, c.name, l.subject, l.starts_at FROM lesson l JOIN class c ON c.id = l.class_id
+		WHERE c.tenant_id = $1 AND c.name = ANY($2) ORDER BY c.name, l.starts_at NULLS LAST, l.created_at`, domain.TenantFromContext(ctx), classes)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	lessons, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.Lesson, error) {
+		var lesson domain.Lesson
+		err := row.Scan(&lesson.ID, &lesson.Class, &lesson.Subject, &lesson.StartsAt)
+		return lesson, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning lesson row: %w", err)
+	}
+
+	return lessons, nil
+}
+
+// ValidateClassLessons checks that every class exists and every lesson belongs to its class.
+// It returns ErrClassNotFound or ErrLessonNotFound for each invalid pair.
+func (pg *RepositoryPG) ValidateClassLessons(ctx context.Context, pairs []domain.ClassLesson) ([]error, error) {
+	classes := make([]string, 0, len(pairs))
+	lessons := make([]uuid.UUID, 0, len(pairs))
+	for _, pair := range pairs {
+		classes = append(classes, pair.Class)
+		lessons = append(lessons, pair.LessonID)
+	}
+
+	rows, err := pg.conn.Query(ctx, `SELECT p.i, c.id IS NOT NULL, l.id IS NOT NULL
+		FROM unnest($1::text[], $2::uuid[]) WITH ORDINALITY AS p(class, lesson_id, i)
+		LEFT JOIN class c ON c.tenant_id = $3 AND c.name = p.class
+		LEFT JOIN lesson l ON l.id = p.lesson_id AND l.class_id = c.id`, classes, lessons, domain.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	defer rows.Close()
+
+	errs := make([]error, len(pairs))
+	for rows.Next() {
+		var (
+			i                         int
+			classExists, lessonExists bool
+		)
+		if err := rows.Scan(&i, &classExists, &lessonExists); err != nil {
+			return nil, fmt.Errorf("error scanning class lesson row: %w", err)
+		}
+
+		switch {
+		case !classExists:
+			errs[i-1] = fmt.Errorf("%w: %s", domain.ErrClassNotFound, pairs[i-1].Class)
+		case !lessonExists:
+			errs[i-1] = fmt.Errorf("%w: lesson %s, class %s", domain.ErrLessonNotFound, pairs[i-1].LessonID, pairs[i-1].Class)
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating class lesson rows: %w", err)
+	}
+
+	return errs, nil
+}
+
+func isUniqueViolation(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation
+}
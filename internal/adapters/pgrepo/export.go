@@ -0,0 +1,116 @@
+package pgrepo
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+const gradebookSelect = `SELECT m.user_id, a.id, a.lesson_id, a.class, a.task_id, a.task_payload, a.deadline, m.mark, m.penalty, m.submitted_at
+	FROM usersMark m
+	JOIN assignment a ON a.id = m.task_id
+	WHERE a.tenant_id = $1 AND a.deleted_at IS NULL`
+
+// StreamTasks passes the templates the user may see to fn row by row while reading them from the database,
+// so the connection is held until fn has seen the last task or returned an error.
+func (pg *RepositoryPG) StreamTasks(ctx context.Context, userID *uuid.UUID, fn func(*domain.Task) error) error {
+	rows, err := pg.conn.Query(ctx, "SELECT id, payload, deadline, category, version, author_id FROM task WHERE tenant_id = $1 AND deleted_at IS NULL AND "+visibleTask+" ORDER BY category, id",
+		domain.TenantFromContext(ctx), userID)
+	if err != nil {
+		return fmt.Errorf("error executing prepared statement: %w", err)
+	}
+	defer rows.Close()
+
+	var task domain.Task
+	for rows.Next() {
+		err := rows.Scan(&task.ID, &task.Payload, &task.Deadline, &task.Category, &task.Version, &task.AuthorID)
+		if err != nil {
+			return fmt.Errorf("error scanning task row: %w", err)
+		}
+
+		if err := fn(&task); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating task rows: %w", err)
+	}
+
+	return nil
+}
+
+// StreamGradebook passes the marks of the class to fn in deadline order.
+func (pg *RepositoryPG) StreamGradebook(ctx context.Context, class string, fn func(*domain.GradebookEntry) error) error {
+	rows, err := pg.conn.Query(ctx, gradebookSelect+" AND a.class = $2 ORDER BY a.deadline NULLS LAST, a.id, m.user_id", domain.TenantFromContext(ctx), class)
+	if err != nil {
+		return fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	return streamGradebook(rows, fn)
+}
+
+// StreamStudentMarks passes the marks of the student to fn in deadline order.
+func (pg *RepositoryPG) StreamStudentMarks(ctx context.Context, studentID uuid.UUID, fn func(*domain.GradebookEntry) error) error {
+	rows, err := pg.conn.Query(ctx, gradebookSelect+" AND m.user_id = $2 ORDER BY a.deadline NULLS LAST, a.id", domain.TenantFromContext(ctx), studentID)
+	if err != nil {
+		return fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	return streamGradebook(rows, fn)
+}
+
+// GetMarksByAssignments returns the marks of every assignment in one query.
+func (pg *RepositoryPG) GetMarksByAssignments(ctx context.Context, assignmentIDs []uuid.UUID) ([]domain.GradebookEntry, error) {
+	rows, err := pg.conn.Query(ctx, gradebookSelect+" AND a.id = ANY($2) ORDER BY a.id, m.user_id", domain.TenantFromContext(ctx), assignmentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	var marks []domain.GradebookEntry
+	err = streamGradebook(rows, func(entry *domain.GradebookEntry) error {
+		marks = append(marks, *entry)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return marks, nil
+}
+
+func streamGradebook(rows pgx.Rows, fn func(*domain.GradebookEntry) error) error {
+	defer rows.Close()
+
+	var entry domain.GradebookEntry
+	for rows.Next() {
+		err := rows.Scan(
+			&entry.StudentID,
+			&entry.TaskID,
+			&entry.LessonID,
+			&entry.Class,
+			&entry.TaskTemplateID,
+			&entry.Payload,
+			&entry.Deadline,
+			&entry.Mark,
+			&entry.Penalty,
+			&entry.SubmittedAt,
+		)
+		if err != nil {
+			return fmt.Errorf("error scanning mark row: %w", err)
+		}
+
+		if err := fn(&entry); err != nil {
+			return err
+		}
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("error iterating mark rows: %w", err)
+	}
+
+	return nil
+}
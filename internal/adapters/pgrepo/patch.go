@@ -0,0 +1,70 @@
+package pgrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (pg *RepositoryPG) GetAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.TaskAsignment, error) {
+	var assignment domain.TaskAsignment
+	err := pg.conn.QueryRow(ctx, "SELECT id, task_id, class, lesson_id, task_payload, deadline, version FROM assignment WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL",
+		assignmentID, domain.TenantFromContext(ctx)).Scan(
+		&assignment.AssignmentID,
+		&assignment.TaskID,
+		&assignment.Class,
+		&assignment.LessonID,
+		&assignment.Payload,
+		&assignment.Deadline,
+		&assignment.Version,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		return nil, err
+	}
+
+	return &assignment, nil
+}
+
+// PatchAssignment stores a patched assignment if it still has assignment.Version and stores the new version in it.
+// A moved deadline forgets the reminders sent for the old one.
+func (pg *RepositoryPG) PatchAssignment(ctx context.Context, assignment *domain.TaskAsignment) error {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	var deadlineMoved bool
+	sql := `UPDATE assignment a SET task_payload = $1, class = $2, class_id = ` + classID("$6", "$2") + `, deadline = $3, version = a.version + 1
+		FROM assignment old WHERE old.id = a.id AND a.id = $4 AND a.tenant_id = $6 AND a.deleted_at IS NULL AND a.version = $5
+		RETURNING a.version, a.deadline IS DISTINCT FROM old.deadline`
+	err = tx.QueryRow(ctx, sql, assignment.Payload, assignment.Class, assignment.Deadline, assignment.AssignmentID, assignment.Version, domain.TenantFromContext(ctx)).
+		Scan(&assignment.Version, &deadlineMoved)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return versionConflict(ctx, tx, "assignment", assignment.AssignmentID, domain.ErrAssignmentNotFound)
+		}
+		return err
+	}
+
+	if deadlineMoved {
+		_, err = tx.Exec(ctx, "DELETE FROM deadline_notification WHERE assignment_id = $1", assignment.AssignmentID)
+		if err != nil {
+			return fmt.Errorf("can't reset deadline reminders: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
@@ -5,11 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"task/internal/domain"
+	"time"
 
 	"github.com/google/uuid"
-	"github.com/jackc/pgerrcode"
 	"github.com/jackc/pgx/v5"
-	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -25,7 +24,8 @@ func NewRepositoruPG(conn *pgxpool.Pool) *RepositoryPG {
 
 func (pg *RepositoryPG) CreateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error) {
 	var id uuid.UUID
-	err := pg.conn.QueryRow(ctx, "INSERT INTO task (id, payload, deadline) VALUES($1, $2, $3) RETURNING id", task.ID, task.Payload, task.Deadline).Scan(&id)
+	err := pg.conn.QueryRow(ctx, "INSERT INTO task (id, tenant_id, payload, deadline, category, author_id) VALUES($1, $2, $3, $4, $5, $6) RETURNING id, version",
+		task.ID, domain.TenantFromContext(ctx), task.Payload, task.Deadline, task.Category, task.AuthorID).Scan(&id, &task.Version)
 	if err != nil {
 		return id, fmt.Errorf("can't create new task records:%w", err)
 	}
@@ -35,7 +35,7 @@ func (pg *RepositoryPG) CreateTask(ctx context.Context, task *domain.Task) (uuid
 
 func (pg *RepositoryPG) GetTaskByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
 	var task domain.Task
-	err := pg.conn.QueryRow(ctx, "SELECT  id, payload, deadline FROM task WHERE id = $1", id).Scan(&task.ID, &task.Payload, &task.Deadline)
+	err := pg.conn.QueryRow(ctx, "SELECT  id, payload, deadline, category, version, author_id FROM task WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL", id, domain.TenantFromContext(ctx)).Scan(&task.ID, &task.Payload, &task.Deadline, &task.Category, &task.Version, &task.AuthorID)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, domain.ErrTaskNotFound
@@ -46,8 +46,10 @@ func (pg *RepositoryPG) GetTaskByID(ctx context.Context, id uuid.UUID) (*domain.
 	return &task, nil
 }
 
-func (pg *RepositoryPG) GetTasks(ctx context.Context) ([]*domain.Task, error) {
-	rows, err := pg.conn.Query(ctx, "SELECT id, payload, deadline FROM task")
+// GetTasks returns the templates the user may see, see visibleTask.
+func (pg *RepositoryPG) GetTasks(ctx context.Context, userID *uuid.UUID) ([]*domain.Task, error) {
+	rows, err := pg.conn.Query(ctx, "SELECT id, payload, deadline, category, version, author_id FROM task WHERE tenant_id = $1 AND deleted_at IS NULL AND "+visibleTask,
+		domain.TenantFromContext(ctx), userID)
 	if err != nil {
 		return nil, fmt.Errorf("error executing prepared statement: %w", err)
 	}
@@ -61,6 +63,9 @@ func (pg *RepositoryPG) GetTasks(ctx context.Context) ([]*domain.Task, error) {
 			&task.ID,
 			&task.Payload,
 			&task.Deadline,
+			&task.Category,
+			&task.Version,
+			&task.AuthorID,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning task row: %w", err)
@@ -75,78 +80,123 @@ func (pg *RepositoryPG) GetTasks(ctx context.Context) ([]*domain.Task, error) {
 	return tasks, nil
 }
 
+// GetTasksByIDs returns the found templates the user may see in one query, see visibleTask.
+func (pg *RepositoryPG) GetTasksByIDs(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID) ([]*domain.Task, error) {
+	rows, err := pg.conn.Query(ctx, "SELECT id, payload, deadline, category, version, author_id FROM task WHERE tenant_id = $1 AND id = ANY($3) AND deleted_at IS NULL AND "+visibleTask,
+		domain.TenantFromContext(ctx), userID, ids)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	tasks, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*domain.Task, error) {
+		var task domain.Task
+		err := row.Scan(&task.ID, &task.Payload, &task.Deadline, &task.Category, &task.Version, &task.AuthorID)
+		return &task, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning task row: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// UpdateTask updates the task if it still has task.Version (any version when it is zero)
+// and stores the new version and the author in task.
 func (pg *RepositoryPG) UpdateTask(ctx context.Context, task *domain.Task) error {
-	_, err := pg.conn.Exec(ctx, "UPDATE task SET payload = $1, deadline = $2 WHERE id = $3", task.Payload, task.Deadline, task.ID)
+	sql := "UPDATE task SET payload = $1, deadline = $2, category = $3, version = version + 1 WHERE id = $4 AND tenant_id = $6 AND deleted_at IS NULL AND ($5::int = 0 OR version = $5) RETURNING version, author_id"
+	err := pg.conn.QueryRow(ctx, sql, task.Payload, task.Deadline, task.Category, task.ID, task.Version, domain.TenantFromContext(ctx)).Scan(&task.Version, &task.AuthorID)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return versionConflict(ctx, pg.conn, "task", task.ID, domain.ErrTaskNotFound)
+		}
 		return err
 	}
 
 	return nil
 }
 
-func (pg *RepositoryPG) DeleteTask(ctx context.Context, id uuid.UUID) error {
+// DeleteTask moves the task to the trash together with its active assignments.
+// They share the deletion time, which is how RestoreTask finds the assignments to bring back.
+func (pg *RepositoryPG) DeleteTask(ctx context.Context, id uuid.UUID, version int, deletedBy *uuid.UUID) error {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
 
-	tag, err := pg.conn.Exec(ctx, "DELETE FROM task WHERE id = $1", id)
+	defer tx.Rollback(ctx)
+
+	var deletedAt time.Time
+	err = tx.QueryRow(ctx, "UPDATE task SET deleted_at = now(), deleted_by = $2 WHERE id = $1 AND tenant_id = $4 AND deleted_at IS NULL AND ($3::int = 0 OR version = $3) RETURNING deleted_at",
+		id, deletedBy, version, domain.TenantFromContext(ctx)).Scan(&deletedAt)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return versionConflict(ctx, tx, "task", id, domain.ErrTaskNotFound)
+		}
 		return err
 	}
 
-	rowsAffected := tag.RowsAffected()
-	if rowsAffected == 0 {
-		return domain.ErrTaskNotFound
+	_, err = tx.Exec(ctx, "UPDATE assignment SET deleted_at = $2, deleted_by = $3 WHERE task_id = $1 AND tenant_id = $4 AND deleted_at IS NULL",
+		id, deletedAt, deletedBy, domain.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("can't delete task assignments: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
 	}
 
 	return nil
 }
 
+// CreateAssignments assigns the task to every class and lesson it isn't assigned to yet and returns
+// only the assignments it inserted; the lessons the task is assigned to already are skipped.
 func (pg *RepositoryPG) CreateAssignments(ctx context.Context, task *domain.TaskAsignments) ([]domain.Assignment, error) {
 	taskDetails, err := pg.GetTaskByID(ctx, task.TaskID)
 	if err != nil {
-		return nil, fmt.Errorf("can't get task details")
+		return nil, fmt.Errorf("can't get task details: %w", err)
 	}
 
-	assignments := make([]domain.Assignment, 0, len(task.ToAssign))
-
-	sql := "INSERT INTO assignment (id, class, task_id, lesson_id, task_payload, deadline) VALUES($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING"
+	sql := "INSERT INTO assignment (id, tenant_id, class, task_id, lesson_id, task_payload, deadline, status, publish_at, class_id) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, " + classID("$2", "$3") + ") ON CONFLICT DO NOTHING RETURNING id"
+	tenantID := domain.TenantFromContext(ctx)
 	batch := &pgx.Batch{}
 	for _, cl := range task.ToAssign {
-		assignmentID := uuid.New()
-
-		assignments = append(assignments, domain.Assignment{
-			AssignmentID: assignmentID,
-			Class:        cl.Class,
-			LessonID:     cl.LessonID,
-		})
-
-		batch.Queue(sql, assignmentID, cl.Class, task.TaskID, cl.LessonID, taskDetails.Payload, taskDetails.Deadline)
+		batch.Queue(sql, uuid.New(), tenantID, cl.Class, task.TaskID, cl.LessonID, taskDetails.Payload, taskDetails.Deadline, task.Status, task.PublishAt)
 	}
 
 	results := pg.conn.SendBatch(ctx, batch)
 	defer results.Close()
 
-	for range task.ToAssign {
-		_, err := results.Exec()
+	assignments := make([]domain.Assignment, 0, len(task.ToAssign))
+	for _, cl := range task.ToAssign {
+		var assignmentID uuid.UUID
+		err := results.QueryRow().Scan(&assignmentID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			continue
+		}
 		if err != nil {
-			var pgErr *pgconn.PgError
-			if errors.As(err, &pgErr) {
-				fmt.Println("error %w", pgErr.Message)
-				if pgErr.Code == pgerrcode.UniqueViolation {
-					continue
-				}
-			}
-
-			return nil, fmt.Errorf("unnable create assignment %w", err)
-
+			return nil, fmt.Errorf("can't create assignment: %w", err)
 		}
 
+		assignments = append(assignments, domain.Assignment{
+			AssignmentID: assignmentID,
+			Class:        cl.Class,
+			LessonID:     cl.LessonID,
+			Status:       task.Status,
+		})
 	}
 
 	return assignments, nil
 }
 
+// UpdateAssignment updates the assignment if it still has task.Version (any version when it is zero)
+// and stores the new version in task.Version.
 func (pg *RepositoryPG) UpdateAssignment(ctx context.Context, task *domain.TaskAsignment) error {
-	_, err := pg.conn.Exec(ctx, "UPDATE assignment SET task_payload = $1, class = $2 WHERE id = $3", task.Payload, task.Class, task.AssignmentID)
+	sql := "UPDATE assignment SET task_payload = $1, class = $2, class_id = " + classID("$5", "$2") + ", version = version + 1 WHERE id = $3 AND tenant_id = $5 AND deleted_at IS NULL AND ($4::int = 0 OR version = $4) RETURNING version"
+	err := pg.conn.QueryRow(ctx, sql, task.Payload, task.Class, task.AssignmentID, task.Version, domain.TenantFromContext(ctx)).Scan(&task.Version)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return versionConflict(ctx, pg.conn, "assignment", task.AssignmentID, domain.ErrAssignmentNotFound)
+		}
 		return err
 	}
 
@@ -154,7 +204,8 @@ func (pg *RepositoryPG) UpdateAssignment(ctx context.Context, task *domain.TaskA
 }
 
 func (pg *RepositoryPG) GetTaskByClass(ctx context.Context, class string) ([]*domain.LessonTask, error) {
-	rows, err := pg.conn.Query(ctx, "SELECT id, lesson_id, task_id, task_payload, deadline FROM assignment where class = $1", class)
+	rows, err := pg.conn.Query(ctx, "SELECT id, lesson_id, task_id, task_payload, deadline, version FROM assignment where class = $1 AND tenant_id = $3 AND status = $2 AND deleted_at IS NULL",
+		class, domain.AssignmentStatusPublished, domain.TenantFromContext(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("error executing prepared statement: %w", err)
 	}
@@ -170,6 +221,7 @@ func (pg *RepositoryPG) GetTaskByClass(ctx context.Context, class string) ([]*do
 			&task.TaskTemplateID,
 			&task.Payload,
 			&task.Deadline,
+			&task.Version,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning task row: %w", err)
@@ -184,14 +236,93 @@ func (pg *RepositoryPG) GetTaskByClass(ctx context.Context, class string) ([]*do
 	return tasks, nil
 }
 
+// GetTasksByClasses returns the published tasks of every class in one query, keyed by the class.
+func (pg *RepositoryPG) GetTasksByClasses(ctx context.Context, classes []string) (map[string][]*domain.LessonTask, error) {
+	rows, err := pg.conn.Query(ctx, "SELECT class, id, lesson_id, task_id, task_payload, deadline, version FROM assignment WHERE class = ANY($1) AND tenant_id = $3 AND status = $2 AND deleted_at IS NULL",
+		classes, domain.AssignmentStatusPublished, domain.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	defer rows.Close()
+
+	tasks := make(map[string][]*domain.LessonTask, len(classes))
+	for rows.Next() {
+		var (
+			class string
+			task  domain.LessonTask
+		)
+		err := rows.Scan(
+			&class,
+			&task.TaskID,
+			&task.LessonID,
+			&task.TaskTemplateID,
+			&task.Payload,
+			&task.Deadline,
+			&task.Version,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning task row: %w", err)
+		}
+		tasks[class] = append(tasks[class], &task)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task rows: %w", err)
+	}
+
+	return tasks, nil
+}
+
+// SetTaskResultsByUsers upserts the marks. A corrected mark keeps the submission time recorded with the first one.
 func (pg *RepositoryPG) SetTaskResultsByUsers(ctx context.Context, taskResults *domain.TaskResult) error {
-	sql := "INSERT INTO usersMark (id, user_id, task_id, lesson_id, mark) VALUES($1, $2, $3, $4, $5) ON CONFLICT (user_id, task_id, lesson_id) DO UPDATE SET mark = $5"
+	return setTaskResults(ctx, pg.conn, taskResults)
+}
+
+// CheckTaskResults upserts the marks of every result in a transaction that is rolled back, so a dry run
+// finds what the database would reject. A result that violates a constraint gets its error, any other error fails the check.
+func (pg *RepositoryPG) CheckTaskResults(ctx context.Context, taskResults []*domain.TaskResult) ([]error, error) {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	errs := make([]error, len(taskResults))
+	for i, results := range taskResults {
+		err := inSavepoint(ctx, tx, func(savepoint pgx.Tx) error {
+			return setTaskResults(ctx, savepoint, results)
+		})
+		if err != nil {
+			resultErr, ok := rejectedErr(err, domain.ErrInvalidImport)
+			if !ok {
+				return nil, fmt.Errorf("task result %d: %w", i, err)
+			}
+			errs[i] = resultErr
+		}
+	}
+
+	return errs, nil
+}
+
+type batchSender interface {
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+func setTaskResults(ctx context.Context, conn batchSender, taskResults *domain.TaskResult) error {
+	sql := "INSERT INTO usersMark (id, user_id, task_id, lesson_id, mark, submitted_at, penalty, tenant_id) VALUES($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (user_id, task_id, lesson_id) DO UPDATE SET mark = $5, submitted_at = COALESCE(usersMark.submitted_at, $6), penalty = $7"
+	tenantID := domain.TenantFromContext(ctx)
 	batch := &pgx.Batch{}
 	for _, userResults := range taskResults.UsersResult {
-		batch.Queue(sql, uuid.New(), userResults.UserID, taskResults.TaskID, taskResults.LessonID, userResults.Mark)
+		var submittedAt *time.Time
+		if !userResults.SubmittedAt.IsZero() {
+			submittedAt = &userResults.SubmittedAt
+		}
+		batch.Queue(sql, uuid.New(), userResults.UserID, taskResults.TaskID, taskResults.LessonID, userResults.Mark, submittedAt, userResults.Penalty, tenantID)
 	}
 
-	results := pg.conn.SendBatch(ctx, batch)
+	results := conn.SendBatch(ctx, batch)
 	defer results.Close()
 
 	for range taskResults.UsersResult {
@@ -204,15 +335,16 @@ func (pg *RepositoryPG) SetTaskResultsByUsers(ctx context.Context, taskResults *
 	return nil
 }
 
-func (pg *RepositoryPG) DeleteAssignment(ctx context.Context, assignmentID uuid.UUID) error {
-	tag, err := pg.conn.Exec(ctx, "DELETE FROM assignment WHERE id=$1", assignmentID)
+func (pg *RepositoryPG) DeleteAssignment(ctx context.Context, assignmentID uuid.UUID, version int, deletedBy *uuid.UUID) error {
+	tag, err := pg.conn.Exec(ctx, "UPDATE assignment SET deleted_at = now(), deleted_by = $2 WHERE id = $1 AND tenant_id = $4 AND deleted_at IS NULL AND ($3::int = 0 OR version = $3)",
+		assignmentID, deletedBy, version, domain.TenantFromContext(ctx))
 	if err != nil {
 		return err
 	}
 
 	rowsAffected := tag.RowsAffected()
 	if rowsAffected == 0 {
-		return domain.ErrAssignmentNotFound
+		return versionConflict(ctx, pg.conn, "assignment", assignmentID, domain.ErrAssignmentNotFound)
 	}
 
 	return nil
@@ -226,14 +358,16 @@ func (pg *RepositoryPG) CreateTaskWithAssignments(ctx context.Context, assignmen
 
 	defer tx.Rollback(ctx)
 
-	_, err = tx.Exec(ctx, "INSERT INTO task (id, payload, deadline) VALUES($1, $2, $3)", assignment.TaskID, assignment.Payload, assignment.Deadline)
+	tenantID := domain.TenantFromContext(ctx)
+	_, err = tx.Exec(ctx, "INSERT INTO task (id, tenant_id, payload, deadline, author_id) VALUES($1, $2, $3, $4, $5)",
+		assignment.TaskID, tenantID, assignment.Payload, assignment.Deadline, assignment.AuthorID)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("can't create new assignment records:%w", err)
 	}
 
 	var id uuid.UUID
-	err = tx.QueryRow(ctx, "INSERT INTO assignment (id, class, task_id, lesson_id, task_payload, deadline) VALUES($1, $2, $3, $4, $5, $6) RETURNING id",
-		uuid.New(), assignment.Class, assignment.TaskID, assignment.LessonID, assignment.Payload, assignment.Deadline).Scan(&id)
+	err = tx.QueryRow(ctx, "INSERT INTO assignment (id, tenant_id, class, task_id, lesson_id, task_payload, deadline, status, publish_at, class_id) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, "+classID("$2", "$3")+") RETURNING id",
+		uuid.New(), tenantID, assignment.Class, assignment.TaskID, assignment.LessonID, assignment.Payload, assignment.Deadline, assignment.Status, assignment.PublishAt).Scan(&id)
 	if err != nil {
 		return id, fmt.Errorf("can't create new assignment records:%w", err)
 	}
@@ -244,3 +378,196 @@ func (pg *RepositoryPG) CreateTaskWithAssignments(ctx context.Context, assignmen
 
 	return id, nil
 }
+
+func (pg *RepositoryPG) SetAssignmentLatePolicy(ctx context.Context, policy *domain.AssignmentLatePolicy) error {
+	tag, err := pg.conn.Exec(ctx, "UPDATE assignment SET late_policy = $1, late_penalty_per_day = $2, version = version + 1 WHERE id = $3 AND tenant_id = $4 AND deleted_at IS NULL",
+		policy.Policy, policy.PenaltyPerDay, policy.AssignmentID, domain.TenantFromContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAssignmentNotFound
+	}
+
+	return nil
+}
+
+func (pg *RepositoryPG) SetDeadlineExtension(ctx context.Context, extension *domain.DeadlineExtension) error {
+	sql := `INSERT INTO deadline_extension (assignment_id, user_id, deadline)
+		SELECT id, $2, $3 FROM assignment WHERE id = $1 AND tenant_id = $4 AND deleted_at IS NULL
+		ON CONFLICT (assignment_id, user_id) DO UPDATE SET deadline = $3`
+	tag, err := pg.conn.Exec(ctx, sql, extension.AssignmentID, extension.UserID, extension.Deadline, domain.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("can't set deadline extension: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAssignmentNotFound
+	}
+
+	return nil
+}
+
+func (pg *RepositoryPG) DeleteDeadlineExtension(ctx context.Context, assignmentID, userID uuid.UUID) error {
+	tag, err := pg.conn.Exec(ctx, `DELETE FROM deadline_extension e USING assignment a
+		WHERE a.id = e.assignment_id AND e.assignment_id = $1 AND e.user_id = $2 AND a.tenant_id = $3`, assignmentID, userID, domain.TenantFromContext(ctx))
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrDeadlineExtensionNotFound
+	}
+
+	return nil
+}
+
+func (pg *RepositoryPG) GetAssignmentDeadline(ctx context.Context, assignmentID uuid.UUID) (*domain.AssignmentDeadline, error) {
+	deadline := domain.AssignmentDeadline{
+		AssignmentID: assignmentID,
+		Extensions:   make(map[uuid.UUID]time.Time),
+		Submissions:  make(map[uuid.UUID]domain.Submission),
+	}
+
+	err := pg.conn.QueryRow(ctx, "SELECT deadline, late_policy, late_penalty_per_day FROM assignment WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL", assignmentID, domain.TenantFromContext(ctx)).
+		Scan(&deadline.Deadline, &deadline.Policy, &deadline.PenaltyPerDay)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		return nil, err
+	}
+
+	rows, err := pg.conn.Query(ctx, "SELECT user_id, deadline FROM deadline_extension WHERE assignment_id = $1", assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			userID   uuid.UUID
+			extended time.Time
+		)
+		if err := rows.Scan(&userID, &extended); err != nil {
+			return nil, fmt.Errorf("error scanning extension row: %w", err)
+		}
+		deadline.Extensions[userID] = extended
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating extension rows: %w", err)
+	}
+
+	submissions, err := pg.conn.Query(ctx, "SELECT user_id, submitted_at, penalty FROM usersMark WHERE task_id = $1 AND tenant_id = $2",
+		assignmentID, domain.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	defer submissions.Close()
+
+	for submissions.Next() {
+		var (
+			userID      uuid.UUID
+			submittedAt *time.Time
+			submission  domain.Submission
+		)
+		if err := submissions.Scan(&userID, &submittedAt, &submission.Penalty); err != nil {
+			return nil, fmt.Errorf("error scanning submission row: %w", err)
+		}
+		if submittedAt != nil {
+			submission.SubmittedAt = *submittedAt
+		}
+		deadline.Submissions[userID] = submission
+	}
+
+	if err := submissions.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating submission rows: %w", err)
+	}
+
+	return &deadline, nil
+}
+
+func (pg *RepositoryPG) PublishAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.Assignment, error) {
+	assignment := domain.Assignment{
+		AssignmentID: assignmentID,
+		Status:       domain.AssignmentStatusPublished,
+	}
+
+	tenantID := domain.TenantFromContext(ctx)
+	err := pg.conn.QueryRow(ctx, "UPDATE assignment SET status = $1, publish_at = now(), version = version + 1 WHERE id = $2 AND tenant_id = $4 AND status = $3 AND deleted_at IS NULL RETURNING class, lesson_id",
+		domain.AssignmentStatusPublished, assignmentID, domain.AssignmentStatusDraft, tenantID).Scan(&assignment.Class, &assignment.LessonID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+
+		var exists bool
+		err = pg.conn.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM assignment WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL)", assignmentID, tenantID).Scan(&exists)
+		if err != nil {
+			return nil, err
+		}
+		if !exists {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		return nil, domain.ErrAssignmentAlreadyPublished
+	}
+
+	return &assignment, nil
+}
+
+// PublishDueAssignments flips every draft whose publish time has come to published in a single statement,
+// so concurrent replicas never publish the same assignment twice. It works across all schools.
+func (pg *RepositoryPG) PublishDueAssignments(ctx context.Context, now time.Time) ([]domain.Assignment, error) {
+	rows, err := pg.conn.Query(ctx, "UPDATE assignment SET status = $1, version = version + 1 WHERE status = $2 AND publish_at <= $3 AND deleted_at IS NULL RETURNING id, class, lesson_id, tenant_id",
+		domain.AssignmentStatusPublished, domain.AssignmentStatusDraft, now)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	defer rows.Close()
+
+	var assignments []domain.Assignment
+	for rows.Next() {
+		assignment := domain.Assignment{Status: domain.AssignmentStatusPublished}
+		err := rows.Scan(
+			&assignment.AssignmentID,
+			&assignment.Class,
+			&assignment.LessonID,
+			&assignment.TenantID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning assignment row: %w", err)
+		}
+		assignments = append(assignments, assignment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating assignment rows: %w", err)
+	}
+
+	return assignments, nil
+}
+
+type querier interface {
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// versionConflict tells a stale version apart from a missing row after a conditional write matched nothing.
+// A row of another school counts as missing.
+func versionConflict(ctx context.Context, q querier, table string, id uuid.UUID, notFound error) error {
+	var exists bool
+	err := q.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM "+table+" WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NULL)", id, domain.TenantFromContext(ctx)).Scan(&exists)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		return domain.ErrVersionMismatch
+	}
+
+	return notFound
+}
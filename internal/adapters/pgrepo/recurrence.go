@@ -0,0 +1,327 @@
+package pgrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+func (pg *RepositoryPG) CreateRecurrence(ctx context.Context, recurrence *domain.Recurrence) error {
+	var offset *int64
+	if recurrence.DeadlineOffset != nil {
+		seconds := int64(recurrence.DeadlineOffset.Seconds())
+		offset = &seconds
+	}
+
+	sql := `INSERT INTO assignment_recurrence (id, tenant_id, task_id, class, lesson_id, rrule, starts_at, timezone, deadline_offset_seconds)
+		SELECT $1, tenant_id, id, $3, $4, $5, $6, $7, $8 FROM task WHERE id = $2 AND tenant_id = $9 AND deleted_at IS NULL`
+	tag, err := pg.conn.Exec(ctx, sql, recurrence.ID, recurrence.TaskID, recurrence.Class, recurrence.LessonID,
+		recurrence.Rule.String(), recurrence.StartsAt, recurrence.Timezone, offset, domain.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("can't create recurrence: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+func (pg *RepositoryPG) GetRecurrence(ctx context.Context, id uuid.UUID) (*domain.Recurrence, error) {
+	sql := "SELECT id, task_id, class, lesson_id, rrule, starts_at, timezone, deadline_offset_seconds, materialized_until FROM assignment_recurrence WHERE id = $1 AND tenant_id = $2 AND stopped_at IS NULL"
+
+	recurrence, err := scanRecurrence(pg.conn.QueryRow(ctx, sql, id, domain.TenantFromContext(ctx)))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrRecurrenceNotFound
+		}
+		return nil, err
+	}
+
+	return recurrence, nil
+}
+
+// StopRecurrence ends the series and removes its occurrences that haven't been published yet.
+func (pg *RepositoryPG) StopRecurrence(ctx context.Context, id uuid.UUID) error {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	tenantID := domain.TenantFromContext(ctx)
+	tag, err := tx.Exec(ctx, "UPDATE assignment_recurrence SET stopped_at = now() WHERE id = $1 AND tenant_id = $2 AND stopped_at IS NULL", id, tenantID)
+	if err != nil {
+		return err
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrRecurrenceNotFound
+	}
+
+	_, err = tx.Exec(ctx, "DELETE FROM assignment WHERE recurrence_id = $1 AND tenant_id = $3 AND status = $2 AND deleted_at IS NULL",
+		id, domain.AssignmentStatusDraft, tenantID)
+	if err != nil {
+		return fmt.Errorf("can't delete pending occurrences: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SetOccurrenceException records a skip or an override of a single occurrence and applies it
+// to the occurrence if it has already been materialized. The recurrence must belong to the school of ctx.
+func (pg *RepositoryPG) SetOccurrenceException(ctx context.Context, exception *domain.OccurrenceException) error {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	tenantID := domain.TenantFromContext(ctx)
+	var exists bool
+	err = tx.QueryRow(ctx, "SELECT EXISTS(SELECT 1 FROM assignment_recurrence WHERE id = $1 AND tenant_id = $2 AND stopped_at IS NULL)",
+		exception.RecurrenceID, tenantID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return domain.ErrRecurrenceNotFound
+	}
+
+	if exception.Skipped {
+		var status domain.AssignmentStatus
+		err = tx.QueryRow(ctx, "SELECT status FROM assignment WHERE recurrence_id = $1 AND occurrence_at = $2 AND tenant_id = $3 AND deleted_at IS NULL FOR UPDATE",
+			exception.RecurrenceID, exception.OccurrenceAt, tenantID).Scan(&status)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+
+		if status == domain.AssignmentStatusPublished {
+			return domain.ErrAssignmentAlreadyPublished
+		}
+
+		_, err = tx.Exec(ctx, "DELETE FROM assignment WHERE recurrence_id = $1 AND occurrence_at = $2 AND tenant_id = $3 AND deleted_at IS NULL",
+			exception.RecurrenceID, exception.OccurrenceAt, tenantID)
+		if err != nil {
+			return fmt.Errorf("can't delete occurrence: %w", err)
+		}
+	} else {
+		_, err = tx.Exec(ctx, "UPDATE assignment SET task_payload = COALESCE($1, task_payload), deadline = COALESCE($2, deadline), version = version + 1 WHERE recurrence_id = $3 AND occurrence_at = $4 AND tenant_id = $5 AND deleted_at IS NULL",
+			exception.Payload, exception.Deadline, exception.RecurrenceID, exception.OccurrenceAt, tenantID)
+		if err != nil {
+			return fmt.Errorf("can't update occurrence: %w", err)
+		}
+	}
+
+	sql := `INSERT INTO assignment_recurrence_exception (recurrence_id, occurrence_at, skipped, payload, deadline) VALUES($1, $2, $3, $4, $5)
+		ON CONFLICT (recurrence_id, occurrence_at) DO UPDATE SET
+		skipped = EXCLUDED.skipped,
+		payload = COALESCE(EXCLUDED.payload, assignment_recurrence_exception.payload),
+		deadline = COALESCE(EXCLUDED.deadline, assignment_recurrence_exception.deadline)`
+	_, err = tx.Exec(ctx, sql, exception.RecurrenceID, exception.OccurrenceAt, exception.Skipped, exception.Payload, exception.Deadline)
+	if err != nil {
+		return fmt.Errorf("can't save occurrence exception: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+type pendingRecurrence struct {
+	*domain.Recurrence
+	payload  string
+	tenantID uuid.UUID
+}
+
+// MaterializeRecurrences creates the assignments of every active recurrence up to `until`.
+// Occurrences that already started are created published, later ones as drafts published at the occurrence time.
+// Recurrences are locked with SKIP LOCKED, so replicas split the work instead of duplicating it.
+// It works across all schools; every occurrence belongs to the school of its recurrence.
+func (pg *RepositoryPG) MaterializeRecurrences(ctx context.Context, now, until time.Time) ([]domain.Assignment, error) {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	sql := `SELECT r.id, r.task_id, r.class, r.lesson_id, r.rrule, r.starts_at, r.timezone, r.deadline_offset_seconds, r.materialized_until, t.payload, r.tenant_id
+		FROM assignment_recurrence r JOIN task t ON t.id = r.task_id
+		WHERE r.stopped_at IS NULL AND t.deleted_at IS NULL AND (r.materialized_until IS NULL OR r.materialized_until < $1)
+		FOR UPDATE OF r SKIP LOCKED`
+	rows, err := tx.Query(ctx, sql, until)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	var pending []pendingRecurrence
+	for rows.Next() {
+		var payload string
+		var tenantID uuid.UUID
+		recurrence, err := scanRecurrence(rows, &payload, &tenantID)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("error scanning recurrence row: %w", err)
+		}
+		pending = append(pending, pendingRecurrence{Recurrence: recurrence, payload: payload, tenantID: tenantID})
+	}
+
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating recurrence rows: %w", err)
+	}
+
+	var assignments []domain.Assignment
+	for _, recurrence := range pending {
+		materialized, err := materializeRecurrence(ctx, tx, recurrence, now, until)
+		if err != nil {
+			return nil, err
+		}
+		assignments = append(assignments, materialized...)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func materializeRecurrence(ctx context.Context, tx pgx.Tx, recurrence pendingRecurrence, now, until time.Time) ([]domain.Assignment, error) {
+	exceptions, err := occurrenceExceptions(ctx, tx, recurrence.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	sql := `INSERT INTO assignment (id, class, task_id, lesson_id, task_payload, deadline, status, publish_at, recurrence_id, occurrence_at, tenant_id, class_id)
+		VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, ` + classID("$11", "$2") + `) ON CONFLICT DO NOTHING RETURNING id`
+
+	var assignments []domain.Assignment
+	for _, occurrence := range recurrence.Rule.Occurrences(recurrence.StartsAt, recurrence.MaterializedUntil, until) {
+		payload, deadline := recurrence.payload, recurrence.Deadline(occurrence)
+		if exception, ok := exceptions[occurrence.UnixMicro()]; ok {
+			if exception.Skipped {
+				continue
+			}
+			if exception.Payload != nil {
+				payload = *exception.Payload
+			}
+			if exception.Deadline != nil {
+				deadline = exception.Deadline
+			}
+		}
+
+		assignment := domain.Assignment{
+			Class:    recurrence.Class,
+			LessonID: recurrence.LessonID,
+			Status:   domain.NewAssignmentStatus(false, &occurrence, now),
+			TenantID: recurrence.tenantID,
+		}
+
+		err := tx.QueryRow(ctx, sql, uuid.New(), recurrence.Class, recurrence.TaskID, recurrence.LessonID, payload, deadline,
+			assignment.Status, occurrence, recurrence.ID, occurrence, recurrence.tenantID).Scan(&assignment.AssignmentID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return nil, fmt.Errorf("unnable create occurrence %w", err)
+		}
+
+		assignments = append(assignments, assignment)
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE assignment_recurrence SET materialized_until = $1 WHERE id = $2", until, recurrence.ID)
+	if err != nil {
+		return nil, fmt.Errorf("can't update recurrence: %w", err)
+	}
+
+	return assignments, nil
+}
+
+func occurrenceExceptions(ctx context.Context, tx pgx.Tx, recurrenceID uuid.UUID) (map[int64]domain.OccurrenceException, error) {
+	rows, err := tx.Query(ctx, "SELECT occurrence_at, skipped, payload, deadline FROM assignment_recurrence_exception WHERE recurrence_id = $1", recurrenceID)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	defer rows.Close()
+
+	exceptions := make(map[int64]domain.OccurrenceException)
+	for rows.Next() {
+		exception := domain.OccurrenceException{RecurrenceID: recurrenceID}
+		err := rows.Scan(
+			&exception.OccurrenceAt,
+			&exception.Skipped,
+			&exception.Payload,
+			&exception.Deadline,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning exception row: %w", err)
+		}
+		exceptions[exception.OccurrenceAt.UnixMicro()] = exception
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating exception rows: %w", err)
+	}
+
+	return exceptions, nil
+}
+
+func scanRecurrence(row pgx.Row, extra ...any) (*domain.Recurrence, error) {
+	var (
+		recurrence domain.Recurrence
+		rule       string
+		offset     *int64
+	)
+
+	dest := append([]any{
+		&recurrence.ID,
+		&recurrence.TaskID,
+		&recurrence.Class,
+		&recurrence.LessonID,
+		&rule,
+		&recurrence.StartsAt,
+		&recurrence.Timezone,
+		&offset,
+		&recurrence.MaterializedUntil,
+	}, extra...)
+
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	parsed, err := domain.ParseRRule(rule)
+	if err != nil {
+		return nil, err
+	}
+	recurrence.Rule = parsed
+
+	location, err := time.LoadLocation(recurrence.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("recurrence %s: %w", recurrence.ID, err)
+	}
+	recurrence.StartsAt = recurrence.StartsAt.In(location)
+
+	if offset != nil {
+		deadlineOffset := time.Duration(*offset) * time.Second
+		recurrence.DeadlineOffset = &deadlineOffset
+	}
+
+	return &recurrence, nil
+}
@@ -0,0 +1,121 @@
+package pgrepo
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"task/internal/domain"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	deadlineRemindersLock = "deadline_reminders"
+	deadlinePassedKind    = "passed"
+)
+
+// claimReminderSQL records the notification for every published assignment whose deadline lies in ($2, $3]
+// and returns the assignments that had not been notified for this kind yet, of all schools.
+const claimReminderSQL = `WITH claimed AS (
+	INSERT INTO deadline_notification (assignment_id, kind)
+	SELECT id, $1 FROM assignment
+	WHERE status = $4 AND deleted_at IS NULL AND deadline IS NOT NULL AND deadline > $2 AND deadline <= $3
+	ON CONFLICT DO NOTHING
+	RETURNING assignment_id
+)
+SELECT a.id, a.class, a.lesson_id, a.deadline, a.tenant_id FROM assignment a JOIN claimed c ON c.assignment_id = a.id`
+
+// ClaimDeadlineReminders marks due reminders as sent, hands them to send and returns how many there were.
+// The claim is committed only after send succeeds, so reminders that failed to go out are claimed again on the next
+// run. Replicas serialize on a transaction-level advisory lock; a replica that can't take it claims nothing
+// and leaves the work to the lock holder.
+func (pg *RepositoryPG) ClaimDeadlineReminders(ctx context.Context, now time.Time, windows []time.Duration,
+	send func([]domain.DeadlineReminder) error) (int, error) {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	var locked bool
+	err = tx.QueryRow(ctx, "SELECT pg_try_advisory_xact_lock(hashtext($1))", deadlineRemindersLock).Scan(&locked)
+	if err != nil {
+		return 0, fmt.Errorf("can't take advisory lock: %w", err)
+	}
+	if !locked {
+		return 0, nil
+	}
+
+	// Largest window first: a deadline only falls into the narrowest window it has reached,
+	// so an assignment created an hour before its deadline doesn't get the day-before reminder too.
+	windows = slices.Clone(windows)
+	slices.SortFunc(windows, func(a, b time.Duration) int { return cmp.Compare(b, a) })
+
+	var reminders []domain.DeadlineReminder
+	for i, window := range windows {
+		lower := now
+		if i+1 < len(windows) {
+			lower = now.Add(windows[i+1])
+		}
+
+		claimed, err := claimReminders(ctx, tx, "approaching:"+window.String(), lower, now.Add(window), window)
+		if err != nil {
+			return 0, err
+		}
+		reminders = append(reminders, claimed...)
+	}
+
+	passed, err := claimReminders(ctx, tx, deadlinePassedKind, time.Time{}, now, 0)
+	if err != nil {
+		return 0, err
+	}
+	reminders = append(reminders, passed...)
+
+	if len(reminders) == 0 {
+		return 0, nil
+	}
+
+	if err := send(reminders); err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return len(reminders), nil
+}
+
+func claimReminders(ctx context.Context, tx pgx.Tx, kind string, from, to time.Time, window time.Duration) ([]domain.DeadlineReminder, error) {
+	rows, err := tx.Query(ctx, claimReminderSQL, kind, from, to, domain.AssignmentStatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	defer rows.Close()
+
+	var reminders []domain.DeadlineReminder
+	for rows.Next() {
+		reminder := domain.DeadlineReminder{Window: window}
+		err := rows.Scan(
+			&reminder.AssignmentID,
+			&reminder.Class,
+			&reminder.LessonID,
+			&reminder.Deadline,
+			&reminder.TenantID,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning reminder row: %w", err)
+		}
+		reminders = append(reminders, reminder)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reminder rows: %w", err)
+	}
+
+	return reminders, nil
+}
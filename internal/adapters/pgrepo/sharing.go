@@ -0,0 +1,124 @@
+package pgrepo
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+)
+
+// visibleTask limits a query on task to the templates the user in $2 may see: those without an author, which predate
+// authorship, their own and those shared with them or with the whole school. Without a user only templates without
+// an author are visible, see domain.TaskAccess.
+const visibleTask = `(task.author_id IS NULL OR task.author_id = $2 OR ($2::uuid IS NOT NULL AND EXISTS (
+	SELECT 1 FROM task_share s WHERE s.task_id = task.id AND (s.user_id = $2 OR s.user_id IS NULL))))`
+
+// GetTaskAccess returns the author of every found template and the highest permission shared with the user.
+// Templates in the trash are found too, their restore depends on it.
+func (pg *RepositoryPG) GetTaskAccess(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error) {
+	rows, err := pg.conn.Query(ctx, `SELECT t.id, t.author_id, coalesce(array_agg(s.permission) FILTER (WHERE s.permission IS NOT NULL), '{}')
+		FROM task t
+		LEFT JOIN task_share s ON s.task_id = t.id AND (s.user_id = $3 OR s.user_id IS NULL)
+		WHERE t.id = ANY($1) AND t.tenant_id = $2
+		GROUP BY t.id, t.author_id`, ids, domain.TenantFromContext(ctx), userID)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	return scanTaskAccess(rows, len(ids))
+}
+
+// GetAssignmentAccess is GetTaskAccess for the templates of the assignments, keyed by the assignment.
+// Assignments in the trash aren't found.
+func (pg *RepositoryPG) GetAssignmentAccess(ctx context.Context, assignmentIDs []uuid.UUID, userID *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error) {
+	rows, err := pg.conn.Query(ctx, `SELECT a.id, t.author_id, coalesce(array_agg(s.permission) FILTER (WHERE s.permission IS NOT NULL), '{}')
+		FROM assignment a
+		JOIN task t ON t.id = a.task_id
+		LEFT JOIN task_share s ON s.task_id = t.id AND (s.user_id = $3 OR s.user_id IS NULL)
+		WHERE a.id = ANY($1) AND a.tenant_id = $2 AND a.deleted_at IS NULL
+		GROUP BY a.id, t.author_id`, assignmentIDs, domain.TenantFromContext(ctx), userID)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	return scanTaskAccess(rows, len(assignmentIDs))
+}
+
+// scanTaskAccess reads rows of an ID, the author and the shared permissions.
+func scanTaskAccess(rows pgx.Rows, n int) (map[uuid.UUID]domain.TaskAccess, error) {
+	defer rows.Close()
+
+	access := make(map[uuid.UUID]domain.TaskAccess, n)
+	for rows.Next() {
+		var (
+			id          uuid.UUID
+			authorID    *uuid.UUID
+			permissions []domain.Permission
+		)
+		err := rows.Scan(&id, &authorID, &permissions)
+		if err != nil {
+			return nil, fmt.Errorf("error scanning task access row: %w", err)
+		}
+		access[id] = domain.TaskAccess{AuthorID: authorID, Shared: domain.HighestPermission(permissions)}
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating task access rows: %w", err)
+	}
+
+	return access, nil
+}
+
+// ShareTask grants the permission or changes the one granted before to the same user or school.
+func (pg *RepositoryPG) ShareTask(ctx context.Context, share *domain.TaskShare) error {
+	tag, err := pg.conn.Exec(ctx, `INSERT INTO task_share (task_id, user_id, permission)
+		SELECT id, $2, $3 FROM task WHERE id = $1 AND tenant_id = $4 AND deleted_at IS NULL
+		ON CONFLICT (task_id, user_id) DO UPDATE SET permission = EXCLUDED.permission`,
+		share.TaskID, share.UserID, share.Permission, domain.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("can't share task: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTaskNotFound
+	}
+
+	return nil
+}
+
+// DeleteTaskShare revokes the permission of the user, or of the school when userID is nil.
+func (pg *RepositoryPG) DeleteTaskShare(ctx context.Context, taskID uuid.UUID, userID *uuid.UUID) error {
+	tag, err := pg.conn.Exec(ctx, `DELETE FROM task_share s USING task t
+		WHERE t.id = s.task_id AND s.task_id = $1 AND s.user_id IS NOT DISTINCT FROM $2 AND t.tenant_id = $3`,
+		taskID, userID, domain.TenantFromContext(ctx))
+	if err != nil {
+		return fmt.Errorf("can't delete task share: %w", err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrTaskShareNotFound
+	}
+
+	return nil
+}
+
+func (pg *RepositoryPG) GetTaskShares(ctx context.Context, taskID uuid.UUID) ([]domain.TaskShare, error) {
+	rows, err := pg.conn.Query(ctx, `SELECT s.task_id, s.user_id, s.permission FROM task_share s JOIN task t ON t.id = s.task_id
+		WHERE s.task_id = $1 AND t.tenant_id = $2 ORDER BY s.created_at`, taskID, domain.TenantFromContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	shares, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.TaskShare, error) {
+		var share domain.TaskShare
+		err := row.Scan(&share.TaskID, &share.UserID, &share.Permission)
+		return share, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning task share row: %w", err)
+	}
+
+	return shares, nil
+}
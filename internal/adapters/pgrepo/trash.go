@@ -0,0 +1,191 @@
+package pgrepo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgerrcode"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// GetTrash returns the deleted templates the user may see and the deleted assignments of those templates, see visibleTask.
+func (pg *RepositoryPG) GetTrash(ctx context.Context, userID *uuid.UUID) (*domain.Trash, error) {
+	var trash domain.Trash
+	tenantID := domain.TenantFromContext(ctx)
+
+	rows, err := pg.conn.Query(ctx, "SELECT id, payload, deadline, deleted_at, deleted_by FROM task WHERE tenant_id = $1 AND deleted_at IS NOT NULL AND "+visibleTask+" ORDER BY deleted_at DESC",
+		tenantID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	trash.Tasks, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.DeletedTask, error) {
+		var task domain.DeletedTask
+		err := row.Scan(
+			&task.ID,
+			&task.Payload,
+			&task.Deadline,
+			&task.DeletedAt,
+			&task.DeletedBy,
+		)
+		return task, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning task row: %w", err)
+	}
+
+	rows, err = pg.conn.Query(ctx, `SELECT a.id, a.task_id, a.class, a.lesson_id, a.task_payload, a.deleted_at, a.deleted_by
+		FROM assignment a JOIN task ON task.id = a.task_id
+		WHERE a.tenant_id = $1 AND a.deleted_at IS NOT NULL AND `+visibleTask+` ORDER BY a.deleted_at DESC`, tenantID, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error executing prepared statement: %w", err)
+	}
+
+	trash.Assignments, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (domain.DeletedAssignment, error) {
+		var assignment domain.DeletedAssignment
+		err := row.Scan(
+			&assignment.AssignmentID,
+			&assignment.TaskID,
+			&assignment.Class,
+			&assignment.LessonID,
+			&assignment.Payload,
+			&assignment.DeletedAt,
+			&assignment.DeletedBy,
+		)
+		return assignment, err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error scanning assignment row: %w", err)
+	}
+
+	return &trash, nil
+}
+
+// RestoreTask takes the task out of the trash along with the assignments that were deleted with it.
+func (pg *RepositoryPG) RestoreTask(ctx context.Context, id uuid.UUID) error {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	tenantID := domain.TenantFromContext(ctx)
+	var deletedAt time.Time
+	err = tx.QueryRow(ctx, "SELECT deleted_at FROM task WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL FOR UPDATE", id, tenantID).Scan(&deletedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrTaskNotFound
+		}
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE task SET deleted_at = NULL, deleted_by = NULL, version = version + 1 WHERE id = $1 AND tenant_id = $2", id, tenantID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(ctx, "UPDATE assignment SET deleted_at = NULL, deleted_by = NULL, version = version + 1 WHERE task_id = $1 AND tenant_id = $3 AND deleted_at = $2",
+		id, deletedAt, tenantID)
+	if err != nil {
+		return restoreError(err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return nil
+}
+
+// GetDeletedAssignment returns the assignment if it is in the trash.
+func (pg *RepositoryPG) GetDeletedAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.DeletedAssignment, error) {
+	var assignment domain.DeletedAssignment
+	err := pg.conn.QueryRow(ctx, "SELECT id, task_id, class, lesson_id, task_payload, deleted_at, deleted_by FROM assignment WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL",
+		assignmentID, domain.TenantFromContext(ctx)).Scan(
+		&assignment.AssignmentID,
+		&assignment.TaskID,
+		&assignment.Class,
+		&assignment.LessonID,
+		&assignment.Payload,
+		&assignment.DeletedAt,
+		&assignment.DeletedBy,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, domain.ErrAssignmentNotFound
+		}
+		return nil, err
+	}
+
+	return &assignment, nil
+}
+
+func (pg *RepositoryPG) RestoreAssignment(ctx context.Context, assignmentID uuid.UUID) error {
+	tenantID := domain.TenantFromContext(ctx)
+	var taskDeleted bool
+	err := pg.conn.QueryRow(ctx, `SELECT t.deleted_at IS NOT NULL FROM assignment a JOIN task t ON t.id = a.task_id
+		WHERE a.id = $1 AND a.tenant_id = $2 AND a.deleted_at IS NOT NULL`, assignmentID, tenantID).Scan(&taskDeleted)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ErrAssignmentNotFound
+		}
+		return err
+	}
+
+	if taskDeleted {
+		return domain.ErrTaskInTrash
+	}
+
+	tag, err := pg.conn.Exec(ctx, "UPDATE assignment SET deleted_at = NULL, deleted_by = NULL, version = version + 1 WHERE id = $1 AND tenant_id = $2 AND deleted_at IS NOT NULL",
+		assignmentID, tenantID)
+	if err != nil {
+		return restoreError(err)
+	}
+
+	if tag.RowsAffected() == 0 {
+		return domain.ErrAssignmentNotFound
+	}
+
+	return nil
+}
+
+// PurgeTrash permanently deletes everything that has been in the trash since before `before`, in all schools.
+func (pg *RepositoryPG) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	tx, err := pg.conn.BeginTx(ctx, pgx.TxOptions{})
+	if err != nil {
+		return 0, fmt.Errorf("starting transaction: %w", err)
+	}
+
+	defer tx.Rollback(ctx)
+
+	assignments, err := tx.Exec(ctx, "DELETE FROM assignment WHERE deleted_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("can't purge assignments: %w", err)
+	}
+
+	tasks, err := tx.Exec(ctx, "DELETE FROM task WHERE deleted_at < $1", before)
+	if err != nil {
+		return 0, fmt.Errorf("can't purge tasks: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("committing transaction: %w", err)
+	}
+
+	return assignments.RowsAffected() + tasks.RowsAffected(), nil
+}
+
+func restoreError(err error) error {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgerrcode.UniqueViolation {
+		return domain.ErrRestoreConflict
+	}
+
+	return fmt.Errorf("can't restore: %w", err)
+}
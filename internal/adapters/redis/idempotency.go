@@ -0,0 +1,51 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"task/pkg/idempotency"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const idempotencyPrefix = keyPrefix + "idempotency:"
+
+// Reserve takes the key with a single SET NX GET, so the stored record comes back in the same command
+// that failed to take the key, even if it is about to expire.
+func (r *Redis) Reserve(ctx context.Context, key string, record *idempotency.Record, ttl time.Duration) (*idempotency.Record, bool, error) {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return nil, false, err
+	}
+
+	stored, err := r.client.SetArgs(ctx, idempotencyPrefix+key, value, redis.SetArgs{Mode: "NX", Get: true, TTL: ttl}).Bytes()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, true, nil
+		}
+		return nil, false, err
+	}
+
+	var storedRecord idempotency.Record
+	if err := json.Unmarshal(stored, &storedRecord); err != nil {
+		return nil, false, fmt.Errorf("invalid idempotency record: %w", err)
+	}
+
+	return &storedRecord, false, nil
+}
+
+func (r *Redis) Complete(ctx context.Context, key string, record *idempotency.Record, ttl time.Duration) error {
+	value, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	return r.client.Set(ctx, idempotencyPrefix+key, value, ttl).Err()
+}
+
+func (r *Redis) Release(ctx context.Context, key string) error {
+	return r.client.Del(ctx, idempotencyPrefix+key).Err()
+}
@@ -8,6 +8,7 @@ import (
 
 	redisLimiter "github.com/go-redis/redis/v8"
 	"github.com/go-redis/redis_rate/v9"
+	"github.com/redis/go-redis/extra/redisotel/v9"
 	"github.com/redis/go-redis/v9"
 )
 
@@ -29,6 +30,11 @@ func New(hosts []string, password string, logger *slog.Logger) (*Redis, *redis_r
 		return nil, nil, fmt.Errorf("error connection to Redis: %w", err)
 	}
 
+	err = redisotel.InstrumentTracing(client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error instrumenting Redis: %w", err)
+	}
+
 	rdb := redisLimiter.NewUniversalClient(&redisLimiter.UniversalOptions{
 		Addrs:    hosts,
 		Password: password,
@@ -43,6 +49,10 @@ func New(hosts []string, password string, logger *slog.Logger) (*Redis, *redis_r
 	}, rateLimiter, nil
 }
 
+func (rdb *Redis) Ping(ctx context.Context) error {
+	return rdb.client.Ping(ctx).Err()
+}
+
 func (rdb *Redis) Close() {
 	if err := rdb.client.Close(); err != nil {
 		rdb.logger.Error("error closing connection:", slog.String("error", err.Error()))
@@ -1,24 +1,52 @@
 package app
 
 import (
+	"context"
 	"log/slog"
 	"os"
 	"task/internal/adapters/brokers/kafka"
 	"task/internal/adapters/pgrepo"
 	"task/internal/adapters/redis"
 	"task/internal/config"
+	graphqlserver "task/internal/ports/graphqlServer"
+	grpcserver "task/internal/ports/grpcServer"
 	httpserver "task/internal/ports/httpServer"
 	"task/internal/services"
+	"task/internal/workers"
 	"task/pkg/database"
+	"task/pkg/health"
+	"task/pkg/metrics"
+	"task/pkg/tracing"
+	"time"
 )
 
+const traceFlushTimeout = 5 * time.Second
+
 type App struct {
-	Server   *httpserver.Server
-	Postgres *database.Postgres
-	Redis    *redis.Redis
+	Server       *httpserver.Server
+	GRPCServer   *grpcserver.Server
+	Publisher    *workers.Publisher
+	Reminder     *workers.Reminder
+	Materializer *workers.Materializer
+	Purger       *workers.Purger
+	Postgres     *database.Postgres
+	Redis        *redis.Redis
+	// flushTraces sends the spans still buffered when the app stops.
+	flushTraces func(context.Context) error
+	logger      *slog.Logger
 }
 
 func InitApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
+	flushTraces, err := tracing.Init(context.Background(), tracing.Config{
+		Exporter:    cfg.Tracing.Exporter,
+		ServiceName: cfg.Tracing.ServiceName,
+		Endpoint:    cfg.Tracing.Endpoint,
+		SampleRatio: cfg.Tracing.SampleRatio,
+	})
+	if err != nil {
+		return nil, err
+	}
+
 	postgres, err := database.NewPG(cfg.Postgres.PostgresURL)
 	if err != nil {
 		return nil, err
@@ -36,23 +64,53 @@ func InitApp(cfg *config.Config, logger *slog.Logger) (*App, error) {
 
 	taskService := services.New(logger, pgrepo.NewRepositoruPG(postgres.GetConn()), rds, kafkaProducer)
 
-	httpServer, err := httpserver.NewHTTPServer(&cfg.Server, logger, taskService, limiter)
+	metrics.RegisterPool(postgres.GetConn())
+
+	checker := health.New(cfg.Server.HealthTimeout,
+		health.Check{Name: "postgres", Check: postgres.Ping},
+		health.Check{Name: "redis", Check: rds.Ping},
+		health.Check{Name: "kafka", Check: kafkaProducer.Ping},
+	)
+
+	graphQLHandler, err := graphqlserver.NewHandler(logger, taskService, &cfg.GraphQL)
 	if err != nil {
 		return nil, err
 	}
 
+	httpServer, err := httpserver.NewHTTPServer(&cfg.Server, logger, taskService, limiter, rds, checker, graphQLHandler.Serve)
+	if err != nil {
+		return nil, err
+	}
+
+	grpcServer := grpcserver.NewGRPCServer(&cfg.GRPC, logger, taskService, checker, cfg.Server.RequireTenant,
+		cfg.Server.GatewaySecret)
+
 	return &App{
-		Server:   httpServer,
-		Postgres: postgres,
-		Redis:    rds,
+		Server:       httpServer,
+		GRPCServer:   grpcServer,
+		Publisher:    workers.NewPublisher(taskService, cfg.Scheduler.PublishInterval, logger),
+		Reminder:     workers.NewReminder(taskService, cfg.Scheduler.ReminderInterval, cfg.Scheduler.ReminderWindows, logger),
+		Materializer: workers.NewMaterializer(taskService, cfg.Scheduler.RecurrenceInterval, cfg.Scheduler.RecurrenceHorizon, logger),
+		Purger:       workers.NewPurger(taskService, cfg.Scheduler.PurgeInterval, cfg.Scheduler.TrashRetention, logger),
+		Postgres:     postgres,
+		Redis:        rds,
+		flushTraces:  flushTraces,
+		logger:       logger,
 	}, nil
 
 }
 
 func (a *App) Shutdown() {
 	a.Server.Stop()
+	a.GRPCServer.Stop()
 	a.Postgres.Close()
 	a.Redis.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), traceFlushTimeout)
+	defer cancel()
+	if err := a.flushTraces(ctx); err != nil {
+		a.logger.Error("failed to flush traces", slog.String("error", err.Error()))
+	}
 }
 
 func InitLogger() *slog.Logger {
@@ -0,0 +1,52 @@
+package app
+
+import (
+	"log/slog"
+	"task/internal/adapters/brokers/kafka"
+	"task/internal/adapters/pgrepo"
+	"task/internal/adapters/redis"
+	"task/internal/config"
+	"task/internal/services"
+	"task/pkg/database"
+)
+
+// Tools is the task service without the HTTP server and workers, for command line tools.
+type Tools struct {
+	TaskService *services.TaskService
+	Postgres    *database.Postgres
+	Redis       *redis.Redis
+	Producer    *kafka.KafkaProducer
+}
+
+func InitTools(cfg *config.Config, logger *slog.Logger) (*Tools, error) {
+	postgres, err := database.NewPG(cfg.Postgres.PostgresURL)
+	if err != nil {
+		return nil, err
+	}
+
+	rds, _, err := redis.New(cfg.Redis.Hosts, cfg.Redis.Password, logger)
+	if err != nil {
+		postgres.Close()
+		return nil, err
+	}
+
+	kafkaProducer, err := kafka.NewProducer(&cfg.Kafka, logger)
+	if err != nil {
+		postgres.Close()
+		rds.Close()
+		return nil, err
+	}
+
+	return &Tools{
+		TaskService: services.New(logger, pgrepo.NewRepositoruPG(postgres.GetConn()), rds, kafkaProducer),
+		Postgres:    postgres,
+		Redis:       rds,
+		Producer:    kafkaProducer,
+	}, nil
+}
+
+func (t *Tools) Shutdown() {
+	t.Producer.Close()
+	t.Postgres.Close()
+	t.Redis.Close()
+}
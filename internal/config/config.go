@@ -11,11 +11,15 @@ import (
 )
 
 type Config struct {
-	Env      string `env:"ENV" env-default:"local"`
-	Postgres PostgresConfig
-	Redis    RedisConfig
-	Server   ServerConfig
-	Kafka    KafkaConfig
+	Env       string `env:"ENV" env-default:"local"`
+	Postgres  PostgresConfig
+	Redis     RedisConfig
+	Server    ServerConfig
+	GRPC      GRPCConfig
+	GraphQL   GraphQLConfig
+	Kafka     KafkaConfig
+	Scheduler SchedulerConfig
+	Tracing   TracingConfig
 }
 
 type ServerConfig struct {
@@ -23,6 +27,48 @@ type ServerConfig struct {
 	ReadTimeout     time.Duration `yaml:"read_timeout" env:"READ_TIMEOUT" env-default:"30s"`
 	WriteTimeout    time.Duration `yaml:"wtite_timeout" env:"WRITE_TIMEOUT" env-default:"30s"`
 	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"SHUTDOWN_TIMEOUT" env-default:"10s"`
+	// RequireIfMatch rejects updates and deletes without an If-Match header with 428.
+	RequireIfMatch bool `yaml:"require_if_match" env:"REQUIRE_IF_MATCH" env-default:"false"`
+	// IdempotencyTTL is how long responses to requests with an Idempotency-Key are kept for replay.
+	IdempotencyTTL time.Duration `yaml:"idempotency_ttl" env:"IDEMPOTENCY_TTL" env-default:"24h"`
+	// CalendarSecret signs the tokens of calendar feed links. Feeds are disabled while it is empty,
+	// and changing it revokes every link handed out before.
+	CalendarSecret string `yaml:"calendar_secret" env:"CALENDAR_SECRET"`
+	// RequireTenant rejects requests without an X-Tenant-ID header with 400. Single-school deployments
+	// turn it off, so such requests belong to the default school.
+	RequireTenant bool `yaml:"require_tenant" env:"REQUIRE_TENANT" env-default:"true"`
+	// GatewaySecret is shared with the gateway that authenticates the users and sets X-Tenant-ID and X-User-ID.
+	// Only requests carrying it in X-Gateway-Secret are served, calendar feeds aside. The servers don't start without it.
+	GatewaySecret string `yaml:"gateway_secret" env:"GATEWAY_SECRET"`
+	// HealthTimeout bounds each dependency check of the readiness probe.
+	HealthTimeout time.Duration `yaml:"health_timeout" env:"HEALTH_TIMEOUT" env-default:"2s"`
+	// DrainDelay is how long the server keeps serving with a failing readiness probe before it shuts down,
+	// so load balancers notice and stop sending it new requests.
+	DrainDelay time.Duration `yaml:"drain_delay" env:"DRAIN_DELAY" env-default:"5s"`
+	// ValidateResponses checks every response against the OpenAPI spec and answers 500 instead of one that
+	// doesn't match. It buffers whole responses, so it is meant for tests and staging.
+	ValidateResponses bool `yaml:"validate_responses" env:"VALIDATE_RESPONSES" env-default:"false"`
+}
+
+// GRPCConfig is the gRPC server for internal services. It shares RequireTenant and GatewaySecret with the HTTP server.
+type GRPCConfig struct {
+	Port            string        `yaml:"port" env:"GRPC_PORT" env-default:"9090"`
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout" env:"GRPC_SHUTDOWN_TIMEOUT" env-default:"10s"`
+}
+
+// GraphQLConfig limits the queries of the GraphQL endpoint, see graphqlserver.Handler.
+type GraphQLConfig struct {
+	MaxDepth      int `yaml:"max_depth" env:"GRAPHQL_MAX_DEPTH" env-default:"6"`
+	MaxComplexity int `yaml:"max_complexity" env:"GRAPHQL_MAX_COMPLEXITY" env-default:"1000"`
+}
+
+type TracingConfig struct {
+	// Exporter is none, stdout for local debugging or otlp.
+	Exporter    string `yaml:"exporter" env:"TRACING_EXPORTER" env-default:"none"`
+	ServiceName string `yaml:"service_name" env:"TRACING_SERVICE_NAME" env-default:"task"`
+	// Endpoint of the OTLP/HTTP collector. When empty the standard OTEL_EXPORTER_OTLP_* variables apply.
+	Endpoint    string  `yaml:"endpoint" env:"TRACING_ENDPOINT"`
+	SampleRatio float64 `yaml:"sample_ratio" env:"TRACING_SAMPLE_RATIO" env-default:"1"`
 }
 
 type PostgresConfig struct {
@@ -39,6 +85,24 @@ type KafkaConfig struct {
 	Topic      string   `yaml:"topic" env-required:"true"`
 }
 
+type SchedulerConfig struct {
+	PublishInterval  time.Duration   `yaml:"publish_interval" env:"PUBLISH_INTERVAL" env-default:"1m"`
+	ReminderInterval time.Duration   `yaml:"reminder_interval" env:"REMINDER_INTERVAL" env-default:"1m"`
+	ReminderWindows  []time.Duration `yaml:"reminder_windows" env:"REMINDER_WINDOWS" env-default:"24h,1h"`
+
+	RecurrenceInterval time.Duration `yaml:"recurrence_interval" env:"RECURRENCE_INTERVAL" env-default:"1m"`
+	RecurrenceHorizon  time.Duration `yaml:"recurrence_horizon" env:"RECURRENCE_HORIZON" env-default:"336h"`
+
+	PurgeInterval  time.Duration `yaml:"purge_interval" env:"PURGE_INTERVAL" env-default:"1h"`
+	TrashRetention time.Duration `yaml:"trash_retention" env:"TRASH_RETENTION" env-default:"720h"`
+}
+
+// The flags are registered with the package, so a command can add its own and parse them all before InitConfig.
+var (
+	envFlag    = flag.String("env", "", "application configuration file")
+	configFlag = flag.String("config", "", "path to config file")
+)
+
 func InitConfig() (*Config, error) {
 	envPath, configPath := fetchConfigPath()
 
@@ -68,11 +132,11 @@ func InitConfig() (*Config, error) {
 }
 
 func fetchConfigPath() (string, string) {
-	var envPath, configPath string
+	if !flag.Parsed() {
+		flag.Parse()
+	}
 
-	flag.StringVar(&envPath, "env", "", "application configuration file")
-	flag.StringVar(&configPath, "config", "", "path to config file")
-	flag.Parse()
+	envPath, configPath := *envFlag, *configFlag
 
 	if envPath == "" {
 		envPath = os.Getenv("ENV_PATH")
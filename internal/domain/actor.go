@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type userIDKey struct{}
+
+// ContextWithUserID stores the ID of the user performing the request.
+func ContextWithUserID(ctx context.Context, userID uuid.UUID) context.Context {
+	return context.WithValue(ctx, userIDKey{}, userID)
+}
+
+// UserIDFromContext returns the ID of the user performing the request, if it is known.
+func UserIDFromContext(ctx context.Context) (uuid.UUID, bool) {
+	userID, ok := ctx.Value(userIDKey{}).(uuid.UUID)
+
+	return userID, ok
+}
@@ -0,0 +1,114 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MaxBulkItems limits the number of items in one bulk request.
+const MaxBulkItems = 1000
+
+type BulkMode string
+
+const (
+	// BulkModeAtomic applies all items in one transaction or none of them.
+	BulkModeAtomic BulkMode = "atomic"
+	// BulkModeBestEffort applies every item that can be applied and reports the rest as failed.
+	BulkModeBestEffort BulkMode = "best_effort"
+)
+
+func (m BulkMode) IsValid() bool {
+	return m == BulkModeAtomic || m == BulkModeBestEffort
+}
+
+type BulkItemStatus string
+
+const (
+	BulkItemDone       BulkItemStatus = "done"
+	BulkItemFailed     BulkItemStatus = "failed"
+	BulkItemRolledBack BulkItemStatus = "rolled_back"
+)
+
+type BulkItemResult struct {
+	Index  int
+	ID     uuid.UUID
+	Status BulkItemStatus
+	Err    error
+}
+
+// BulkResult holds the outcome of every item of a bulk request in request order.
+type BulkResult struct {
+	Mode  BulkMode
+	Items []BulkItemResult
+}
+
+func NewBulkResult(mode BulkMode, n int) *BulkResult {
+	items := make([]BulkItemResult, n)
+	for i := range items {
+		items[i].Index = i
+	}
+
+	return &BulkResult{Mode: mode, Items: items}
+}
+
+func (r *BulkResult) Fail(i int, err error) {
+	r.Items[i].Status = BulkItemFailed
+	r.Items[i].Err = err
+}
+
+func (r *BulkResult) Failed() bool {
+	for _, item := range r.Items {
+		if item.Status == BulkItemFailed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Finish marks the items that haven't failed as done, or as rolled back when an atomic request had a failure.
+func (r *BulkResult) Finish() {
+	status := BulkItemDone
+	if r.Mode == BulkModeAtomic && r.Failed() {
+		status = BulkItemRolledBack
+	}
+
+	for i := range r.Items {
+		if r.Items[i].Status != BulkItemFailed {
+			r.Items[i].Status = status
+		}
+	}
+}
+
+// Applied counts the items that were written.
+func (r *BulkResult) Applied() int {
+	var applied int
+	for _, item := range r.Items {
+		if item.Status == BulkItemDone {
+			applied++
+		}
+	}
+
+	return applied
+}
+
+type BulkAssignment struct {
+	TaskID   uuid.UUID
+	Class    string
+	LessonID uuid.UUID
+}
+
+type BulkAssignments struct {
+	Mode      BulkMode
+	Items     []BulkAssignment
+	Draft     bool
+	PublishAt *time.Time
+	Status    AssignmentStatus
+}
+
+// DeadlineUpdate moves the deadline of an assignment; a nil Deadline removes it.
+type DeadlineUpdate struct {
+	AssignmentID uuid.UUID
+	Deadline     *time.Time
+}
@@ -0,0 +1,48 @@
+package domain
+
+const (
+	TaskChangedEventType       = "TaskChanged"
+	AssignmentChangedEventType = "AssignmentChanged"
+)
+
+type TaskChangedEvent struct {
+	EventTenant
+	TaskID  string   `json:"task_id"`
+	Fields  []string `json:"fields"`
+	Version int      `json:"version"`
+}
+
+func NewTaskChangedEvent(task *Task, fields []string) *TaskChangedEvent {
+	return &TaskChangedEvent{
+		TaskID:  task.ID.String(),
+		Fields:  fields,
+		Version: task.Version,
+	}
+}
+
+func (s *TaskChangedEvent) Type() string {
+	return TaskChangedEventType
+}
+
+type AssignmentChangedEvent struct {
+	EventTenant
+	Class    string   `json:"class"`
+	LessonID string   `json:"lesson_id"`
+	TaskID   string   `json:"task_id"`
+	Fields   []string `json:"fields"`
+	Version  int      `json:"version"`
+}
+
+func NewAssignmentChangedEvent(assignment *TaskAsignment, fields []string) *AssignmentChangedEvent {
+	return &AssignmentChangedEvent{
+		Class:    assignment.Class,
+		LessonID: assignment.LessonID.String(),
+		TaskID:   assignment.AssignmentID.String(),
+		Fields:   fields,
+		Version:  assignment.Version,
+	}
+}
+
+func (s *AssignmentChangedEvent) Type() string {
+	return AssignmentChangedEventType
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Class of a school. Its name is unique within the school and is what assignments, gradebooks
+// and calendars refer to.
+type Class struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// Lesson of a class from the schedule. Its ID usually comes from the schedule service.
+type Lesson struct {
+	ID       uuid.UUID
+	Class    string
+	Subject  string
+	StartsAt *time.Time
+}
+
+const MaxClassNameLength = 32
+
+// NormalizeClassName makes "5a", " 5A" and "5A" the same class.
+func NormalizeClassName(name string) string {
+	return strings.ToUpper(strings.TrimSpace(name))
+}
@@ -0,0 +1,67 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	DeadlineApproachingEventType = "DeadlineApproaching"
+	DeadlinePassedEventType      = "DeadlinePassed"
+)
+
+// DeadlineReminder is an assignment whose deadline entered a reminder window.
+// A zero Window means the deadline has already passed.
+type DeadlineReminder struct {
+	AssignmentID uuid.UUID
+	Class        string
+	LessonID     uuid.UUID
+	Deadline     time.Time
+	Window       time.Duration
+	TenantID     uuid.UUID
+}
+
+type DeadlineApproachingEvent struct {
+	EventTenant
+	Class    string    `json:"class"`
+	LessonID string    `json:"lesson_id"`
+	TaskID   string    `json:"task_id"`
+	Deadline time.Time `json:"deadline"`
+	Window   string    `json:"window"`
+}
+
+func (s *DeadlineApproachingEvent) Type() string {
+	return DeadlineApproachingEventType
+}
+
+type DeadlinePassedEvent struct {
+	EventTenant
+	Class    string    `json:"class"`
+	LessonID string    `json:"lesson_id"`
+	TaskID   string    `json:"task_id"`
+	Deadline time.Time `json:"deadline"`
+}
+
+func (s *DeadlinePassedEvent) Type() string {
+	return DeadlinePassedEventType
+}
+
+func NewDeadlineEvent(reminder DeadlineReminder) Event {
+	if reminder.Window == 0 {
+		return &DeadlinePassedEvent{
+			Class:    reminder.Class,
+			LessonID: reminder.LessonID.String(),
+			TaskID:   reminder.AssignmentID.String(),
+			Deadline: reminder.Deadline,
+		}
+	}
+
+	return &DeadlineApproachingEvent{
+		Class:    reminder.Class,
+		LessonID: reminder.LessonID.String(),
+		TaskID:   reminder.AssignmentID.String(),
+		Deadline: reminder.Deadline,
+		Window:   reminder.Window.String(),
+	}
+}
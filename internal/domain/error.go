@@ -1,8 +1,126 @@
 package domain
 
-import "errors"
+import (
+	"errors"
+	"strings"
+)
+
+// Kind tells what went wrong from the client's point of view, so every transport reports
+// the same error the same way.
+type Kind int
+
+const (
+	// KindInternal is everything else: the client can't fix it and gets no details.
+	KindInternal Kind = iota
+	KindNotFound
+	KindConflict
+	KindValidation
+	KindForbidden
+	// KindLocked means the record can't be changed in its current state, e.g. while it is in the trash.
+	KindLocked
+	// KindPrecondition means the record changed since the client read it.
+	KindPrecondition
+)
+
+// FieldError points at the invalid field of a request.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error is an error the client caused and can act upon. Its message is meant for clients.
+type Error struct {
+	Kind    Kind
+	Message string
+	Fields  []FieldError
+
+	// sentinel is the error this one adds details to.
+	sentinel *Error
+}
+
+func newError(kind Kind, message string) *Error {
+	return &Error{Kind: kind, Message: message}
+}
+
+func (e *Error) Error() string {
+	if len(e.Fields) == 0 {
+		return e.Message
+	}
+
+	details := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		details = append(details, field.Field+" "+field.Message)
+	}
+
+	return e.Message + ": " + strings.Join(details, ", ")
+}
+
+// Is makes the error with details match its sentinel, e.g. errors.Is(err, ErrInvalidPatch).
+func (e *Error) Is(target error) bool {
+	return e.sentinel != nil && e.sentinel == target
+}
+
+// WithField returns the error pointing at the invalid field.
+func (e *Error) WithField(field, message string) *Error {
+	return e.WithFields(FieldError{Field: field, Message: message})
+}
+
+func (e *Error) WithFields(fields ...FieldError) *Error {
+	sentinel := e
+	if e.sentinel != nil {
+		sentinel = e.sentinel
+	}
+
+	return &Error{
+		Kind:     e.Kind,
+		Message:  e.Message,
+		Fields:   append(append([]FieldError(nil), e.Fields...), fields...),
+		sentinel: sentinel,
+	}
+}
+
+// AsError returns the domain error in the chain of err, if there is one.
+func AsError(err error) (*Error, bool) {
+	var domainErr *Error
+	if errors.As(err, &domainErr) {
+		return domainErr, true
+	}
+
+	return nil, false
+}
 
 var (
-	ErrTaskNotFound       = errors.New("task doesn't exist")
-	ErrAssignmentNotFound = errors.New("assignment doesn't exist")
+	ErrTaskNotFound              = newError(KindNotFound, "task doesn't exist")
+	ErrAssignmentNotFound        = newError(KindNotFound, "assignment doesn't exist")
+	ErrDeadlineExtensionNotFound = newError(KindNotFound, "deadline extension doesn't exist")
+	ErrRecurrenceNotFound        = newError(KindNotFound, "recurrence doesn't exist")
+	ErrOccurrenceNotFound        = newError(KindNotFound, "occurrence doesn't exist")
+	ErrTaskShareNotFound         = newError(KindNotFound, "task share doesn't exist")
+	ErrClassNotFound             = newError(KindNotFound, "class doesn't exist")
+	ErrLessonNotFound            = newError(KindNotFound, "lesson doesn't exist in the class")
+
+	ErrAssignmentAlreadyPublished = newError(KindConflict, "assignment is already published")
+	ErrRestoreConflict            = newError(KindConflict, "an active copy of the restored record already exists")
+	ErrAssignmentExists           = newError(KindConflict, "the task is already assigned to this lesson")
+	ErrClassExists                = newError(KindConflict, "class already exists")
+	ErrLessonExists               = newError(KindConflict, "lesson already exists")
+
+	ErrTaskInTrash     = newError(KindLocked, "task is in the trash")
+	ErrVersionMismatch = newError(KindPrecondition, "record has been modified by someone else")
+	ErrForbidden       = newError(KindForbidden, "not enough permissions on the task")
+	// ErrUserRequired is returned to requests that change templates or assignments without naming the user.
+	ErrUserRequired = newError(KindForbidden, "the request doesn't name the user")
+	// ErrCalendarForbidden is returned to those who are neither a teacher of the class nor the student of the calendar.
+	ErrCalendarForbidden = newError(KindForbidden, "only the teachers of the class and the student may subscribe to the calendar")
+
+	ErrInvalidLatePolicy      = newError(KindValidation, "invalid late policy")
+	ErrLateSubmissionRejected = newError(KindValidation, "late submission rejected by assignment policy")
+	ErrSubmittedAtRequired    = newError(KindValidation, "submission time of a late mark is required")
+	ErrInvalidRecurrence      = newError(KindValidation, "invalid recurrence")
+	ErrInvalidPatch           = newError(KindValidation, "invalid patch")
+	ErrInvalidBulkRequest     = newError(KindValidation, "invalid bulk request")
+	ErrInvalidBulkItem        = newError(KindValidation, "invalid bulk item")
+	ErrInvalidImport          = newError(KindValidation, "invalid import")
+	ErrInvalidPermission      = newError(KindValidation, "invalid permission")
+	ErrInvalidClass           = newError(KindValidation, "invalid class")
 )
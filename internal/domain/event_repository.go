@@ -1,5 +1,17 @@
 package domain
 
+import "github.com/google/uuid"
+
 type Event interface {
 	Type() string
+	SetTenant(tenantID uuid.UUID)
+}
+
+// EventTenant is embedded into every event, so consumers know which school the event belongs to.
+type EventTenant struct {
+	TenantID uuid.UUID `json:"tenant_id"`
+}
+
+func (e *EventTenant) SetTenant(tenantID uuid.UUID) {
+	e.TenantID = tenantID
 }
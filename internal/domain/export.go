@@ -0,0 +1,22 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GradebookEntry is the mark of a student for an assignment. TaskID is the assignment,
+// like in TaskResult, so exported gradebooks can be imported back.
+type GradebookEntry struct {
+	StudentID      uuid.UUID
+	TaskID         uuid.UUID
+	LessonID       uuid.UUID
+	Class          string
+	TaskTemplateID uuid.UUID
+	Payload        string
+	Deadline       *time.Time
+	Mark           *int
+	Penalty        int
+	SubmittedAt    *time.Time
+}
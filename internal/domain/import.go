@@ -0,0 +1,234 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// importTimeLayouts are the date formats accepted in imported spreadsheets. Times without an offset are UTC.
+var importTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"02.01.2006 15:04",
+	"02.01.2006",
+}
+
+// MaxImportRows is the most rows, not counting the header, a single import may have.
+const MaxImportRows = 5000
+
+// ImportRowError is a validation error of a spreadsheet row. Row is 1-based and counts the header row,
+// so it matches the row number the teacher sees in the spreadsheet.
+type ImportRowError struct {
+	Row    int
+	Column string
+	Err    error
+}
+
+func (e ImportRowError) Error() string {
+	if e.Column == "" {
+		return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+	}
+
+	return fmt.Sprintf("row %d, column %s: %s", e.Row, e.Column, e.Err)
+}
+
+func (e ImportRowError) Unwrap() error {
+	return e.Err
+}
+
+type ImportReport struct {
+	DryRun   bool
+	Rows     int
+	Imported int
+	Errors   []ImportRowError
+}
+
+type TaskImportRow struct {
+	Row  int
+	Task Task
+}
+
+type MarkImportRow struct {
+	Row      int
+	TaskID   uuid.UUID
+	LessonID uuid.UUID
+	Result   UserResult
+}
+
+// ParseTaskImport reads task templates from a table with the header payload, deadline, category.
+// Only payload is required; columns can come in any order.
+func ParseTaskImport(table [][]string) ([]TaskImportRow, []ImportRowError) {
+	columns, errs := importHeader(table, []string{"payload"}, []string{"deadline", "category"})
+	if errs != nil {
+		return nil, errs
+	}
+
+	var rows []TaskImportRow
+	for i, record := range table[1:] {
+		row := importRow{number: i + 2, record: record, columns: columns}
+		if row.empty() {
+			continue
+		}
+
+		task := Task{
+			ID:       uuid.New(),
+			Payload:  row.value("payload"),
+			Category: row.value("category"),
+		}
+		if task.Payload == "" {
+			errs = append(errs, row.error("payload", fmt.Errorf("%w: payload is required", ErrInvalidImport)))
+		}
+		if utf8.RuneCountInString(task.Payload) > MaxPayloadLength {
+			errs = append(errs, row.error("payload", fmt.Errorf("%w: payload must be at most %d characters long", ErrInvalidImport, MaxPayloadLength)))
+		}
+
+		deadline, err := row.time("deadline")
+		errs = appendImportError(errs, err)
+		task.Deadline = deadline
+
+		rows = append(rows, TaskImportRow{Row: row.number, Task: task})
+	}
+
+	return rows, errs
+}
+
+// ParseMarkImport reads marks from a table with the header student_id, task_id, lesson_id, mark and an optional submitted_at.
+func ParseMarkImport(table [][]string) ([]MarkImportRow, []ImportRowError) {
+	columns, errs := importHeader(table, []string{"student_id", "task_id", "lesson_id", "mark"}, []string{"submitted_at"})
+	if errs != nil {
+		return nil, errs
+	}
+
+	var rows []MarkImportRow
+	for i, record := range table[1:] {
+		row := importRow{number: i + 2, record: record, columns: columns}
+		if row.empty() {
+			continue
+		}
+
+		mark := MarkImportRow{Row: row.number}
+
+		var err error
+		mark.Result.UserID, err = row.uuid("student_id")
+		errs = appendImportError(errs, err)
+		mark.TaskID, err = row.uuid("task_id")
+		errs = appendImportError(errs, err)
+		mark.LessonID, err = row.uuid("lesson_id")
+		errs = appendImportError(errs, err)
+
+		mark.Result.Mark, err = strconv.Atoi(row.value("mark"))
+		if err != nil || mark.Result.Mark < 0 || mark.Result.Mark > MaxMark {
+			errs = append(errs, row.error("mark", fmt.Errorf("%w: mark must be an integer from 0 to %d", ErrInvalidImport, MaxMark)))
+		}
+
+		submittedAt, err := row.time("submitted_at")
+		errs = appendImportError(errs, err)
+		if submittedAt != nil {
+			mark.Result.SubmittedAt = *submittedAt
+		}
+
+		rows = append(rows, mark)
+	}
+
+	return rows, errs
+}
+
+func importHeader(table [][]string, required, optional []string) (map[string]int, []ImportRowError) {
+	if len(table) == 0 {
+		return nil, []ImportRowError{{Row: 1, Err: fmt.Errorf("%w: the file is empty", ErrInvalidImport)}}
+	}
+
+	if len(table)-1 > MaxImportRows {
+		return nil, []ImportRowError{{Row: MaxImportRows + 2, Err: fmt.Errorf("%w: the file has more than %d rows", ErrInvalidImport, MaxImportRows)}}
+	}
+
+	columns := make(map[string]int)
+	for i, name := range table[0] {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !slices.Contains(required, name) && !slices.Contains(optional, name) {
+			continue
+		}
+		columns[name] = i
+	}
+
+	var errs []ImportRowError
+	for _, name := range required {
+		if _, ok := columns[name]; !ok {
+			errs = append(errs, ImportRowError{Row: 1, Column: name, Err: fmt.Errorf("%w: required column is missing", ErrInvalidImport)})
+		}
+	}
+
+	return columns, errs
+}
+
+func appendImportError(errs []ImportRowError, err error) []ImportRowError {
+	var rowErr ImportRowError
+	if errors.As(err, &rowErr) {
+		errs = append(errs, rowErr)
+	}
+
+	return errs
+}
+
+type importRow struct {
+	number  int
+	record  []string
+	columns map[string]int
+}
+
+func (r importRow) value(column string) string {
+	i, ok := r.columns[column]
+	if !ok || i >= len(r.record) {
+		return ""
+	}
+
+	return strings.TrimSpace(r.record[i])
+}
+
+func (r importRow) empty() bool {
+	for _, value := range r.record {
+		if strings.TrimSpace(value) != "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (r importRow) error(column string, err error) ImportRowError {
+	return ImportRowError{Row: r.number, Column: column, Err: err}
+}
+
+func (r importRow) uuid(column string) (uuid.UUID, error) {
+	id, err := uuid.Parse(r.value(column))
+	if err != nil {
+		return uuid.Nil, r.error(column, fmt.Errorf("%w: invalid id %q", ErrInvalidImport, r.value(column)))
+	}
+
+	return id, nil
+}
+
+// time parses an optional date column; an empty cell is nil.
+func (r importRow) time(column string) (*time.Time, error) {
+	value := r.value(column)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range importTimeLayouts {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, r.error(column, fmt.Errorf("%w: invalid date %q", ErrInvalidImport, value))
+}
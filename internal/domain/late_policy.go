@@ -0,0 +1,104 @@
+package domain
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type LatePolicy string
+
+const (
+	LatePolicyAccept  LatePolicy = "accept"
+	LatePolicyPenalty LatePolicy = "penalty"
+	LatePolicyReject  LatePolicy = "reject"
+)
+
+func (p LatePolicy) IsValid() bool {
+	switch p {
+	case LatePolicyAccept, LatePolicyPenalty, LatePolicyReject:
+		return true
+	}
+
+	return false
+}
+
+type AssignmentLatePolicy struct {
+	AssignmentID  uuid.UUID
+	Policy        LatePolicy
+	PenaltyPerDay int
+}
+
+type DeadlineExtension struct {
+	AssignmentID uuid.UUID
+	UserID       uuid.UUID
+	Deadline     time.Time
+}
+
+// Submission is what was settled when a student's work was first marked.
+// A zero SubmittedAt is a mark recorded before submission times were kept.
+type Submission struct {
+	SubmittedAt time.Time
+	Penalty     int
+}
+
+// AssignmentDeadline describes everything needed to decide whether a mark is late:
+// the assignment deadline, its late policy, per-student extensions and the submissions marked before.
+type AssignmentDeadline struct {
+	AssignmentID  uuid.UUID
+	Deadline      *time.Time
+	Policy        LatePolicy
+	PenaltyPerDay int
+	Extensions    map[uuid.UUID]time.Time
+	Submissions   map[uuid.UUID]Submission
+}
+
+// EffectiveDeadline returns the student's extension if there is one, otherwise the assignment deadline.
+func (a *AssignmentDeadline) EffectiveDeadline(userID uuid.UUID) *time.Time {
+	if extension, ok := a.Extensions[userID]; ok {
+		return &extension
+	}
+
+	return a.Deadline
+}
+
+// ApplyLatePolicy fills in the late penalty of the result and lowers its mark accordingly.
+// Every started day after the effective deadline costs PenaltyPerDay percent of the mark.
+// The penalty is settled when the work is first marked: a corrected mark keeps the submission time and the penalty
+// of the first one. A first mark without a submission time counts as submitted at now, unless the deadline has
+// already passed under a penalty or reject policy, as a mark entered late isn't necessarily late work.
+func (a *AssignmentDeadline) ApplyLatePolicy(result *UserResult, now time.Time) error {
+	if submission, ok := a.Submissions[result.UserID]; ok {
+		result.SubmittedAt = submission.SubmittedAt
+		result.Penalty = submission.Penalty
+		result.Mark = result.Mark * (100 - submission.Penalty) / 100
+
+		return nil
+	}
+
+	deadline := a.EffectiveDeadline(result.UserID)
+	if result.SubmittedAt.IsZero() {
+		if deadline != nil && now.After(*deadline) && a.Policy != LatePolicyAccept {
+			return ErrSubmittedAtRequired.WithField("submitted_at", "is required once the deadline has passed")
+		}
+		result.SubmittedAt = now
+	}
+
+	if deadline == nil || !result.SubmittedAt.After(*deadline) {
+		return nil
+	}
+
+	switch a.Policy {
+	case LatePolicyReject:
+		return ErrLateSubmissionRejected
+	case LatePolicyPenalty:
+		daysLate := int(math.Ceil(result.SubmittedAt.Sub(*deadline).Hours() / 24))
+		penalty := min(daysLate*a.PenaltyPerDay, 100)
+
+		result.Penalty = penalty
+		result.Mark = result.Mark * (100 - penalty) / 100
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PatchField is a member of an RFC 7396 merge patch. Set reports whether the member was present;
+// a present member without a Value is null and clears the field.
+type PatchField[T any] struct {
+	Set   bool
+	Value *T
+}
+
+// TaskPatch is a merge patch of a task template. A non-zero Version is the version the caller expects to change.
+type TaskPatch struct {
+	ID       uuid.UUID
+	Version  int
+	Payload  PatchField[string]
+	Deadline PatchField[time.Time]
+	Category PatchField[string]
+}
+
+// Apply validates the patch, applies it to the task and returns the names of the fields that actually changed.
+func (p *TaskPatch) Apply(task *Task) ([]string, error) {
+	var changed []string
+
+	payload, ok, err := patchRequired(p.Payload, task.Payload, "payload")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		task.Payload = payload
+		changed = append(changed, "payload")
+	}
+
+	if deadline, ok := patchDeadline(p.Deadline, task.Deadline); ok {
+		task.Deadline = deadline
+		changed = append(changed, "deadline")
+	}
+
+	if p.Category.Set {
+		var category string
+		if p.Category.Value != nil {
+			category = *p.Category.Value
+		}
+		if category != task.Category {
+			task.Category = category
+			changed = append(changed, "category")
+		}
+	}
+
+	return changed, nil
+}
+
+// AssignmentPatch is a merge patch of an assignment. A non-zero Version is the version the caller expects to change.
+type AssignmentPatch struct {
+	AssignmentID uuid.UUID
+	Version      int
+	Class        PatchField[string]
+	Payload      PatchField[string]
+	Deadline     PatchField[time.Time]
+}
+
+// Apply validates the patch, applies it to the assignment and returns the names of the fields that actually changed.
+func (p *AssignmentPatch) Apply(assignment *TaskAsignment) ([]string, error) {
+	var changed []string
+
+	class, ok, err := patchRequired(p.Class, assignment.Class, "class")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		assignment.Class = class
+		changed = append(changed, "class")
+	}
+
+	payload, ok, err := patchRequired(p.Payload, assignment.Payload, "payload")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		assignment.Payload = payload
+		changed = append(changed, "payload")
+	}
+
+	if deadline, ok := patchDeadline(p.Deadline, assignment.Deadline); ok {
+		assignment.Deadline = deadline
+		changed = append(changed, "deadline")
+	}
+
+	return changed, nil
+}
+
+// patchRequired applies a patch of a field that can't be cleared and reports whether the value changed.
+func patchRequired(field PatchField[string], current, name string) (string, bool, error) {
+	if !field.Set {
+		return current, false, nil
+	}
+
+	if field.Value == nil || *field.Value == "" {
+		return current, false, ErrInvalidPatch.WithField(name, "can't be empty")
+	}
+
+	return *field.Value, *field.Value != current, nil
+}
+
+// patchDeadline applies a patch of an optional deadline and reports whether the deadline changed.
+func patchDeadline(field PatchField[time.Time], current *time.Time) (*time.Time, bool) {
+	if !field.Set {
+		return current, false
+	}
+
+	if field.Value == nil || current == nil {
+		return field.Value, field.Value != current
+	}
+
+	return field.Value, !field.Value.Equal(*current)
+}
@@ -0,0 +1,28 @@
+package domain
+
+import "time"
+
+type AssignmentStatus string
+
+const (
+	AssignmentStatusDraft     AssignmentStatus = "draft"
+	AssignmentStatusPublished AssignmentStatus = "published"
+)
+
+// NewAssignmentStatus decides whether a new assignment is visible right away.
+// An assignment stays a draft while its publish time is in the future, or indefinitely
+// when it is an explicit draft without a publish time.
+func NewAssignmentStatus(draft bool, publishAt *time.Time, now time.Time) AssignmentStatus {
+	if publishAt != nil {
+		if publishAt.After(now) {
+			return AssignmentStatusDraft
+		}
+		return AssignmentStatusPublished
+	}
+
+	if draft {
+		return AssignmentStatusDraft
+	}
+
+	return AssignmentStatusPublished
+}
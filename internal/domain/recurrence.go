@@ -0,0 +1,251 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Frequency string
+
+const (
+	FrequencyDaily  Frequency = "DAILY"
+	FrequencyWeekly Frequency = "WEEKLY"
+)
+
+const rruleUntilLayout = "20060102T150405Z"
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RRule is the subset of RFC 5545 recurrence rules supported for assignments:
+// FREQ=DAILY|WEEKLY with optional INTERVAL, BYDAY, UNTIL and COUNT. Weeks start on Monday.
+type RRule struct {
+	Freq     Frequency
+	Interval int
+	ByDay    []time.Weekday
+	Until    *time.Time
+	Count    int
+}
+
+func ParseRRule(rule string) (*RRule, error) {
+	r := &RRule{Interval: 1}
+
+	for _, part := range strings.Split(strings.TrimPrefix(strings.TrimSpace(rule), "RRULE:"), ";") {
+		key, value, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, ErrInvalidRecurrence.WithField("rrule", fmt.Sprintf("malformed rule part %q", part))
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			r.Freq = Frequency(strings.ToUpper(value))
+			if r.Freq != FrequencyDaily && r.Freq != FrequencyWeekly {
+				return nil, ErrInvalidRecurrence.WithField("rrule", fmt.Sprintf("unsupported FREQ %q", value))
+			}
+		case "INTERVAL":
+			interval, err := strconv.Atoi(value)
+			if err != nil || interval < 1 {
+				return nil, ErrInvalidRecurrence.WithField("rrule", fmt.Sprintf("invalid INTERVAL %q", value))
+			}
+			r.Interval = interval
+		case "BYDAY":
+			for _, day := range strings.Split(value, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, ErrInvalidRecurrence.WithField("rrule", fmt.Sprintf("invalid BYDAY %q", day))
+				}
+				if !slices.Contains(r.ByDay, weekday) {
+					r.ByDay = append(r.ByDay, weekday)
+				}
+			}
+		case "UNTIL":
+			until, err := parseUntil(value)
+			if err != nil {
+				return nil, ErrInvalidRecurrence.WithField("rrule", fmt.Sprintf("invalid UNTIL %q", value))
+			}
+			r.Until = &until
+		case "COUNT":
+			count, err := strconv.Atoi(value)
+			if err != nil || count < 1 {
+				return nil, ErrInvalidRecurrence.WithField("rrule", fmt.Sprintf("invalid COUNT %q", value))
+			}
+			r.Count = count
+		default:
+			return nil, ErrInvalidRecurrence.WithField("rrule", fmt.Sprintf("unsupported rule part %q", key))
+		}
+	}
+
+	if r.Freq == "" {
+		return nil, ErrInvalidRecurrence.WithField("rrule", "FREQ is required")
+	}
+
+	if r.Until != nil && r.Count > 0 {
+		return nil, ErrInvalidRecurrence.WithField("rrule", "UNTIL and COUNT are mutually exclusive")
+	}
+
+	slices.SortFunc(r.ByDay, func(a, b time.Weekday) int { return mondayOffset(a) - mondayOffset(b) })
+
+	return r, nil
+}
+
+func parseUntil(value string) (time.Time, error) {
+	if until, err := time.Parse(rruleUntilLayout, value); err == nil {
+		return until, nil
+	}
+
+	// A date-only UNTIL includes the whole day.
+	until, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return until.Add(24*time.Hour - time.Second), nil
+}
+
+func (r *RRule) String() string {
+	parts := []string{"FREQ=" + string(r.Freq)}
+
+	if r.Interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.Interval))
+	}
+
+	if len(r.ByDay) > 0 {
+		days := make([]string, 0, len(r.ByDay))
+		for _, weekday := range r.ByDay {
+			days = append(days, strings.ToUpper(weekday.String()[:2]))
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+
+	if r.Until != nil {
+		parts = append(parts, "UNTIL="+r.Until.UTC().Format(rruleUntilLayout))
+	}
+
+	if r.Count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.Count))
+	}
+
+	return strings.Join(parts, ";")
+}
+
+// Occurrences expands the rule anchored at start and returns the occurrences after `after`
+// (all of them when after is nil) up to and including until. Occurrences keep start's wall clock
+// time in start's location, so they don't drift across daylight saving changes.
+func (r *RRule) Occurrences(start time.Time, after *time.Time, until time.Time) []time.Time {
+	if r.Until != nil && r.Until.Before(until) {
+		until = *r.Until
+	}
+
+	var (
+		occurrences []time.Time
+		generated   int
+	)
+
+	emit := func(occurrence time.Time) bool {
+		if occurrence.After(until) || (r.Count > 0 && generated >= r.Count) {
+			return false
+		}
+
+		generated++
+		if after == nil || occurrence.After(*after) {
+			occurrences = append(occurrences, occurrence)
+		}
+
+		return true
+	}
+
+	switch r.Freq {
+	case FrequencyDaily:
+		for day := 0; ; day += r.Interval {
+			occurrence := start.AddDate(0, 0, day)
+			if occurrence.After(until) {
+				return occurrences
+			}
+			if len(r.ByDay) > 0 && !slices.Contains(r.ByDay, occurrence.Weekday()) {
+				continue
+			}
+			if !emit(occurrence) {
+				return occurrences
+			}
+		}
+	case FrequencyWeekly:
+		byDay := r.ByDay
+		if len(byDay) == 0 {
+			byDay = []time.Weekday{start.Weekday()}
+		}
+
+		weekStart := start.AddDate(0, 0, -mondayOffset(start.Weekday()))
+		for week := 0; ; week += r.Interval {
+			if weekStart.AddDate(0, 0, week*7).After(until) {
+				return occurrences
+			}
+			for _, weekday := range byDay {
+				occurrence := weekStart.AddDate(0, 0, week*7+mondayOffset(weekday))
+				if occurrence.Before(start) {
+					continue
+				}
+				if !emit(occurrence) {
+					return occurrences
+				}
+			}
+		}
+	}
+
+	return occurrences
+}
+
+// IsOccurrence reports whether at is one of the occurrences of the rule anchored at start.
+func (r *RRule) IsOccurrence(start, at time.Time) bool {
+	occurrences := r.Occurrences(start, nil, at)
+
+	return len(occurrences) > 0 && occurrences[len(occurrences)-1].Equal(at)
+}
+
+func mondayOffset(weekday time.Weekday) int {
+	return (int(weekday) + 6) % 7
+}
+
+// Recurrence materializes a task template as a series of assignments for one class and lesson slot.
+type Recurrence struct {
+	ID                uuid.UUID
+	TaskID            uuid.UUID
+	Class             string
+	LessonID          uuid.UUID
+	Rule              *RRule
+	StartsAt          time.Time
+	Timezone          string
+	DeadlineOffset    *time.Duration
+	MaterializedUntil *time.Time
+}
+
+// Deadline computes the deadline of an occurrence relative to its start.
+func (r *Recurrence) Deadline(occurrence time.Time) *time.Time {
+	if r.DeadlineOffset == nil {
+		return nil
+	}
+
+	deadline := occurrence.Add(*r.DeadlineOffset)
+
+	return &deadline
+}
+
+// OccurrenceException skips or overrides a single occurrence of a recurrence.
+type OccurrenceException struct {
+	RecurrenceID uuid.UUID
+	OccurrenceAt time.Time
+	Skipped      bool
+	Payload      *string
+	Deadline     *time.Time
+}
@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	"task/internal/domain"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRRule(t *testing.T) {
+	rule, err := domain.ParseRRule("RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=TH,MO;UNTIL=20250930")
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.FrequencyWeekly, rule.Freq)
+	assert.Equal(t, 2, rule.Interval)
+	assert.Equal(t, []time.Weekday{time.Monday, time.Thursday}, rule.ByDay)
+	assert.Equal(t, "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;UNTIL=20250930T235959Z", rule.String())
+
+	for _, invalid := range []string{"", "FREQ=MONTHLY", "FREQ=DAILY;INTERVAL=0", "FREQ=WEEKLY;BYDAY=XX", "FREQ=DAILY;COUNT=2;UNTIL=20250101"} {
+		_, err := domain.ParseRRule(invalid)
+		assert.ErrorIs(t, err, domain.ErrInvalidRecurrence, invalid)
+	}
+}
+
+func TestRRuleOccurrencesWeekly(t *testing.T) {
+	rule, err := domain.ParseRRule("FREQ=WEEKLY;BYDAY=MO,TH;COUNT=4")
+	require.NoError(t, err)
+
+	// Tuesday: the first occurrence is the Thursday of the same week.
+	start := time.Date(2025, 9, 2, 9, 0, 0, 0, time.UTC)
+	occurrences := rule.Occurrences(start, nil, start.AddDate(1, 0, 0))
+
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 9, 4, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 9, 8, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 9, 11, 9, 0, 0, 0, time.UTC),
+		time.Date(2025, 9, 15, 9, 0, 0, 0, time.UTC),
+	}, occurrences)
+
+	after := occurrences[1]
+	assert.Equal(t, occurrences[2:], rule.Occurrences(start, &after, start.AddDate(1, 0, 0)))
+	assert.True(t, rule.IsOccurrence(start, occurrences[3]))
+	assert.False(t, rule.IsOccurrence(start, occurrences[3].AddDate(0, 0, 7)))
+}
+
+func TestRRuleOccurrencesDailyKeepsWallClock(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	rule, err := domain.ParseRRule("FREQ=DAILY;INTERVAL=2;BYDAY=MO,TU,WE,TH,FR")
+	require.NoError(t, err)
+
+	// Daylight saving time ends on 2025-10-26.
+	start := time.Date(2025, 10, 22, 8, 30, 0, 0, location)
+	occurrences := rule.Occurrences(start, nil, time.Date(2025, 10, 31, 0, 0, 0, 0, location))
+
+	assert.Equal(t, []time.Time{
+		time.Date(2025, 10, 22, 8, 30, 0, 0, location),
+		time.Date(2025, 10, 24, 8, 30, 0, 0, location),
+		time.Date(2025, 10, 28, 8, 30, 0, 0, location),
+		time.Date(2025, 10, 30, 8, 30, 0, 0, location),
+	}, occurrences)
+}
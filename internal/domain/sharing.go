@@ -0,0 +1,96 @@
+package domain
+
+import (
+	"github.com/google/uuid"
+)
+
+// Permission is what a teacher may do with a task template. Every permission includes the ones before it.
+type Permission string
+
+const (
+	PermissionNone Permission = ""
+	PermissionView Permission = "view"
+	// PermissionUse also allows assigning the template to classes and copying it to the own bank.
+	PermissionUse  Permission = "use"
+	PermissionEdit Permission = "edit"
+	// PermissionOwner is held by the author only: deleting the template and sharing it.
+	PermissionOwner Permission = "owner"
+)
+
+var permissionRank = map[Permission]int{
+	PermissionView:  1,
+	PermissionUse:   2,
+	PermissionEdit:  3,
+	PermissionOwner: 4,
+}
+
+// IsShareable tells whether the permission can be granted to colleagues.
+func (p Permission) IsShareable() bool {
+	switch p {
+	case PermissionView, PermissionUse, PermissionEdit:
+		return true
+	}
+
+	return false
+}
+
+func (p Permission) Includes(required Permission) bool {
+	return permissionRank[p] >= permissionRank[required]
+}
+
+// Check returns ErrTaskNotFound when the template is hidden from the caller, so its existence doesn't leak,
+// and ErrForbidden when the caller sees it but may not do what required allows.
+func (p Permission) Check(required Permission) error {
+	if p == PermissionNone {
+		return ErrTaskNotFound
+	}
+
+	if !p.Includes(required) {
+		return ErrForbidden
+	}
+
+	return nil
+}
+
+// TaskAccess is what decides the permission of a caller on a template: its author
+// and the highest permission shared with the caller directly or with the whole school.
+type TaskAccess struct {
+	AuthorID *uuid.UUID
+	Shared   Permission
+}
+
+// Permission of the caller, nil when the request doesn't name a user. Templates without an author
+// were created before authorship was recorded and stay open to every user; callers without one only view them.
+func (a TaskAccess) Permission(callerID *uuid.UUID) Permission {
+	if callerID == nil {
+		if a.AuthorID == nil {
+			return PermissionView
+		}
+		return PermissionNone
+	}
+
+	if a.AuthorID == nil || *a.AuthorID == *callerID {
+		return PermissionOwner
+	}
+
+	return a.Shared
+}
+
+// HighestPermission picks the permission that includes all the others.
+func HighestPermission(permissions []Permission) Permission {
+	highest := PermissionNone
+	for _, permission := range permissions {
+		if permissionRank[permission] > permissionRank[highest] {
+			highest = permission
+		}
+	}
+
+	return highest
+}
+
+// TaskShare grants a permission on a template to a colleague, or to the whole school when UserID is nil.
+type TaskShare struct {
+	TaskID     uuid.UUID
+	UserID     *uuid.UUID
+	Permission Permission
+}
@@ -3,11 +3,13 @@ package domain
 const StudentsGotMarkEventType = "StudentsGotMarkEvent"
 
 type UsersMark struct {
-	UserID string `json:"user_id"`
-	Mark   int    `json:"mark"`
+	UserID  string `json:"user_id"`
+	Mark    int    `json:"mark"`
+	Penalty int    `json:"penalty,omitempty"`
 }
 
 type StudentsGotMarkEvent struct {
+	EventTenant
 	UsersMark []UsersMark `json:"users_mark"`
 	TaskID    string      `json:"task_id"`
 	LessonID  string      `json:"lesson_id"`
@@ -17,8 +19,9 @@ func NewStudentsGotMarkEvent(taskResults *TaskResult) *StudentsGotMarkEvent {
 	usersMark := make([]UsersMark, 0, len(taskResults.UsersResult))
 	for _, ur := range taskResults.UsersResult {
 		usersMark = append(usersMark, UsersMark{
-			UserID: ur.UserID.String(),
-			Mark:   ur.Mark,
+			UserID:  ur.UserID.String(),
+			Mark:    ur.Mark,
+			Penalty: ur.Penalty,
 		})
 	}
 
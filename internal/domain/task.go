@@ -6,18 +6,28 @@ import (
 	"github.com/google/uuid"
 )
 
+// Task is a task template. Version grows with every update and backs optimistic concurrency:
+// on updates and deletes a non-zero Version is the version the caller expects to change.
+// AuthorID is nil only for templates created before authorship was recorded, which every user may change.
 type Task struct {
 	ID       uuid.UUID  `json:"id"`
 	Payload  string     `json:"payload"`
 	Deadline *time.Time `json:"deadline,omitempty"`
+	Category string     `json:"category,omitempty"`
+	Version  int        `json:"version"`
+	AuthorID *uuid.UUID `json:"author_id,omitempty"`
 }
 
 type TaskWithAsignment struct {
-	Class    string
-	LessonID uuid.UUID
-	TaskID   uuid.UUID
-	Payload  string
-	Deadline *time.Time
+	Class     string
+	LessonID  uuid.UUID
+	TaskID    uuid.UUID
+	Payload   string
+	Deadline  *time.Time
+	Draft     bool
+	PublishAt *time.Time
+	Status    AssignmentStatus
+	AuthorID  *uuid.UUID
 }
 
 type ClassLesson struct {
@@ -26,8 +36,11 @@ type ClassLesson struct {
 }
 
 type TaskAsignments struct {
-	ToAssign []ClassLesson
-	TaskID   uuid.UUID
+	ToAssign  []ClassLesson
+	TaskID    uuid.UUID
+	Draft     bool
+	PublishAt *time.Time
+	Status    AssignmentStatus
 }
 
 type RTaskAsignments struct {
@@ -37,12 +50,20 @@ type Assignment struct {
 	AssignmentID uuid.UUID
 	Class        string
 	LessonID     uuid.UUID
+	Status       AssignmentStatus
+	// TenantID is only filled in by the background jobs that work across schools.
+	TenantID uuid.UUID
 }
 
 type TaskAsignment struct {
 	AssignmentID uuid.UUID
-	Class        string
-	Payload      string
+	// TaskID is the template the assignment was made from, it decides who may change the assignment.
+	TaskID   uuid.UUID
+	Class    string
+	LessonID uuid.UUID
+	Payload  string
+	Deadline *time.Time
+	Version  int
 }
 
 type LessonTask struct {
@@ -51,11 +72,22 @@ type LessonTask struct {
 	Payload        string
 	Deadline       *time.Time
 	TaskTemplateID uuid.UUID
+	Version        int
 }
 
+// MaxPayloadLength limits the text of a task in characters.
+const MaxPayloadLength = 10000
+
+const MaxCategoryLength = 100
+
+// MaxMark is the highest mark. Marks are points, so a late penalty can take a percentage of them.
+const MaxMark = 100
+
 type UserResult struct {
-	UserID uuid.UUID
-	Mark   int
+	UserID      uuid.UUID
+	Mark        int
+	SubmittedAt time.Time
+	Penalty     int
 }
 
 type TaskResult struct {
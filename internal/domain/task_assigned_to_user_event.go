@@ -3,6 +3,7 @@ package domain
 const TaskAssignedToClassEventType = "TaskAssignedToClass"
 
 type TaskAssignmentToClassEvent struct {
+	EventTenant
 	Class    string `json:"class"`
 	LessonID string `json:"lesson_id"`
 	TaskID   string `json:"task_id"`
@@ -0,0 +1,27 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// DefaultTenant is the school of single-school deployments and of the data created before schools were separated.
+var DefaultTenant = uuid.Nil
+
+type tenantKey struct{}
+
+// ContextWithTenant stores the school the request acts for. Every query and cache key of the request is limited to it.
+func ContextWithTenant(ctx context.Context, tenantID uuid.UUID) context.Context {
+	return context.WithValue(ctx, tenantKey{}, tenantID)
+}
+
+// TenantFromContext returns the school the request acts for, DefaultTenant when none was set.
+func TenantFromContext(ctx context.Context) uuid.UUID {
+	tenantID, ok := ctx.Value(tenantKey{}).(uuid.UUID)
+	if !ok {
+		return DefaultTenant
+	}
+
+	return tenantID
+}
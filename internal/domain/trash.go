@@ -0,0 +1,31 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Deletion struct {
+	DeletedAt time.Time
+	DeletedBy *uuid.UUID
+}
+
+type DeletedTask struct {
+	Task
+	Deletion
+}
+
+type DeletedAssignment struct {
+	AssignmentID uuid.UUID
+	TaskID       uuid.UUID
+	Class        string
+	LessonID     uuid.UUID
+	Payload      string
+	Deletion
+}
+
+type Trash struct {
+	Tasks       []DeletedTask
+	Assignments []DeletedAssignment
+}
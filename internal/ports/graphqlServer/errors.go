@@ -0,0 +1,84 @@
+package graphqlserver
+
+import (
+	"context"
+	"log/slog"
+	"task/internal/domain"
+	"task/pkg/validation"
+)
+
+// The codes go to extensions.code of an error, the names follow the common GraphQL servers.
+var kindCode = map[domain.Kind]string{
+	domain.KindNotFound:     "NOT_FOUND",
+	domain.KindConflict:     "CONFLICT",
+	domain.KindValidation:   "BAD_USER_INPUT",
+	domain.KindForbidden:    "FORBIDDEN",
+	domain.KindLocked:       "LOCKED",
+	domain.KindPrecondition: "PRECONDITION_FAILED",
+}
+
+const codeInternal = "INTERNAL_SERVER_ERROR"
+
+// fieldError is an invalid input field, named the way the query names it, e.g. assignTo[1].lessonId.
+type fieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// Error is a resolver error with its code and invalid fields in the extensions.
+type Error struct {
+	message string
+	code    string
+	fields  []fieldError
+}
+
+func (e *Error) Error() string {
+	return e.message
+}
+
+func (e *Error) Extensions() map[string]interface{} {
+	extensions := map[string]interface{}{"code": e.code}
+	if len(e.fields) > 0 {
+		extensions["fields"] = e.fields
+	}
+
+	return extensions
+}
+
+// fail maps domain errors the same way the REST API does. Any other error is internal: its text
+// may come from the database, so the client gets a generic message and the details stay in the log.
+func (h *Handler) fail(ctx context.Context, err error) error {
+	h.log(ctx).Error("failed to resolve", slog.String("error", err.Error()))
+
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		return &Error{message: "internal server error", code: codeInternal}
+	}
+
+	code, ok := kindCode[domainErr.Kind]
+	if !ok {
+		return &Error{message: "internal server error", code: codeInternal}
+	}
+
+	fields := make([]fieldError, 0, len(domainErr.Fields))
+	for _, field := range domainErr.Fields {
+		fields = append(fields, fieldError{Field: field.Field, Message: field.Message})
+	}
+
+	return &Error{message: domainErr.Message, code: code, fields: fields}
+}
+
+// invalid reports every invalid field of an input at once.
+func invalid(err error) error {
+	validationFields, ok := validation.Fields(err)
+	if !ok {
+		return &Error{message: "input is not valid", code: kindCode[domain.KindValidation]}
+	}
+
+	fields := make([]fieldError, 0, len(validationFields))
+	for _, field := range validationFields {
+		fields = append(fields, fieldError{Field: field.Field, Message: field.Message})
+	}
+
+	return &Error{message: "input is not valid", code: kindCode[domain.KindValidation], fields: fields}
+}
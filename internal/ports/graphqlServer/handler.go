@@ -0,0 +1,148 @@
+package graphqlserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"task/internal/config"
+	"task/internal/domain"
+	"task/pkg/logging"
+	"task/pkg/problem"
+	"task/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/location"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+)
+
+// TaskService is the part of the task service the GraphQL schema is built on.
+type TaskService interface {
+	CreateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error)
+	GetTask(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+	GetTasks(ctx context.Context) ([]*domain.Task, error)
+	GetTasksByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Task, error)
+	UpdateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error)
+	DeleteTask(ctx context.Context, id uuid.UUID, version int) error
+	CreateAssignments(ctx context.Context, taskAssignments *domain.TaskAsignments) ([]domain.Assignment, error)
+	GetTasksByClasses(ctx context.Context, classes []string) (map[string][]*domain.LessonTask, error)
+	GetStudentTasks(ctx context.Context, class string, studentID uuid.UUID) ([]*domain.LessonTask, error)
+	PublishAssignment(ctx context.Context, assignmentID uuid.UUID) error
+	SetTaskResultsByUsers(ctx context.Context, taskResults *domain.TaskResult) error
+	GetMarksByAssignments(ctx context.Context, assignmentIDs []uuid.UUID) (map[uuid.UUID][]domain.GradebookEntry, error)
+	ExportStudentMarks(ctx context.Context, studentID uuid.UUID, fn func(*domain.GradebookEntry) error) error
+	GetClasses(ctx context.Context) ([]domain.Class, error)
+	GetLessonsByClasses(ctx context.Context, classes []string) (map[string][]domain.Lesson, error)
+}
+
+// Handler serves GraphQL queries over HTTP. Queries deeper than maxDepth or more complex than
+// maxComplexity, see cost, are rejected before anything is resolved.
+type Handler struct {
+	schema        graphql.Schema
+	taskService   TaskService
+	validate      *validator.Validate
+	logger        *slog.Logger
+	maxDepth      int
+	maxComplexity int
+}
+
+func NewHandler(logger *slog.Logger, taskService TaskService, config *config.GraphQLConfig) (*Handler, error) {
+	validate := validator.New()
+	if err := validation.Setup(validate, domain.MaxPayloadLength); err != nil {
+		return nil, err
+	}
+
+	h := &Handler{
+		taskService:   taskService,
+		validate:      validate,
+		logger:        logger,
+		maxDepth:      config.MaxDepth,
+		maxComplexity: config.MaxComplexity,
+	}
+
+	schema, err := h.newSchema()
+	if err != nil {
+		return nil, fmt.Errorf("build graphql schema: %w", err)
+	}
+	h.schema = schema
+
+	return h, nil
+}
+
+// log returns the logger of the request, which carries its request ID, route, user and tenant.
+func (h *Handler) log(ctx context.Context) *slog.Logger {
+	return logging.FromContext(ctx, h.logger)
+}
+
+type request struct {
+	Query         string         `json:"query" binding:"required"`
+	OperationName string         `json:"operationName"`
+	Variables     map[string]any `json:"variables"`
+}
+
+// Serve godoc
+// @Summary GraphQL
+// @Description Выполнить запрос GraphQL. Ошибки запроса возвращаются в поле errors со статусом 200,
+// @Description код ошибки в extensions.code, неверные поля в extensions.fields
+// @tags graphql
+// @Accept json
+// @Produce json
+// @Param request body request true "Запрос GraphQL"
+// @Success 200 {object} map[string]any
+// @Failure 400 {object} problem.Details
+// @Router /api/v1/graphql [post].
+func (h *Handler) Serve(c *gin.Context) {
+	var req request
+	if err := c.ShouldBindJSON(&req); err != nil {
+		problem.Abort(c, problem.New(http.StatusBadRequest, "body must be a GraphQL request with a query"))
+		return
+	}
+
+	ctx := contextWithLoaders(c.Request.Context(), newLoaders(h.taskService))
+	c.JSON(http.StatusOK, h.execute(ctx, req))
+}
+
+// execute is graphql.Do with the depth and complexity checked between the validation and the execution.
+func (h *Handler) execute(ctx context.Context, req request) *graphql.Result {
+	document, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{
+		Body: []byte(req.Query),
+		Name: "GraphQL request",
+	})})
+	if err != nil {
+		return &graphql.Result{Errors: gqlerrors.FormatErrors(err)}
+	}
+
+	validationResult := graphql.ValidateDocument(&h.schema, document, nil)
+	if !validationResult.IsValid {
+		return &graphql.Result{Errors: validationResult.Errors}
+	}
+
+	cost := estimate(&h.schema, document, req.OperationName)
+	switch {
+	case cost.depth > h.maxDepth:
+		return limitExceeded(fmt.Sprintf("query depth %d exceeds the limit of %d", cost.depth, h.maxDepth), "QUERY_TOO_DEEP")
+	case cost.complexity > h.maxComplexity:
+		return limitExceeded(fmt.Sprintf("query complexity %d exceeds the limit of %d", cost.complexity, h.maxComplexity), "QUERY_TOO_COMPLEX")
+	}
+
+	return graphql.Execute(graphql.ExecuteParams{
+		Schema:        h.schema,
+		AST:           document,
+		OperationName: req.OperationName,
+		Args:          req.Variables,
+		Context:       ctx,
+	})
+}
+
+func limitExceeded(message, code string) *graphql.Result {
+	return &graphql.Result{Errors: []gqlerrors.FormattedError{{
+		Message:    message,
+		Locations:  []location.SourceLocation{},
+		Extensions: map[string]any{"code": code},
+	}}}
+}
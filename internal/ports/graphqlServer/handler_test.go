@@ -0,0 +1,153 @@
+package graphqlserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task/internal/app"
+	"task/internal/config"
+	"task/internal/domain"
+	graphqlserver "task/internal/ports/graphqlServer"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taskService serves classes with one assignment each and counts the batched calls.
+type taskService struct {
+	graphqlserver.TaskService
+	classes      []domain.Class
+	template     *domain.Task
+	taskBatches  [][]uuid.UUID
+	classBatches [][]string
+}
+
+func (s *taskService) GetClasses(context.Context) ([]domain.Class, error) {
+	return s.classes, nil
+}
+
+func (s *taskService) GetTasksByClasses(_ context.Context, classes []string) (map[string][]*domain.LessonTask, error) {
+	s.classBatches = append(s.classBatches, classes)
+	tasks := make(map[string][]*domain.LessonTask, len(classes))
+	for _, class := range classes {
+		tasks[class] = []*domain.LessonTask{{TaskID: uuid.New(), LessonID: uuid.New(), TaskTemplateID: s.template.ID, Payload: class}}
+	}
+
+	return tasks, nil
+}
+
+func (s *taskService) GetTasksByIDs(_ context.Context, ids []uuid.UUID) ([]*domain.Task, error) {
+	s.taskBatches = append(s.taskBatches, ids)
+	return []*domain.Task{s.template}, nil
+}
+
+func (s *taskService) CreateTask(_ context.Context, task *domain.Task) (uuid.UUID, error) {
+	return task.ID, nil
+}
+
+func (s *taskService) GetTask(context.Context, uuid.UUID) (*domain.Task, error) {
+	return nil, domain.ErrTaskNotFound
+}
+
+type response struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message    string `json:"message"`
+		Extensions struct {
+			Code   string `json:"code"`
+			Fields []struct {
+				Field   string `json:"field"`
+				Message string `json:"message"`
+			} `json:"fields"`
+		} `json:"extensions"`
+	} `json:"errors"`
+}
+
+func serve(t *testing.T, service graphqlserver.TaskService, query string) response {
+	t.Helper()
+
+	handler, err := graphqlserver.NewHandler(app.InitLogger(), service, &config.GraphQLConfig{MaxDepth: 4, MaxComplexity: 400})
+	require.NoError(t, err)
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.POST("/graphql", handler.Serve)
+
+	body, err := json.Marshal(map[string]string{"query": query})
+	require.NoError(t, err)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(string(body))))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var resp response
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &resp))
+	return resp
+}
+
+func TestClassesLoadAssignmentsAndTemplatesInBatches(t *testing.T) {
+	service := &taskService{
+		classes:  []domain.Class{{ID: uuid.New(), Name: "5A"}, {ID: uuid.New(), Name: "9A"}, {ID: uuid.New(), Name: "9B"}},
+		template: &domain.Task{ID: uuid.New(), Payload: "algebra", Version: 1},
+	}
+
+	resp := serve(t, service, `{ classes { name assignments { payload template { payload } } } }`)
+
+	require.Empty(t, resp.Errors)
+	assert.Equal(t, [][]string{{"5A", "9A", "9B"}}, service.classBatches)
+	assert.Equal(t, [][]uuid.UUID{{service.template.ID}}, service.taskBatches)
+	assert.JSONEq(t, `[
+		{"name": "5A", "assignments": [{"payload": "5A", "template": {"payload": "algebra"}}]},
+		{"name": "9A", "assignments": [{"payload": "9A", "template": {"payload": "algebra"}}]},
+		{"name": "9B", "assignments": [{"payload": "9B", "template": {"payload": "algebra"}}]}
+	]`, string(resp.Data["classes"]))
+}
+
+func TestQueryLimits(t *testing.T) {
+	tests := []struct {
+		name  string
+		query string
+		code  string
+	}{
+		{
+			name:  "too deep",
+			query: `{ classes { assignments { marks { template { id } } } } }`,
+			code:  "QUERY_TOO_DEEP",
+		},
+		{
+			name:  "too complex",
+			query: `{ classes { assignments { id payload deadline version } } }`,
+			code:  "QUERY_TOO_COMPLEX",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := &taskService{}
+
+			resp := serve(t, service, tt.query)
+
+			require.Len(t, resp.Errors, 1)
+			assert.Equal(t, tt.code, resp.Errors[0].Extensions.Code)
+			assert.Empty(t, service.classBatches)
+		})
+	}
+}
+
+func TestErrors(t *testing.T) {
+	resp := serve(t, &taskService{}, `mutation { createTask(input: {payload: " ", category: "algebra"}) { id } }`)
+
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "BAD_USER_INPUT", resp.Errors[0].Extensions.Code)
+	require.Len(t, resp.Errors[0].Extensions.Fields, 1)
+	assert.Equal(t, "payload", resp.Errors[0].Extensions.Fields[0].Field)
+
+	resp = serve(t, &taskService{}, `{ task(id: "`+uuid.NewString()+`") { id } }`)
+
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, "NOT_FOUND", resp.Errors[0].Extensions.Code)
+	assert.Equal(t, "null", string(resp.Data["task"]))
+}
@@ -0,0 +1,129 @@
+package graphqlserver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The inputs have the rules of the REST requests. The json tags are the GraphQL names,
+// so invalid fields are reported the way the query names them.
+
+type taskInput struct {
+	Payload  string     `json:"payload" validate:"required,notblank,payload"`
+	Deadline *time.Time `json:"deadline" validate:"omitempty,future"`
+	Category string     `json:"category" validate:"max=100"`
+}
+
+// taskUpdateInput is taskInput without the future rule on the deadline, so a template can still be
+// saved after its deadline has passed.
+type taskUpdateInput struct {
+	Payload  string     `json:"payload" validate:"required,notblank,payload"`
+	Deadline *time.Time `json:"deadline"`
+	Category string     `json:"category" validate:"max=100"`
+}
+
+func (t taskInput) toDomain(id uuid.UUID, version int) *domain.Task {
+	return taskUpdateInput(t).toDomain(id, version)
+}
+
+func (t taskUpdateInput) toDomain(id uuid.UUID, version int) *domain.Task {
+	return &domain.Task{
+		ID:       id,
+		Payload:  t.Payload,
+		Deadline: t.Deadline,
+		Category: t.Category,
+		Version:  version,
+	}
+}
+
+type idInput struct {
+	ID      string `json:"id" validate:"required,uuid"`
+	Version int    `json:"version" validate:"min=0"`
+}
+
+type classLessonInput struct {
+	Class    string `json:"class" validate:"required,notblank,max=32"`
+	LessonID string `json:"lessonId" validate:"required,uuid"`
+}
+
+type assignTaskInput struct {
+	TaskID string `json:"taskId" validate:"required,uuid"`
+	// AssignTo names every lesson once.
+	AssignTo  []classLessonInput `json:"assignTo" validate:"required,min=1,max=100,unique=LessonID,dive"`
+	Draft     bool               `json:"draft"`
+	PublishAt *time.Time         `json:"publishAt"`
+}
+
+func (t assignTaskInput) toDomain() *domain.TaskAsignments {
+	toAssign := make([]domain.ClassLesson, 0, len(t.AssignTo))
+	for _, classLesson := range t.AssignTo {
+		toAssign = append(toAssign, domain.ClassLesson{
+			Class:    classLesson.Class,
+			LessonID: uuid.MustParse(classLesson.LessonID),
+		})
+	}
+
+	return &domain.TaskAsignments{
+		TaskID:    uuid.MustParse(t.TaskID),
+		ToAssign:  toAssign,
+		Draft:     t.Draft,
+		PublishAt: t.PublishAt,
+	}
+}
+
+type markInput struct {
+	StudentID   string     `json:"studentId" validate:"required,uuid"`
+	Mark        int        `json:"mark" validate:"min=0,max=100"`
+	SubmittedAt *time.Time `json:"submittedAt"`
+}
+
+type setMarksInput struct {
+	AssignmentID string `json:"assignmentId" validate:"required,uuid"`
+	LessonID     string `json:"lessonId" validate:"required,uuid"`
+	// Marks has one mark per student.
+	Marks []markInput `json:"marks" validate:"required,min=1,max=1000,unique=StudentID,dive"`
+}
+
+func (t setMarksInput) toDomain() *domain.TaskResult {
+	results := make([]domain.UserResult, 0, len(t.Marks))
+	for _, mark := range t.Marks {
+		result := domain.UserResult{
+			UserID: uuid.MustParse(mark.StudentID),
+			Mark:   mark.Mark,
+		}
+		if mark.SubmittedAt != nil {
+			result.SubmittedAt = *mark.SubmittedAt
+		}
+		results = append(results, result)
+	}
+
+	return &domain.TaskResult{
+		TaskID:      uuid.MustParse(t.AssignmentID),
+		LessonID:    uuid.MustParse(t.LessonID),
+		UsersResult: results,
+	}
+}
+
+// decode fills the input from the arguments graphql-go has already coerced to the schema types
+// and checks its rules. The IDs in a valid input are UUIDs.
+func (h *Handler) decode(ctx context.Context, args any, input any) error {
+	raw, err := json.Marshal(args)
+	if err != nil {
+		return h.fail(ctx, fmt.Errorf("encode arguments: %w", err))
+	}
+
+	if err := json.Unmarshal(raw, input); err != nil {
+		return h.fail(ctx, fmt.Errorf("decode arguments: %w", err))
+	}
+
+	if err := h.validate.Struct(input); err != nil {
+		return invalid(err)
+	}
+
+	return nil
+}
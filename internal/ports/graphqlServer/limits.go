@@ -0,0 +1,119 @@
+package graphqlserver
+
+import (
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// listWeight is how many items a list field is expected to have when the complexity is estimated.
+const listWeight = 10
+
+// cost is the depth and the complexity of an operation. Every field costs 1 plus the cost of its
+// selection, multiplied by listWeight for list fields, so a class list asking for the marks of every
+// assignment costs far more than a single task. Introspection is left out, its size is bounded by the schema.
+type cost struct {
+	depth      int
+	complexity int
+}
+
+// estimate returns the cost of the operation the request executes. The document must be valid.
+func estimate(schema *graphql.Schema, document *ast.Document, operationName string) cost {
+	fragments := make(map[string]*ast.FragmentDefinition)
+	var operation *ast.OperationDefinition
+	for _, definition := range document.Definitions {
+		switch definition := definition.(type) {
+		case *ast.FragmentDefinition:
+			fragments[definition.Name.Value] = definition
+		case *ast.OperationDefinition:
+			if operationName == "" || (definition.Name != nil && definition.Name.Value == operationName) {
+				operation = definition
+			}
+		}
+	}
+	if operation == nil {
+		return cost{}
+	}
+
+	root := schema.QueryType()
+	if operation.Operation == ast.OperationTypeMutation {
+		root = schema.MutationType()
+	}
+
+	e := estimator{schema: schema, fragments: fragments}
+	return e.selectionSet(root, operation.SelectionSet)
+}
+
+type estimator struct {
+	schema    *graphql.Schema
+	fragments map[string]*ast.FragmentDefinition
+}
+
+func (e estimator) selectionSet(parent *graphql.Object, selectionSet *ast.SelectionSet) cost {
+	var total cost
+	if parent == nil || selectionSet == nil {
+		return total
+	}
+
+	for _, selection := range selectionSet.Selections {
+		var selected cost
+		switch selection := selection.(type) {
+		case *ast.Field:
+			selected = e.field(parent, selection)
+		case *ast.InlineFragment:
+			selected = e.selectionSet(e.condition(parent, selection.TypeCondition), selection.SelectionSet)
+		case *ast.FragmentSpread:
+			if fragment, ok := e.fragments[selection.Name.Value]; ok {
+				selected = e.selectionSet(e.condition(parent, fragment.TypeCondition), fragment.SelectionSet)
+			}
+		}
+
+		total.complexity += selected.complexity
+		total.depth = max(total.depth, selected.depth)
+	}
+
+	return total
+}
+
+func (e estimator) field(parent *graphql.Object, field *ast.Field) cost {
+	if strings.HasPrefix(field.Name.Value, "__") {
+		return cost{}
+	}
+
+	definition, ok := parent.Fields()[field.Name.Value]
+	if !ok {
+		return cost{depth: 1, complexity: 1}
+	}
+
+	weight := 1
+	fieldType := definition.Type
+	if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+		fieldType = nonNull.OfType
+	}
+	if list, ok := fieldType.(*graphql.List); ok {
+		weight = listWeight
+		fieldType = list.OfType
+		if nonNull, ok := fieldType.(*graphql.NonNull); ok {
+			fieldType = nonNull.OfType
+		}
+	}
+
+	object, _ := fieldType.(*graphql.Object)
+	selected := e.selectionSet(object, field.SelectionSet)
+
+	return cost{
+		depth:      selected.depth + 1,
+		complexity: 1 + weight*selected.complexity,
+	}
+}
+
+// condition is the type a fragment applies to, the parent when it names none.
+func (e estimator) condition(parent *graphql.Object, condition *ast.Named) *graphql.Object {
+	if condition == nil {
+		return parent
+	}
+
+	object, _ := e.schema.Type(condition.Name.Value).(*graphql.Object)
+	return object
+}
@@ -0,0 +1,109 @@
+package graphqlserver
+
+import (
+	"context"
+	"sync"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// loader collects the keys asked for while a level of the query is resolved and fetches them
+// with one call when the first of their thunks runs. graphql-go resolves every field of a level
+// before it calls the thunks they returned, so a level costs one query however many objects it has.
+// Keys are fetched once per request.
+type loader[K comparable, V any] struct {
+	fetch func(ctx context.Context, keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	queued  map[K]bool
+	done    map[K]bool
+	values  map[K]V
+	errs    map[K]error
+}
+
+func newLoader[K comparable, V any](fetch func(ctx context.Context, keys []K) (map[K]V, error)) *loader[K, V] {
+	return &loader[K, V]{
+		fetch:  fetch,
+		queued: make(map[K]bool),
+		done:   make(map[K]bool),
+		values: make(map[K]V),
+		errs:   make(map[K]error),
+	}
+}
+
+// load queues the key and returns the thunk giving its value, the zero value when nothing was found.
+func (l *loader[K, V]) load(ctx context.Context, key K) func() (V, error) {
+	l.mu.Lock()
+	if !l.queued[key] {
+		l.queued[key] = true
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		if !l.done[key] {
+			l.flush(ctx)
+		}
+
+		return l.values[key], l.errs[key]
+	}
+}
+
+func (l *loader[K, V]) flush(ctx context.Context) {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.fetch(ctx, keys)
+	for _, key := range keys {
+		l.done[key] = true
+		if err != nil {
+			l.errs[key] = err
+			continue
+		}
+		l.values[key] = values[key]
+	}
+}
+
+// loaders are created for every request, so nothing is shared between users or tenants.
+type loaders struct {
+	tasks       *loader[uuid.UUID, *domain.Task]
+	assignments *loader[string, []*domain.LessonTask]
+	lessons     *loader[string, []domain.Lesson]
+	marks       *loader[uuid.UUID, []domain.GradebookEntry]
+}
+
+func newLoaders(taskService TaskService) *loaders {
+	return &loaders{
+		tasks: newLoader(func(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*domain.Task, error) {
+			tasks, err := taskService.GetTasksByIDs(ctx, ids)
+			if err != nil {
+				return nil, err
+			}
+
+			byID := make(map[uuid.UUID]*domain.Task, len(tasks))
+			for _, task := range tasks {
+				byID[task.ID] = task
+			}
+
+			return byID, nil
+		}),
+		assignments: newLoader(taskService.GetTasksByClasses),
+		lessons:     newLoader(taskService.GetLessonsByClasses),
+		marks:       newLoader(taskService.GetMarksByAssignments),
+	}
+}
+
+type loadersKey struct{}
+
+func contextWithLoaders(ctx context.Context, l *loaders) context.Context {
+	return context.WithValue(ctx, loadersKey{}, l)
+}
+
+func loadersFromContext(ctx context.Context) *loaders {
+	return ctx.Value(loadersKey{}).(*loaders)
+}
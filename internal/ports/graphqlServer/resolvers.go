@@ -0,0 +1,283 @@
+package graphqlserver
+
+import (
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+// The sources of the object types, that is what their parent resolvers return.
+type (
+	taskSource          = *domain.Task
+	markSource          = domain.GradebookEntry
+	lessonSource        = domain.Lesson
+	classSource         = domain.Class
+	newAssignmentSource = domain.Assignment
+)
+
+// assignmentSource is a task of a class, LessonTask doesn't know its class.
+type assignmentSource struct {
+	*domain.LessonTask
+	class string
+}
+
+func newAssignments(class string, tasks []*domain.LessonTask) []assignmentSource {
+	assignments := make([]assignmentSource, 0, len(tasks))
+	for _, task := range tasks {
+		assignments = append(assignments, assignmentSource{LessonTask: task, class: class})
+	}
+
+	return assignments
+}
+
+type classInput struct {
+	Name string `json:"name" validate:"required,notblank,max=32"`
+}
+
+type studentTasksInput struct {
+	Class     string `json:"class" validate:"required,notblank,max=32"`
+	StudentID string `json:"studentId" validate:"required,uuid"`
+}
+
+type studentMarksInput struct {
+	StudentID string `json:"studentId" validate:"required,uuid"`
+}
+
+func (h *Handler) task(p graphql.ResolveParams) (any, error) {
+	var input idInput
+	if err := h.decode(p.Context, p.Args, &input); err != nil {
+		return nil, err
+	}
+
+	task, err := h.taskService.GetTask(p.Context, uuid.MustParse(input.ID))
+	if err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return task, nil
+}
+
+func (h *Handler) tasks(p graphql.ResolveParams) (any, error) {
+	tasks, err := h.taskService.GetTasks(p.Context)
+	if err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return tasks, nil
+}
+
+func (h *Handler) classes(p graphql.ResolveParams) (any, error) {
+	classes, err := h.taskService.GetClasses(p.Context)
+	if err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return classes, nil
+}
+
+// class is null when the school has no such class.
+func (h *Handler) class(p graphql.ResolveParams) (any, error) {
+	var input classInput
+	if err := h.decode(p.Context, p.Args, &input); err != nil {
+		return nil, err
+	}
+
+	classes, err := h.taskService.GetClasses(p.Context)
+	if err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	name := domain.NormalizeClassName(input.Name)
+	for _, class := range classes {
+		if class.Name == name {
+			return class, nil
+		}
+	}
+
+	return nil, nil
+}
+
+func (h *Handler) studentTasks(p graphql.ResolveParams) (any, error) {
+	var input studentTasksInput
+	if err := h.decode(p.Context, p.Args, &input); err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.taskService.GetStudentTasks(p.Context, input.Class, uuid.MustParse(input.StudentID))
+	if err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return newAssignments(domain.NormalizeClassName(input.Class), tasks), nil
+}
+
+func (h *Handler) studentMarks(p graphql.ResolveParams) (any, error) {
+	var input studentMarksInput
+	if err := h.decode(p.Context, p.Args, &input); err != nil {
+		return nil, err
+	}
+
+	var marks []domain.GradebookEntry
+	err := h.taskService.ExportStudentMarks(p.Context, uuid.MustParse(input.StudentID), func(entry *domain.GradebookEntry) error {
+		marks = append(marks, *entry)
+		return nil
+	})
+	if err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return marks, nil
+}
+
+func (h *Handler) classLessons(p graphql.ResolveParams) (any, error) {
+	class := p.Source.(classSource)
+	lessons := loadersFromContext(p.Context).lessons.load(p.Context, class.Name)
+
+	return func() (any, error) {
+		lessons, err := lessons()
+		if err != nil {
+			return nil, h.fail(p.Context, err)
+		}
+
+		return lessons, nil
+	}, nil
+}
+
+func (h *Handler) classAssignments(p graphql.ResolveParams) (any, error) {
+	class := p.Source.(classSource)
+	tasks := loadersFromContext(p.Context).assignments.load(p.Context, class.Name)
+
+	return func() (any, error) {
+		tasks, err := tasks()
+		if err != nil {
+			return nil, h.fail(p.Context, err)
+		}
+
+		return newAssignments(class.Name, tasks), nil
+	}, nil
+}
+
+// assignmentTemplate is null when the template was deleted or isn't shared with the caller.
+func (h *Handler) assignmentTemplate(p graphql.ResolveParams) (any, error) {
+	return h.template(p, p.Source.(assignmentSource).TaskTemplateID)
+}
+
+func (h *Handler) markTemplate(p graphql.ResolveParams) (any, error) {
+	return h.template(p, p.Source.(markSource).TaskTemplateID)
+}
+
+func (h *Handler) template(p graphql.ResolveParams, id uuid.UUID) (any, error) {
+	task := loadersFromContext(p.Context).tasks.load(p.Context, id)
+
+	return func() (any, error) {
+		task, err := task()
+		if err != nil {
+			return nil, h.fail(p.Context, err)
+		}
+		if task == nil {
+			return nil, nil
+		}
+
+		return task, nil
+	}, nil
+}
+
+func (h *Handler) assignmentMarks(p graphql.ResolveParams) (any, error) {
+	marks := loadersFromContext(p.Context).marks.load(p.Context, p.Source.(assignmentSource).TaskID)
+
+	return func() (any, error) {
+		marks, err := marks()
+		if err != nil {
+			return nil, h.fail(p.Context, err)
+		}
+
+		return marks, nil
+	}, nil
+}
+
+func (h *Handler) createTask(p graphql.ResolveParams) (any, error) {
+	var input taskInput
+	if err := h.decode(p.Context, p.Args["input"], &input); err != nil {
+		return nil, err
+	}
+
+	task := input.toDomain(uuid.New(), 0)
+	if _, err := h.taskService.CreateTask(p.Context, task); err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return task, nil
+}
+
+func (h *Handler) updateTask(p graphql.ResolveParams) (any, error) {
+	var id idInput
+	if err := h.decode(p.Context, p.Args, &id); err != nil {
+		return nil, err
+	}
+
+	var input taskUpdateInput
+	if err := h.decode(p.Context, p.Args["input"], &input); err != nil {
+		return nil, err
+	}
+
+	task := input.toDomain(uuid.MustParse(id.ID), id.Version)
+	if _, err := h.taskService.UpdateTask(p.Context, task); err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return task, nil
+}
+
+func (h *Handler) deleteTask(p graphql.ResolveParams) (any, error) {
+	var input idInput
+	if err := h.decode(p.Context, p.Args, &input); err != nil {
+		return nil, err
+	}
+
+	if err := h.taskService.DeleteTask(p.Context, uuid.MustParse(input.ID), input.Version); err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return true, nil
+}
+
+func (h *Handler) assignTask(p graphql.ResolveParams) (any, error) {
+	var input assignTaskInput
+	if err := h.decode(p.Context, p.Args["input"], &input); err != nil {
+		return nil, err
+	}
+
+	assignments, err := h.taskService.CreateAssignments(p.Context, input.toDomain())
+	if err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return assignments, nil
+}
+
+func (h *Handler) publishAssignment(p graphql.ResolveParams) (any, error) {
+	var input idInput
+	if err := h.decode(p.Context, p.Args, &input); err != nil {
+		return nil, err
+	}
+
+	if err := h.taskService.PublishAssignment(p.Context, uuid.MustParse(input.ID)); err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return true, nil
+}
+
+func (h *Handler) setMarks(p graphql.ResolveParams) (any, error) {
+	var input setMarksInput
+	if err := h.decode(p.Context, p.Args["input"], &input); err != nil {
+		return nil, err
+	}
+
+	if err := h.taskService.SetTaskResultsByUsers(p.Context, input.toDomain()); err != nil {
+		return nil, h.fail(p.Context, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,267 @@
+package graphqlserver
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/graphql-go/graphql"
+)
+
+// newSchema describes the types the frontends build their screens from. Task is a template,
+// Assignment is a task given to a class for a lesson, Mark is the result of a student for an assignment.
+// The fields of other objects are loaded in batches, see loader.
+func (h *Handler) newSchema() (graphql.Schema, error) {
+	task := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Task",
+		Description: "Template of a task.",
+		Fields: graphql.Fields{
+			"id":       {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveTask(func(t taskSource) any { return t.ID.String() })},
+			"payload":  {Type: graphql.NewNonNull(graphql.String), Resolve: resolveTask(func(t taskSource) any { return t.Payload })},
+			"deadline": {Type: graphql.DateTime, Resolve: resolveTask(func(t taskSource) any { return timeValue(t.Deadline) })},
+			"category": {Type: graphql.NewNonNull(graphql.String), Resolve: resolveTask(func(t taskSource) any { return t.Category })},
+			"version":  {Type: graphql.NewNonNull(graphql.Int), Resolve: resolveTask(func(t taskSource) any { return t.Version })},
+			"authorId": {Type: graphql.ID, Resolve: resolveTask(func(t taskSource) any { return idValue(t.AuthorID) })},
+		},
+	})
+
+	mark := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Mark",
+		Description: "Mark of a student for an assignment.",
+		Fields: graphql.Fields{
+			"studentId":    {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveMark(func(m markSource) any { return m.StudentID.String() })},
+			"assignmentId": {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveMark(func(m markSource) any { return m.TaskID.String() })},
+			"lessonId":     {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveMark(func(m markSource) any { return m.LessonID.String() })},
+			"class":        {Type: graphql.NewNonNull(graphql.String), Resolve: resolveMark(func(m markSource) any { return m.Class })},
+			"payload":      {Type: graphql.NewNonNull(graphql.String), Resolve: resolveMark(func(m markSource) any { return m.Payload })},
+			"deadline":     {Type: graphql.DateTime, Resolve: resolveMark(func(m markSource) any { return timeValue(m.Deadline) })},
+			"mark":         {Type: graphql.Int, Resolve: resolveMark(func(m markSource) any { return intValue(m.Mark) })},
+			"penalty":      {Type: graphql.NewNonNull(graphql.Int), Resolve: resolveMark(func(m markSource) any { return m.Penalty })},
+			"submittedAt":  {Type: graphql.DateTime, Resolve: resolveMark(func(m markSource) any { return timeValue(m.SubmittedAt) })},
+			"template":     {Type: task, Resolve: h.markTemplate},
+		},
+	})
+
+	assignment := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Assignment",
+		Description: "Published task of a class for a lesson. Deadlines of a student's tasks have the student's extensions applied.",
+		Fields: graphql.Fields{
+			"id":       {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveAssignment(func(a assignmentSource) any { return a.TaskID.String() })},
+			"class":    {Type: graphql.NewNonNull(graphql.String), Resolve: resolveAssignment(func(a assignmentSource) any { return a.class })},
+			"lessonId": {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveAssignment(func(a assignmentSource) any { return a.LessonID.String() })},
+			"payload":  {Type: graphql.NewNonNull(graphql.String), Resolve: resolveAssignment(func(a assignmentSource) any { return a.Payload })},
+			"deadline": {Type: graphql.DateTime, Resolve: resolveAssignment(func(a assignmentSource) any { return timeValue(a.Deadline) })},
+			"version":  {Type: graphql.NewNonNull(graphql.Int), Resolve: resolveAssignment(func(a assignmentSource) any { return a.Version })},
+			"template": {Type: task, Resolve: h.assignmentTemplate},
+			"marks":    {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(mark))), Resolve: h.assignmentMarks},
+		},
+	})
+
+	lesson := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Lesson",
+		Description: "Lesson of a class from the schedule.",
+		Fields: graphql.Fields{
+			"id":       {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveLesson(func(l lessonSource) any { return l.ID.String() })},
+			"subject":  {Type: graphql.NewNonNull(graphql.String), Resolve: resolveLesson(func(l lessonSource) any { return l.Subject })},
+			"startsAt": {Type: graphql.DateTime, Resolve: resolveLesson(func(l lessonSource) any { return timeValue(l.StartsAt) })},
+		},
+	})
+
+	class := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "Class",
+		Description: "Class of the school.",
+		Fields: graphql.Fields{
+			"id":          {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveClass(func(c classSource) any { return c.ID.String() })},
+			"name":        {Type: graphql.NewNonNull(graphql.String), Resolve: resolveClass(func(c classSource) any { return c.Name })},
+			"lessons":     {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(lesson))), Resolve: h.classLessons},
+			"assignments": {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(assignment))), Resolve: h.classAssignments},
+		},
+	})
+
+	newAssignment := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "NewAssignment",
+		Description: "Assignment created from a template.",
+		Fields: graphql.Fields{
+			"id":       {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveNewAssignment(func(a newAssignmentSource) any { return a.AssignmentID.String() })},
+			"class":    {Type: graphql.NewNonNull(graphql.String), Resolve: resolveNewAssignment(func(a newAssignmentSource) any { return a.Class })},
+			"lessonId": {Type: graphql.NewNonNull(graphql.ID), Resolve: resolveNewAssignment(func(a newAssignmentSource) any { return a.LessonID.String() })},
+			"status":   {Type: graphql.NewNonNull(graphql.String), Resolve: resolveNewAssignment(func(a newAssignmentSource) any { return string(a.Status) })},
+		},
+	})
+
+	taskInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "TaskInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"payload":  {Type: graphql.NewNonNull(graphql.String)},
+			"deadline": {Type: graphql.DateTime},
+			"category": {Type: graphql.String},
+		},
+	})
+
+	classLessonInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "ClassLessonInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"class":    {Type: graphql.NewNonNull(graphql.String)},
+			"lessonId": {Type: graphql.NewNonNull(graphql.ID)},
+		},
+	})
+
+	assignTaskInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "AssignTaskInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"taskId":    {Type: graphql.NewNonNull(graphql.ID)},
+			"assignTo":  {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(classLessonInput)))},
+			"draft":     {Type: graphql.Boolean},
+			"publishAt": {Type: graphql.DateTime},
+		},
+	})
+
+	markInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "MarkInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"studentId":   {Type: graphql.NewNonNull(graphql.ID)},
+			"mark":        {Type: graphql.NewNonNull(graphql.Int)},
+			"submittedAt": {Type: graphql.DateTime},
+		},
+	})
+
+	setMarksInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "SetMarksInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"assignmentId": {Type: graphql.NewNonNull(graphql.ID)},
+			"lessonId":     {Type: graphql.NewNonNull(graphql.ID)},
+			"marks":        {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(markInput)))},
+		},
+	})
+
+	id := &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.ID)}
+	version := &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"task": {
+				Type:    task,
+				Args:    graphql.FieldConfigArgument{"id": id},
+				Resolve: h.task,
+			},
+			"tasks": {
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(task))),
+				Resolve: h.tasks,
+			},
+			"classes": {
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(class))),
+				Resolve: h.classes,
+			},
+			"class": {
+				Type:    class,
+				Args:    graphql.FieldConfigArgument{"name": {Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: h.class,
+			},
+			"studentTasks": {
+				Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(assignment))),
+				Args: graphql.FieldConfigArgument{
+					"class":     {Type: graphql.NewNonNull(graphql.String)},
+					"studentId": id,
+				},
+				Resolve: h.studentTasks,
+			},
+			"studentMarks": {
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(mark))),
+				Args:    graphql.FieldConfigArgument{"studentId": id},
+				Resolve: h.studentMarks,
+			},
+		},
+	})
+
+	mutation := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createTask": {
+				Type:    graphql.NewNonNull(task),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(taskInput)}},
+				Resolve: h.createTask,
+			},
+			"updateTask": {
+				Type: graphql.NewNonNull(task),
+				Args: graphql.FieldConfigArgument{
+					"id":      id,
+					"version": version,
+					"input":   {Type: graphql.NewNonNull(taskInput)},
+				},
+				Resolve: h.updateTask,
+			},
+			"deleteTask": {
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    graphql.FieldConfigArgument{"id": id, "version": version},
+				Resolve: h.deleteTask,
+			},
+			"assignTask": {
+				Type:    graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(newAssignment))),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(assignTaskInput)}},
+				Resolve: h.assignTask,
+			},
+			"publishAssignment": {
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    graphql.FieldConfigArgument{"id": id},
+				Resolve: h.publishAssignment,
+			},
+			"setMarks": {
+				Type:    graphql.NewNonNull(graphql.Boolean),
+				Args:    graphql.FieldConfigArgument{"input": {Type: graphql.NewNonNull(setMarksInput)}},
+				Resolve: h.setMarks,
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+}
+
+// The scalar resolvers read their object from the source, null pointers become nulls.
+
+func resolveTask(field func(taskSource) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) { return field(p.Source.(taskSource)), nil }
+}
+
+func resolveMark(field func(markSource) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) { return field(p.Source.(markSource)), nil }
+}
+
+func resolveAssignment(field func(assignmentSource) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) { return field(p.Source.(assignmentSource)), nil }
+}
+
+func resolveLesson(field func(lessonSource) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) { return field(p.Source.(lessonSource)), nil }
+}
+
+func resolveClass(field func(classSource) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) { return field(p.Source.(classSource)), nil }
+}
+
+func resolveNewAssignment(field func(newAssignmentSource) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) { return field(p.Source.(newAssignmentSource)), nil }
+}
+
+func timeValue(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+
+	return *t
+}
+
+func idValue(id *uuid.UUID) any {
+	if id == nil {
+		return nil
+	}
+
+	return id.String()
+}
+
+func intValue(i *int) any {
+	if i == nil {
+		return nil
+	}
+
+	return *i
+}
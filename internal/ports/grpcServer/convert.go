@@ -0,0 +1,48 @@
+package grpcserver
+
+import (
+	taskv1 "task/api/task/v1"
+	"task/internal/domain"
+	"time"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func newTask(task *domain.Task) *taskv1.Task {
+	resp := &taskv1.Task{
+		Id:       task.ID.String(),
+		Payload:  task.Payload,
+		Deadline: timestamp(task.Deadline),
+		Category: task.Category,
+		Version:  int64(task.Version),
+	}
+	if task.AuthorID != nil {
+		resp.AuthorId = task.AuthorID.String()
+	}
+
+	return resp
+}
+
+func newLessonTasks(tasks []*domain.LessonTask) *taskv1.ListLessonTasksResponse {
+	resp := &taskv1.ListLessonTasksResponse{Tasks: make([]*taskv1.LessonTask, 0, len(tasks))}
+	for _, task := range tasks {
+		resp.Tasks = append(resp.Tasks, &taskv1.LessonTask{
+			LessonId:       task.LessonID.String(),
+			TaskId:         task.TaskID.String(),
+			Payload:        task.Payload,
+			Deadline:       timestamp(task.Deadline),
+			TaskTemplateId: task.TaskTemplateID.String(),
+			Version:        int64(task.Version),
+		})
+	}
+
+	return resp
+}
+
+func timestamp(t *time.Time) *timestamppb.Timestamp {
+	if t == nil {
+		return nil
+	}
+
+	return timestamppb.New(*t)
+}
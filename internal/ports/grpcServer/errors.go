@@ -0,0 +1,69 @@
+package grpcserver
+
+import (
+	"task/internal/domain"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var kindCode = map[domain.Kind]codes.Code{
+	domain.KindNotFound:     codes.NotFound,
+	domain.KindConflict:     codes.AlreadyExists,
+	domain.KindValidation:   codes.InvalidArgument,
+	domain.KindForbidden:    codes.PermissionDenied,
+	domain.KindLocked:       codes.FailedPrecondition,
+	domain.KindPrecondition: codes.FailedPrecondition,
+}
+
+// toStatus maps domain errors the same way the REST API does. Any other error is internal: its text
+// may come from the database, so the client gets a generic message and the details stay in the log.
+func toStatus(err error) error {
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	code, ok := kindCode[domainErr.Kind]
+	if !ok {
+		return status.Error(codes.Internal, "internal server error")
+	}
+
+	violations := make(violations, 0, len(domainErr.Fields))
+	for _, field := range domainErr.Fields {
+		violations.add(field.Field, field.Message)
+	}
+
+	return violations.status(code, domainErr.Message)
+}
+
+// violations collects every invalid field of a request, so the client learns about all of them at once.
+type violations []*errdetails.BadRequest_FieldViolation
+
+func (v *violations) add(field, message string) {
+	*v = append(*v, &errdetails.BadRequest_FieldViolation{Field: field, Description: message})
+}
+
+// err is nil when the request is valid.
+func (v violations) err() error {
+	if len(v) == 0 {
+		return nil
+	}
+
+	return v.status(codes.InvalidArgument, "request is not valid")
+}
+
+func (v violations) status(code codes.Code, message string) error {
+	st := status.New(code, message)
+	if len(v) == 0 {
+		return st.Err()
+	}
+
+	detailed, err := st.WithDetails(&errdetails.BadRequest{FieldViolations: v})
+	if err != nil {
+		return st.Err()
+	}
+
+	return detailed.Err()
+}
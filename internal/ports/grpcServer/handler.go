@@ -0,0 +1,356 @@
+package grpcserver
+
+import (
+	"context"
+	"log/slog"
+	"strconv"
+	taskv1 "task/api/task/v1"
+	"task/internal/domain"
+	"task/pkg/logging"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// TaskService is the part of the task service exposed over gRPC: tasks, assignments and results.
+type TaskService interface {
+	CreateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error)
+	GetTask(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+	GetTasks(ctx context.Context) ([]*domain.Task, error)
+	UpdateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error)
+	DeleteTask(ctx context.Context, id uuid.UUID, version int) error
+	CreateAssignments(ctx context.Context, taskAssignments *domain.TaskAsignments) ([]domain.Assignment, error)
+	CreateTaskWithAssignments(ctx context.Context, assignments *domain.TaskWithAsignment) (uuid.UUID, error)
+	GetTaskByClass(ctx context.Context, class string) ([]*domain.LessonTask, error)
+	GetStudentTasks(ctx context.Context, class string, studentID uuid.UUID) ([]*domain.LessonTask, error)
+	UpdateAssignment(ctx context.Context, task *domain.TaskAsignment) error
+	DeleteAssignment(ctx context.Context, assignmentID uuid.UUID, version int) error
+	PublishAssignment(ctx context.Context, assignmentID uuid.UUID) error
+	SetTaskResultsByUsers(ctx context.Context, taskResults *domain.TaskResult) error
+}
+
+type Handler struct {
+	taskv1.UnimplementedTaskServiceServer
+	taskService TaskService
+	logger      *slog.Logger
+}
+
+func NewHandler(logger *slog.Logger, taskService TaskService) *Handler {
+	return &Handler{
+		logger:      logger,
+		taskService: taskService,
+	}
+}
+
+// log returns the logger of the call, which carries its request ID, method, user and tenant.
+func (h *Handler) log(ctx context.Context) *slog.Logger {
+	return logging.FromContext(ctx, h.logger)
+}
+
+func (h *Handler) CreateTask(ctx context.Context, req *taskv1.CreateTaskRequest) (*taskv1.CreateTaskResponse, error) {
+	var v violations
+	v.required("payload", req.GetPayload(), domain.MaxPayloadLength)
+	v.maxLength("category", req.GetCategory(), domain.MaxCategoryLength)
+	deadline := v.deadline("deadline", req.GetDeadline())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	task := &domain.Task{
+		ID:       uuid.New(),
+		Payload:  req.GetPayload(),
+		Deadline: deadline,
+		Category: req.GetCategory(),
+	}
+
+	id, err := h.taskService.CreateTask(ctx, task)
+	if err != nil {
+		h.log(ctx).Error("failed to create task", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return &taskv1.CreateTaskResponse{Id: id.String(), Version: int64(task.Version)}, nil
+}
+
+func (h *Handler) GetTask(ctx context.Context, req *taskv1.GetTaskRequest) (*taskv1.Task, error) {
+	var v violations
+	id := v.id("id", req.GetId())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	task, err := h.taskService.GetTask(ctx, id)
+	if err != nil {
+		h.log(ctx).Error("failed to get task", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return newTask(task), nil
+}
+
+func (h *Handler) ListTasks(ctx context.Context, _ *taskv1.ListTasksRequest) (*taskv1.ListTasksResponse, error) {
+	tasks, err := h.taskService.GetTasks(ctx)
+	if err != nil {
+		h.log(ctx).Error("failed to get tasks", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	resp := &taskv1.ListTasksResponse{Tasks: make([]*taskv1.Task, 0, len(tasks))}
+	for _, task := range tasks {
+		resp.Tasks = append(resp.Tasks, newTask(task))
+	}
+
+	return resp, nil
+}
+
+func (h *Handler) UpdateTask(ctx context.Context, req *taskv1.UpdateTaskRequest) (*taskv1.UpdateTaskResponse, error) {
+	var v violations
+	id := v.id("id", req.GetId())
+	v.required("payload", req.GetPayload(), domain.MaxPayloadLength)
+	v.maxLength("category", req.GetCategory(), domain.MaxCategoryLength)
+	deadline := v.time("deadline", req.GetDeadline())
+	version := v.version("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	task := &domain.Task{
+		ID:       id,
+		Payload:  req.GetPayload(),
+		Deadline: deadline,
+		Category: req.GetCategory(),
+		Version:  version,
+	}
+
+	_, err := h.taskService.UpdateTask(ctx, task)
+	if err != nil {
+		h.log(ctx).Error("failed to update task", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return &taskv1.UpdateTaskResponse{Id: id.String(), Version: int64(task.Version)}, nil
+}
+
+func (h *Handler) DeleteTask(ctx context.Context, req *taskv1.DeleteTaskRequest) (*emptypb.Empty, error) {
+	var v violations
+	id := v.id("id", req.GetId())
+	version := v.version("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.taskService.DeleteTask(ctx, id, version); err != nil {
+		h.log(ctx).Error("failed to delete task", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) CreateAssignments(ctx context.Context, req *taskv1.CreateAssignmentsRequest) (*taskv1.CreateAssignmentsResponse, error) {
+	var v violations
+	taskID := v.id("template_task_id", req.GetTemplateTaskId())
+	v.items("assign_to", len(req.GetAssignTo()), maxAssignTo)
+	toAssign := make([]domain.ClassLesson, 0, len(req.GetAssignTo()))
+	lessons := make(map[uuid.UUID]bool, len(req.GetAssignTo()))
+	for i, classLesson := range req.GetAssignTo() {
+		v.required(itemField("assign_to", i, "class"), classLesson.GetClass(), domain.MaxClassNameLength)
+		lessonID := v.id(itemField("assign_to", i, "lesson_id"), classLesson.GetLessonId())
+		if lessonID != uuid.Nil && lessons[lessonID] {
+			v.add("assign_to", "must not contain duplicates")
+		}
+		lessons[lessonID] = true
+		toAssign = append(toAssign, domain.ClassLesson{Class: classLesson.GetClass(), LessonID: lessonID})
+	}
+	publishAt := v.time("publish_at", req.GetPublishAt())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	assignments, err := h.taskService.CreateAssignments(ctx, &domain.TaskAsignments{
+		TaskID:    taskID,
+		ToAssign:  toAssign,
+		Draft:     req.GetDraft(),
+		PublishAt: publishAt,
+	})
+	if err != nil {
+		h.log(ctx).Error("failed to create assignments", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	resp := &taskv1.CreateAssignmentsResponse{
+		TemplateTaskId: taskID.String(),
+		Assignments:    make([]*taskv1.Assignment, 0, len(assignments)),
+	}
+	for _, assignment := range assignments {
+		resp.Assignments = append(resp.Assignments, &taskv1.Assignment{
+			Id:       assignment.AssignmentID.String(),
+			Class:    assignment.Class,
+			LessonId: assignment.LessonID.String(),
+			Status:   string(assignment.Status),
+		})
+	}
+
+	return resp, nil
+}
+
+func (h *Handler) CreateTaskWithAssignment(ctx context.Context,
+	req *taskv1.CreateTaskWithAssignmentRequest) (*taskv1.CreateTaskWithAssignmentResponse, error) {
+	var v violations
+	v.required("class", req.GetClass(), domain.MaxClassNameLength)
+	lessonID := v.id("lesson_id", req.GetLessonId())
+	v.required("payload", req.GetPayload(), domain.MaxPayloadLength)
+	deadline := v.deadline("deadline", req.GetDeadline())
+	publishAt := v.time("publish_at", req.GetPublishAt())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	id, err := h.taskService.CreateTaskWithAssignments(ctx, &domain.TaskWithAsignment{
+		Class:     req.GetClass(),
+		LessonID:  lessonID,
+		TaskID:    uuid.New(),
+		Payload:   req.GetPayload(),
+		Deadline:  deadline,
+		Draft:     req.GetDraft(),
+		PublishAt: publishAt,
+	})
+	if err != nil {
+		h.log(ctx).Error("failed to create task with assignment", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return &taskv1.CreateTaskWithAssignmentResponse{TaskId: id.String()}, nil
+}
+
+func (h *Handler) ListClassTasks(ctx context.Context, req *taskv1.ListClassTasksRequest) (*taskv1.ListLessonTasksResponse, error) {
+	var v violations
+	v.maxLength("class", req.GetClass(), domain.MaxClassNameLength)
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.taskService.GetTaskByClass(ctx, req.GetClass())
+	if err != nil {
+		h.log(ctx).Error("failed to get tasks by class", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return newLessonTasks(tasks), nil
+}
+
+func (h *Handler) ListStudentTasks(ctx context.Context, req *taskv1.ListStudentTasksRequest) (*taskv1.ListLessonTasksResponse, error) {
+	var v violations
+	v.required("class", req.GetClass(), domain.MaxClassNameLength)
+	studentID := v.id("student_id", req.GetStudentId())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	tasks, err := h.taskService.GetStudentTasks(ctx, req.GetClass(), studentID)
+	if err != nil {
+		h.log(ctx).Error("failed to get student tasks", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return newLessonTasks(tasks), nil
+}
+
+func (h *Handler) UpdateAssignment(ctx context.Context, req *taskv1.UpdateAssignmentRequest) (*taskv1.UpdateAssignmentResponse, error) {
+	var v violations
+	id := v.id("id", req.GetId())
+	v.required("class", req.GetClass(), domain.MaxClassNameLength)
+	v.required("payload", req.GetPayload(), domain.MaxPayloadLength)
+	version := v.version("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	assignment := &domain.TaskAsignment{
+		AssignmentID: id,
+		Class:        req.GetClass(),
+		Payload:      req.GetPayload(),
+		Version:      version,
+	}
+
+	if err := h.taskService.UpdateAssignment(ctx, assignment); err != nil {
+		h.log(ctx).Error("failed to update assignment", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return &taskv1.UpdateAssignmentResponse{Version: int64(assignment.Version)}, nil
+}
+
+func (h *Handler) DeleteAssignment(ctx context.Context, req *taskv1.DeleteAssignmentRequest) (*emptypb.Empty, error) {
+	var v violations
+	id := v.id("id", req.GetId())
+	version := v.version("version", req.GetVersion())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.taskService.DeleteAssignment(ctx, id, version); err != nil {
+		h.log(ctx).Error("failed to delete assignment", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) PublishAssignment(ctx context.Context, req *taskv1.PublishAssignmentRequest) (*emptypb.Empty, error) {
+	var v violations
+	id := v.id("id", req.GetId())
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	if err := h.taskService.PublishAssignment(ctx, id); err != nil {
+		h.log(ctx).Error("failed to publish assignment", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *Handler) SetTaskResults(ctx context.Context, req *taskv1.SetTaskResultsRequest) (*emptypb.Empty, error) {
+	var v violations
+	taskID := v.id("task_id", req.GetTaskId())
+	lessonID := v.id("lesson_id", req.GetLessonId())
+	v.items("users_result", len(req.GetUsersResult()), maxUsersResult)
+	results := make([]domain.UserResult, 0, len(req.GetUsersResult()))
+	users := make(map[uuid.UUID]bool, len(req.GetUsersResult()))
+	for i, result := range req.GetUsersResult() {
+		userID := v.id(itemField("users_result", i, "user_id"), result.GetUserId())
+		if userID != uuid.Nil && users[userID] {
+			v.add("users_result", "must not contain duplicates")
+		}
+		users[userID] = true
+		switch {
+		case result.GetMark() < 0:
+			v.add(itemField("users_result", i, "mark"), "must be at least 0")
+		case result.GetMark() > domain.MaxMark:
+			v.add(itemField("users_result", i, "mark"), "must be at most "+strconv.Itoa(domain.MaxMark))
+		}
+
+		userResult := domain.UserResult{UserID: userID, Mark: int(result.GetMark())}
+		if submittedAt := v.time(itemField("users_result", i, "submitted_at"), result.GetSubmittedAt()); submittedAt != nil {
+			userResult.SubmittedAt = *submittedAt
+		}
+		results = append(results, userResult)
+	}
+	if err := v.err(); err != nil {
+		return nil, err
+	}
+
+	err := h.taskService.SetTaskResultsByUsers(ctx, &domain.TaskResult{
+		TaskID:      taskID,
+		LessonID:    lessonID,
+		UsersResult: results,
+	})
+	if err != nil {
+		h.log(ctx).Error("failed to set results", slog.String("error", err.Error()))
+		return nil, toStatus(err)
+	}
+
+	return &emptypb.Empty{}, nil
+}
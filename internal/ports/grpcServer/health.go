@@ -0,0 +1,37 @@
+package grpcserver
+
+import (
+	"context"
+	taskv1 "task/api/task/v1"
+	"task/pkg/health"
+
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+)
+
+// Health is the standard gRPC health service answered by the same checks as the readiness probe.
+// Watch isn't supported: the checks run on demand, so there is nothing to stream.
+type Health struct {
+	healthpb.UnimplementedHealthServer
+	checker *health.Checker
+}
+
+func NewHealth(checker *health.Checker) *Health {
+	return &Health{checker: checker}
+}
+
+// Check reports the whole server for the empty service name and the task service by its name.
+func (h *Health) Check(ctx context.Context, req *healthpb.HealthCheckRequest) (*healthpb.HealthCheckResponse, error) {
+	switch req.GetService() {
+	case "", taskv1.TaskService_ServiceDesc.ServiceName:
+	default:
+		return nil, status.Error(codes.NotFound, "unknown service "+req.GetService())
+	}
+
+	if !h.checker.Ready(ctx).Ready {
+		return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_NOT_SERVING}, nil
+	}
+
+	return &healthpb.HealthCheckResponse{Status: healthpb.HealthCheckResponse_SERVING}, nil
+}
@@ -0,0 +1,191 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	taskv1 "task/api/task/v1"
+	"task/internal/domain"
+	"task/pkg/logging"
+	"time"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// The metadata keys match the headers of the REST API, gRPC metadata keys are lowercase.
+const (
+	requestIDKey = "x-request-id"
+	// maxRequestIDLength bounds the request IDs taken from clients, they end up in every log line.
+	maxRequestIDLength = 128
+
+	userIDKey        = "x-user-id"
+	tenantKey        = "x-tenant-id"
+	gatewaySecretKey = "x-gateway-secret"
+)
+
+// GatewayAuth rejects the calls to the task service without the secret shared with the gateway in the
+// x-gateway-secret metadata, so clients that bypass the gateway can't pick the school or the user themselves.
+// While the secret is empty every such call is rejected.
+func GatewayAuth(logger *slog.Logger, secret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !isTaskMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		value := metadataValue(ctx, gatewaySecretKey)
+		if secret == "" || subtle.ConstantTimeCompare([]byte(value), []byte(secret)) != 1 {
+			logging.FromContext(ctx, logger).Error("call didn't come through the gateway")
+			return nil, status.Error(codes.Unauthenticated, "missing or invalid "+gatewaySecretKey+" metadata")
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// TenantContext puts the school the call works in, from the x-tenant-id metadata, into the context.
+// Calls without it belong to the default school unless requireTenant is set. Health checks and
+// reflection don't work with data and don't need a school.
+func TenantContext(logger *slog.Logger, requireTenant bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if !isTaskMethod(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		value := metadataValue(ctx, tenantKey)
+		if value == "" {
+			if requireTenant {
+				return nil, status.Error(codes.InvalidArgument, "missing "+tenantKey+" metadata")
+			}
+			return handler(ctx, req)
+		}
+
+		tenantID, err := uuid.Parse(value)
+		if err != nil {
+			logging.FromContext(ctx, logger).Error("failed to parse tenant id", slog.String("error", err.Error()))
+			return nil, status.Error(codes.InvalidArgument, "invalid "+tenantKey+" metadata")
+		}
+
+		ctx = logging.With(ctx, logger, slog.String("tenant_id", tenantID.String()))
+		return handler(domain.ContextWithTenant(ctx, tenantID), req)
+	}
+}
+
+// UserContext puts the ID of the calling user from the x-user-id metadata into the context.
+func UserContext(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		value := metadataValue(ctx, userIDKey)
+		if value == "" {
+			return handler(ctx, req)
+		}
+
+		userID, err := uuid.Parse(value)
+		if err != nil {
+			logging.FromContext(ctx, logger).Error("failed to parse user id", slog.String("error", err.Error()))
+			return nil, status.Error(codes.InvalidArgument, "invalid "+userIDKey+" metadata")
+		}
+
+		ctx = logging.With(ctx, logger, slog.String("user_id", userID.String()))
+		return handler(domain.ContextWithUserID(ctx, userID), req)
+	}
+}
+
+// RequestID takes the request ID from the x-request-id metadata or generates one, sends it back in the header
+// and puts a logger carrying it, the method and the trace into the context.
+func RequestID(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		requestID := metadataValue(ctx, requestIDKey)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+		if err := grpc.SetHeader(ctx, metadata.Pairs(requestIDKey, requestID)); err != nil {
+			logger.Error("failed to set request id header", slog.String("error", err.Error()))
+		}
+
+		args := []any{
+			slog.String("request_id", requestID),
+			slog.String("method", info.FullMethod),
+		}
+		if span := trace.SpanContextFromContext(ctx); span.IsValid() {
+			args = append(args, slog.String("trace_id", span.TraceID().String()))
+		}
+
+		return handler(logging.With(ctx, logger, args...), req)
+	}
+}
+
+// AccessLog logs every call once it is served, with the logger of the call.
+func AccessLog(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		code := status.Code(err)
+		if isHealthCheck(info.FullMethod) && code == codes.OK {
+			return resp, err
+		}
+
+		level := slog.LevelInfo
+		switch code {
+		case codes.OK:
+		case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+			level = slog.LevelError
+		default:
+			level = slog.LevelWarn
+		}
+
+		logging.FromContext(ctx, logger).LogAttrs(ctx, level, "call",
+			slog.String("code", code.String()),
+			slog.Duration("duration", time.Since(start)),
+		)
+
+		return resp, err
+	}
+}
+
+// Recovery turns a panic in a handler into an Internal error and logs it with the stack,
+// instead of crashing the whole server.
+func Recovery(logger *slog.Logger) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			logging.FromContext(ctx, logger).Error("panic in handler",
+				slog.String("error", fmt.Sprint(recovered)),
+				slog.String("stack", string(debug.Stack())),
+			)
+			resp, err = nil, status.Error(codes.Internal, "internal server error")
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+func metadataValue(ctx context.Context, key string) string {
+	values := metadata.ValueFromIncomingContext(ctx, key)
+	if len(values) == 0 {
+		return ""
+	}
+
+	return values[0]
+}
+
+func isTaskMethod(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+taskv1.TaskService_ServiceDesc.ServiceName+"/")
+}
+
+func isHealthCheck(fullMethod string) bool {
+	return strings.HasPrefix(fullMethod, "/"+healthpb.Health_ServiceDesc.ServiceName+"/")
+}
@@ -0,0 +1,94 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net"
+	taskv1 "task/api/task/v1"
+	"task/internal/config"
+	"task/pkg/health"
+	"task/pkg/metrics"
+	"time"
+
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/stats"
+)
+
+type Server struct {
+	server          *grpc.Server
+	addr            string
+	logger          *slog.Logger
+	shutDownTimeout time.Duration
+}
+
+func NewGRPCServer(config *config.GRPCConfig, logger *slog.Logger, taskService TaskService, checker *health.Checker,
+	requireTenant bool, gatewaySecret string) *Server {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler(otelgrpc.WithFilter(func(info *stats.RPCTagInfo) bool {
+			return !isHealthCheck(info.FullMethodName)
+		}))),
+		// Recovery is the innermost, so the access log and the metrics see the Internal code of a panic.
+		grpc.ChainUnaryInterceptor(
+			metrics.GRPC(),
+			RequestID(logger),
+			AccessLog(logger),
+			Recovery(logger),
+			GatewayAuth(logger, gatewaySecret),
+			TenantContext(logger, requireTenant),
+			UserContext(logger),
+		),
+	)
+
+	taskv1.RegisterTaskServiceServer(server, NewHandler(logger, taskService))
+	healthpb.RegisterHealthServer(server, NewHealth(checker))
+	reflection.Register(server)
+
+	return &Server{
+		server:          server,
+		addr:            ":" + config.Port,
+		logger:          logger,
+		shutDownTimeout: config.ShutdownTimeout,
+	}
+}
+
+func (s *Server) Run(ctx context.Context) error {
+	listener, err := net.Listen("tcp", s.addr)
+	if err != nil {
+		return fmt.Errorf("listen %s: %w", s.addr, err)
+	}
+
+	errChan := make(chan error)
+	go func() {
+		s.logger.Info(fmt.Sprintf("starting gRPC listening: %s", s.addr))
+
+		errChan <- s.server.Serve(listener)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case err = <-errChan:
+	}
+	return err
+}
+
+// Stop waits for the calls in flight up to the shutdown timeout and then cancels the rest.
+// The health service already reports NOT_SERVING, as the HTTP server drains first.
+func (s *Server) Stop() {
+	stopped := make(chan struct{})
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-time.After(s.shutDownTimeout):
+		s.logger.Error("failed to stop gRPC server gracefully, cancelling calls in flight")
+		s.server.Stop()
+	}
+}
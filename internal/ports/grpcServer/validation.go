@@ -0,0 +1,99 @@
+package grpcserver
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// The messages match the field errors of the REST API.
+
+func (v *violations) id(field, value string) uuid.UUID {
+	if value == "" {
+		v.add(field, "is required")
+		return uuid.Nil
+	}
+
+	id, err := uuid.Parse(value)
+	if err != nil {
+		v.add(field, "must be a UUID")
+		return uuid.Nil
+	}
+
+	return id
+}
+
+func (v *violations) required(field, value string, maxLength int) {
+	switch {
+	case value == "":
+		v.add(field, "is required")
+	case strings.TrimSpace(value) == "":
+		v.add(field, "can't be blank")
+	default:
+		v.maxLength(field, value, maxLength)
+	}
+}
+
+func (v *violations) maxLength(field, value string, maxLength int) {
+	if utf8.RuneCountInString(value) > maxLength {
+		v.add(field, "must be at most "+strconv.Itoa(maxLength)+" characters long")
+	}
+}
+
+func (v *violations) items(field string, count, maxCount int) {
+	switch {
+	case count == 0:
+		v.add(field, "is required")
+	case count > maxCount:
+		v.add(field, "must have at most "+strconv.Itoa(maxCount)+" items")
+	}
+}
+
+func (v *violations) version(field string, version int64) int {
+	if version < 0 {
+		v.add(field, "must be at least 0")
+		return 0
+	}
+
+	return int(version)
+}
+
+// time returns nil when the timestamp isn't set.
+func (v *violations) time(field string, ts *timestamppb.Timestamp) *time.Time {
+	if ts == nil {
+		return nil
+	}
+
+	if err := ts.CheckValid(); err != nil {
+		v.add(field, "is not valid")
+		return nil
+	}
+
+	t := ts.AsTime()
+	return &t
+}
+
+// deadline returns nil when the timestamp isn't set and requires it to be in the future otherwise.
+func (v *violations) deadline(field string, ts *timestamppb.Timestamp) *time.Time {
+	deadline := v.time(field, ts)
+	if deadline != nil && !deadline.After(time.Now()) {
+		v.add(field, "must be in the future")
+	}
+
+	return deadline
+}
+
+// The list limits match the REST API.
+const (
+	maxAssignTo    = 100
+	maxUsersResult = 1000
+)
+
+// itemField names a field of a list item the way the REST API does, e.g. users_result[0].user_id.
+func itemField(list string, index int, field string) string {
+	return list + "[" + strconv.Itoa(index) + "]." + field
+}
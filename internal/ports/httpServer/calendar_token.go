@@ -0,0 +1,40 @@
+package httpserver
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// Calendar apps can't send headers, so a feed URL carries a token that grants read access to that feed only.
+// The token is an HMAC of the school and the feed, so it needs no storage; rotating the secret revokes all of them.
+// The school travels in the tenant query parameter, and a token is only valid together with it.
+
+func classCalendarPath(class string) string {
+	return "/api/v1/class/" + url.PathEscape(domain.NormalizeClassName(class)) + "/calendar.ics"
+}
+
+func studentCalendarPath(class string, studentID uuid.UUID) string {
+	return "/api/v1/class/" + url.PathEscape(domain.NormalizeClassName(class)) + "/student/" + studentID.String() + "/calendar.ics"
+}
+
+func (h *Handler) calendarToken(tenantID uuid.UUID, feedPath string) string {
+	mac := hmac.New(sha256.New, h.calendarSecret)
+	mac.Write([]byte(tenantID.String() + ":" + feedPath))
+
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (h *Handler) validCalendarToken(tenantID uuid.UUID, feedPath, token string) bool {
+	return hmac.Equal([]byte(h.calendarToken(tenantID, feedPath)), []byte(token))
+}
+
+func (h *Handler) calendarLink(tenantID uuid.UUID, feedPath string) string {
+	query := url.Values{"token": {h.calendarToken(tenantID, feedPath)}, tenantQuery: {tenantID.String()}}
+
+	return feedPath + "?" + query.Encode()
+}
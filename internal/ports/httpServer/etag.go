@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+func etag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+func setETag(c *gin.Context, version int) {
+	c.Header("ETag", etag(version))
+}
+
+// ifMatchVersion returns the version from the If-Match header; zero means the request may change any version.
+// When the header is missing and required, or can't be parsed, it writes the error response and returns false.
+func (h *Handler) ifMatchVersion(c *gin.Context) (int, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		if h.requireIfMatch {
+			h.log(c).Error("missing If-Match header")
+			h.problem(c, http.StatusPreconditionRequired, "If-Match header is required")
+			return 0, false
+		}
+		return 0, true
+	}
+
+	if header == "*" {
+		return 0, true
+	}
+
+	version, err := parseETag(header)
+	if err != nil {
+		h.log(c).Error("failed to parse If-Match", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return 0, false
+	}
+
+	return version, true
+}
+
+// notModified answers 304 when the If-None-Match header matches the current version.
+func notModified(c *gin.Context, version int) bool {
+	header := c.GetHeader("If-None-Match")
+	if header == "" {
+		return false
+	}
+
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" || strings.TrimPrefix(tag, "W/") == etag(version) {
+			setETag(c, version)
+			c.Status(http.StatusNotModified)
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseETag(tag string) (int, error) {
+	unquoted, err := strconv.Unquote(strings.TrimPrefix(tag, "W/"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid entity tag %s", tag)
+	}
+
+	version, err := strconv.Atoi(unquoted)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("invalid entity tag %s", tag)
+	}
+
+	return version, nil
+}
@@ -2,13 +2,13 @@ package httpserver
 
 import (
 	"context"
-	"errors"
 	"log/slog"
 	"net/http"
 	"task/internal/domain"
-	"task/internal/ports/httpServer/common"
 	"task/internal/ports/httpServer/request"
 	"task/internal/ports/httpServer/response"
+	"task/pkg/health"
+	"task/pkg/logging"
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -19,414 +19,372 @@ type TaskService interface {
 	GetTask(ctx context.Context, id uuid.UUID) (*domain.Task, error)
 	GetTasks(ctx context.Context) ([]*domain.Task, error)
 	UpdateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error)
-	DeleteTask(ctx context.Context, id uuid.UUID) error
+	DeleteTask(ctx context.Context, id uuid.UUID, version int) error
 	CreateAssignments(ctx context.Context, taskAssignments *domain.TaskAsignments) ([]domain.Assignment, error)
 	GetTaskByClass(ctx context.Context, ckass string) ([]*domain.LessonTask, error)
 	SetTaskResultsByUsers(ctx context.Context, taskResults *domain.TaskResult) error
-	DeleteAssignment(ctx context.Context, assignmentID uuid.UUID) error
+	DeleteAssignment(ctx context.Context, assignmentID uuid.UUID, version int) error
 	CreateTaskWithAssignments(ctx context.Context, assignments *domain.TaskWithAsignment) (uuid.UUID, error)
 	UpdateAssignment(ctx context.Context, task *domain.TaskAsignment) error
+	GetAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.TaskAsignment, error)
+	GetMarksByAssignments(ctx context.Context, assignmentIDs []uuid.UUID) (map[uuid.UUID][]domain.GradebookEntry, error)
+	SetAssignmentLatePolicy(ctx context.Context, policy *domain.AssignmentLatePolicy) error
+	SetDeadlineExtension(ctx context.Context, extension *domain.DeadlineExtension) error
+	DeleteDeadlineExtension(ctx context.Context, assignmentID, userID uuid.UUID) error
+	PublishAssignment(ctx context.Context, assignmentID uuid.UUID) error
+	CreateRecurrence(ctx context.Context, recurrence *domain.Recurrence) (uuid.UUID, error)
+	StopRecurrence(ctx context.Context, id uuid.UUID) error
+	SetOccurrenceException(ctx context.Context, exception *domain.OccurrenceException) error
+	GetTrash(ctx context.Context) (*domain.Trash, error)
+	RestoreTask(ctx context.Context, id uuid.UUID) error
+	RestoreAssignment(ctx context.Context, assignmentID uuid.UUID) error
+	PatchTask(ctx context.Context, patch *domain.TaskPatch) (*domain.Task, error)
+	PatchAssignment(ctx context.Context, patch *domain.AssignmentPatch) (*domain.TaskAsignment, error)
+	CreateTasksBulk(ctx context.Context, mode domain.BulkMode, tasks []*domain.Task) (*domain.BulkResult, error)
+	AssignTasksBulk(ctx context.Context, bulk *domain.BulkAssignments) (*domain.BulkResult, error)
+	DeleteAssignmentsBulk(ctx context.Context, mode domain.BulkMode, assignmentIDs []uuid.UUID) (*domain.BulkResult, error)
+	UpdateDeadlinesBulk(ctx context.Context, mode domain.BulkMode, updates []domain.DeadlineUpdate) (*domain.BulkResult, error)
+	ImportTasks(ctx context.Context, table [][]string, dryRun bool) (*domain.ImportReport, error)
+	ImportMarks(ctx context.Context, table [][]string, dryRun bool) (*domain.ImportReport, error)
+	ExportTasks(ctx context.Context, fn func(*domain.Task) error) error
+	ExportGradebook(ctx context.Context, class string, fn func(*domain.GradebookEntry) error) error
+	ExportStudentMarks(ctx context.Context, studentID uuid.UUID, fn func(*domain.GradebookEntry) error) error
+	GetStudentTasks(ctx context.Context, class string, studentID uuid.UUID) ([]*domain.LessonTask, error)
+	AuthorizeCalendarLink(ctx context.Context, class string, studentID *uuid.UUID) error
+	ShareTask(ctx context.Context, share *domain.TaskShare) error
+	UnshareTask(ctx context.Context, taskID uuid.UUID, userID *uuid.UUID) error
+	GetTaskShares(ctx context.Context, taskID uuid.UUID) ([]domain.TaskShare, error)
+	CopyTask(ctx context.Context, id uuid.UUID) (*domain.Task, error)
+	CreateClass(ctx context.Context, class *domain.Class) (uuid.UUID, error)
+	GetClasses(ctx context.Context) ([]domain.Class, error)
+	CreateLesson(ctx context.Context, lesson *domain.Lesson) (uuid.UUID, error)
+	GetLessons(ctx context.Context, class string) ([]domain.Lesson, error)
 }
 
 type Handler struct {
-	taskService TaskService
-	logger      *slog.Logger
+	taskService    TaskService
+	logger         *slog.Logger
+	health         *health.Checker
+	requireIfMatch bool
+	calendarSecret []byte
 }
 
-func NewHandler(logger *slog.Logger, taskService TaskService) *Handler {
+func NewHandler(logger *slog.Logger, taskService TaskService, checker *health.Checker, requireIfMatch bool, calendarSecret string) *Handler {
 	return &Handler{
-		logger:      logger,
-		taskService: taskService,
+		logger:         logger,
+		taskService:    taskService,
+		health:         checker,
+		requireIfMatch: requireIfMatch,
+		calendarSecret: []byte(calendarSecret),
 	}
 }
 
-// CreateTask godoc
-// @Summary Создание шаблона задачи(без назначения на классы и уроки)
-// @Description Создает шаблон/задачу(без назначения на классы и уроки), но этот шаблон может использоваться для создания назначения
-// @tags tasks
-// @Accept json
-// @Param tasks body request.Task true "Данные задачи"
-// @Produce json
-// @Success 201 {object} response.TaskID
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task [post].
+// log returns the logger of the request, which carries its request ID, route, user and tenant.
+func (h *Handler) log(c *gin.Context) *slog.Logger {
+	return logging.FromContext(c.Request.Context(), h.logger)
+}
+
+// CreateTask creates a task template that isn't assigned to any class yet.
 func (h *Handler) CreateTask(c *gin.Context) {
 	ctx := c.Request.Context()
 	var input request.Task
 
-	if err := c.BindJSON(&input); err != nil {
-		h.logger.Error("failed to bind body", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
-	task, err := h.taskService.CreateTask(ctx, input.ToDomain())
+	domainTask := input.ToDomain()
+	task, err := h.taskService.CreateTask(ctx, domainTask)
 	if err != nil {
-		h.logger.Error("failed to create task", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		h.log(c).Error("failed to create task", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
+	setETag(c, domainTask.Version)
 	c.JSON(http.StatusCreated, response.NewTaskIDResponse(task))
 }
 
-// GetTask godoc
-// @Summary Поучить задачу
-// @Description Получить задачу
-// @tags tasks
-// @Accept json
-// @Param id path string true "ID задачи"
-// @Produce json
-// @Success 200 {object} response.Task
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/{id} [get].
+// GetTask returns the task template, or 304 without a body when If-None-Match holds its current version.
+// Both api/v1 and api/v2 route here.
 func (h *Handler) GetTask(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
 	taskID, err := uuid.Parse(id)
 	if err != nil {
-		h.logger.Error("failed to parse id", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	task, err := h.taskService.GetTask(ctx, taskID)
 	if err != nil {
-		h.logger.Error("failed to create task", slog.String("error", err.Error()))
-		if errors.Is(err, domain.ErrTaskNotFound) {
-			c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to create task", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	if notModified(c, task.Version) {
 		return
 	}
 
+	setETag(c, task.Version)
 	c.JSON(http.StatusOK, response.NewTaskResponse(task))
 }
 
-// GetTask godoc
-// @Summary Поучить все шаблоны задач
-// @Description Получить все шаблоны задач
-// @tags tasks
-// @Accept json
-// @Produce json
-// @Success 200 {object} response.Task
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/all [get].
+// GetTasks returns the templates the caller sees: their own, those that predate authorship and those shared with them or the school.
+// Both api/v1 and api/v2 route here.
 func (h *Handler) GetTasks(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	task, err := h.taskService.GetTasks(ctx)
 	if err != nil {
-		h.logger.Error("failed to get task", slog.String("error", err.Error()))
-		if errors.Is(err, domain.ErrTaskNotFound) {
-			c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to get task", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, response.NewTasksResponse(task))
 }
 
-// UpdateTask godoc
-// @Summary Обновить шаблон задачи
-// @Description Обновить шаблон задачи
-// @tags tasks
-// @Accept json
-// @Param id path string true "ID задачи"
-// @Param tasks body request.Task true "Данные задачи"
-// @Produce json
-// @Success 200 {object} response.TaskID
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/{id}/update [put].
+// UpdateTask replaces the task template. With If-Match it only succeeds while the version is unchanged.
+// Both api/v1 and api/v2 route here.
 func (h *Handler) UpdateTask(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
 	taskID, err := uuid.Parse(id)
 	if err != nil {
-		h.logger.Error("failed to parse id", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
-	var input request.Task
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var input request.TaskUpdate
 
-	if err := c.BindJSON(&input); err != nil {
-		h.logger.Error("failed to bind body", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
-	task, err := h.taskService.UpdateTask(ctx, input.ToDomainWithID(taskID))
+	domainTask := input.ToDomain(taskID)
+	domainTask.Version = version
+
+	task, err := h.taskService.UpdateTask(ctx, domainTask)
 	if err != nil {
-		h.logger.Error("failed to update task", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to update task", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
+	setETag(c, domainTask.Version)
 	c.JSON(http.StatusOK, response.NewTaskIDResponse(task))
 }
 
-// DeleteTask godoc
-// @Summary Удалить шаблон задачи
-// @Description Удалить шаблон задачи(удалятся все назначения, которые были созданы по задаче)
-// @tags tasks
-// @Accept json
-// @Param id path string true "ID задачи"
-// @Produce json
-// @Success 200 {object} response.TaskID
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/{id}/delete [delete].
+// DeleteTask moves the template to the trash together with its assignments. Only the author may delete it.
 func (h *Handler) DeleteTask(c *gin.Context) {
 	ctx := c.Request.Context()
 	id := c.Param("id")
 	taskID, err := uuid.Parse(id)
 	if err != nil {
-		h.logger.Error("failed to parse id", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
-	err = h.taskService.DeleteTask(ctx, taskID)
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	err = h.taskService.DeleteTask(ctx, taskID, version)
 	if err != nil {
-		h.logger.Error("failed to create task", slog.String("error", err.Error()))
-		if errors.Is(err, domain.ErrTaskNotFound) {
-			c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to create task", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, response.NewTaskIDResponse(taskID))
 }
 
-// GetTasksByClass godoc
-// @Summary Поучить задачи класса
-// @Description Поучить задачи класса
-// @tags tasks
-// @Accept json
-// @Param class query string true "название класса"
-// @Produce json
-// @Success 200 {object} response.ClassTasks
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/get-by-class [get].
+// GetTaskByClass returns the published assignments of the class, drafts are left out.
+// Both api/v1 and api/v2 route here.
 func (h *Handler) GetTaskByClass(c *gin.Context) {
 	ctx := c.Request.Context()
 	var class request.Class
 
-	if err := c.BindQuery(&class); err != nil {
-		h.logger.Error("failed to bind query class", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+	if err := c.ShouldBindQuery(&class); err != nil {
+		h.log(c).Error("failed to bind query class", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	tasks, err := h.taskService.GetTaskByClass(ctx, class.Class)
 	if err != nil {
-		h.logger.Error("failed to get tasks by class", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to get tasks by class", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusOK, response.NewClassTasksResponse(class.Class, tasks))
 }
 
-// DeleteAssignment godoc
-// @Summary Удалить задачу с класса и урока
-// @Description Удалить задачу с класса и урока
-// @tags tasks
-// @Accept json
-// @Param class_task_id query string true "id назначения задачи классу"
-// @Produce json
-// @Success 200 {string} string "OK"
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/assignment-delete [delete].
+// DeleteAssignment moves the assignment to the trash.
 func (h *Handler) DeleteAssignment(c *gin.Context) {
 	ctx := c.Request.Context()
 
 	var input request.TaskAsignmentID
 
-	if err := c.BindQuery(&input); err != nil {
-		h.logger.Error("failed to bind query class_task_id", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+	if err := c.ShouldBindQuery(&input); err != nil {
+		h.log(c).Error("failed to bind query class_task_id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	assignment, err := input.ToUUID()
 	if err != nil {
-		h.logger.Error("failed covert to uuid", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		h.log(c).Error("failed covert to uuid", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
 		return
 	}
 
-	err = h.taskService.DeleteAssignment(ctx, assignment)
+	err = h.taskService.DeleteAssignment(ctx, assignment, version)
 	if err != nil {
-		h.logger.Error("failed to delete assignment", slog.String("error", err.Error()))
-		if errors.Is(err, domain.ErrTaskNotFound) {
-			c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
-			return
-		}
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to delete assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
 	c.String(http.StatusOK, "OK")
 }
 
-// AssignTaskToClasses godoc
-// @Summary Назначить задачу классу и уроку
-// @Description Назначить задачу классу и уроку
-// @tags tasks
-// @Accept json
-// @Param task-assign body request.TaskAsignments true "Данные для назначения"
-// @Produce json
-// @Success 200 {object} response.TaskAssignments
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/assignment [post].
+// AssignTaskToClasses assigns the task to lessons of classes. With draft or a future publish_at the assignments
+// are created as drafts and published later.
 func (h *Handler) AssignTaskToClasses(c *gin.Context) {
 	ctx := c.Request.Context()
 	var input request.TaskAsignments
 
-	if err := c.BindJSON(&input); err != nil {
-		h.logger.Error("failed to bind body", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	domainAssignments, err := input.ToDomain()
 	if err != nil {
-		h.logger.Error("failed assert to domain", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	assignments, err := h.taskService.CreateAssignments(ctx, domainAssignments)
 	if err != nil {
-		h.logger.Error("failed to create assignment", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to create assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, response.NewAssignmentsResponse(input.TaskID, assignments))
 }
 
-// CreateTaskWithAssignment godoc
-// @Summary Создать задачу для класса
-// @Description Создать задачу для класса
-// @tags tasks
-// @Accept json
-// @Param task-assign body request.TaskWithAsignment true "Данные для создания задачи с назначением классу и уроку"
-// @Produce json
-// @Success 200 {object} response.AssignmentID
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/create-with-assignment [post].
+// CreateTaskWithAssignment creates a template and assigns it to a lesson of a class in one go.
 func (h *Handler) CreateTaskWithAssignment(c *gin.Context) {
 	ctx := c.Request.Context()
 	var input request.TaskWithAsignment
 
-	if err := c.BindJSON(&input); err != nil {
-		h.logger.Error("failed to bind body", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	domainAssignments, err := input.ToDomain()
 	if err != nil {
-		h.logger.Error("failed assert to domain", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	assignment, err := h.taskService.CreateTaskWithAssignments(ctx, domainAssignments)
 	if err != nil {
-		h.logger.Error("failed to create assignment", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to create assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
 	c.JSON(http.StatusCreated, response.NewAssignmentIDResponse(assignment.String()))
 }
 
-// UpdateTaskAssignment godoc
-// @Summary Обновить задачу для класса
-// @Description Обновить задачу для класса
-// @tags tasks
-// @Accept json
-// @Param task-assign body request.TaskAsignment true "Данные для назначения"
-// @Produce json
-// @Success 200 {string} string "OK"
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/assignment-update [put].
+// UpdateTaskAssignment replaces the assignment. With If-Match it only succeeds while the version is unchanged.
 func (h *Handler) UpdateTaskAssignment(c *gin.Context) {
 	ctx := c.Request.Context()
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
 	var input request.TaskAsignment
 
-	if err := c.BindJSON(&input); err != nil {
-		h.logger.Error("failed to bind body", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	domainAssignment, err := input.ToDomain()
 	if err != nil {
-		h.logger.Error("failed assert to domain", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
+	domainAssignment.Version = version
+
 	err = h.taskService.UpdateAssignment(ctx, domainAssignment)
 	if err != nil {
-		h.logger.Error("failed to create assignment", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to create assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
+	setETag(c, domainAssignment.Version)
 	c.String(http.StatusOK, "OK")
 }
 
-// TaskResults godoc
-// @Summary Поставить результаты за задачу ученикам
-// @Description Поставить результаты за задачу ученикам
-// @tags tasks
-// @Accept json
-// @Param task-results body request.TaskResult true "Оценки пользователей за задачу"
-// @Produce json
-// @Success 200 {string} string "OK"
-// @Failure 400 {object} common.ErrorResponse
-// @Failure 500 {object} common.ErrorResponse
-// @Router /api/v1/task/result [post].
+// TaskResult sets the marks of students for a task, applying the late policy of the assignment.
 func (h *Handler) TaskResult(c *gin.Context) {
 	ctx := c.Request.Context()
 	var input request.TaskResult
-	if err := c.BindJSON(&input); err != nil {
-		h.logger.Error("failed to bind body", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	taskResults, err := input.ToDomain()
 	if err != nil {
-		h.logger.Error("failed assert to domain", slog.String("error", err.Error()))
-		c.JSON(http.StatusBadRequest, common.NewErrorResponse(err.Error(), http.StatusBadRequest))
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
 		return
 	}
 
 	err = h.taskService.SetTaskResultsByUsers(ctx, taskResults)
 	if err != nil {
-		h.logger.Error("failed to set result", slog.String("error", err.Error()))
-		c.JSON(http.StatusInternalServerError, common.NewErrorResponse(err.Error(), http.StatusInternalServerError))
+		h.log(c).Error("failed to set result", slog.String("error", err.Error()))
+		h.fail(c, err)
 		return
 	}
 
@@ -0,0 +1,109 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/domain"
+	"task/internal/ports/httpServer/request"
+	"task/internal/ports/httpServer/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BulkCreateTasks creates up to 1000 templates: all or none in atomic mode, every valid one in best_effort mode.
+func (h *Handler) BulkCreateTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.BulkTasks
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	result, err := h.taskService.CreateTasksBulk(ctx, input.BulkMode.ToDomain(), input.ToDomain())
+	h.bulkResponse(c, result, err)
+}
+
+// BulkAssignTasks assigns up to 1000 task, class and lesson triples: all or none in atomic mode,
+// every possible one in best_effort mode.
+func (h *Handler) BulkAssignTasks(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.BulkAssignments
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	bulk, err := input.ToDomain()
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	result, err := h.taskService.AssignTasksBulk(ctx, bulk)
+	h.bulkResponse(c, result, err)
+}
+
+// BulkDeleteAssignments moves up to 1000 assignments to the trash.
+func (h *Handler) BulkDeleteAssignments(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.BulkAssignmentIDs
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	ids, err := input.ToUUIDs()
+	if err != nil {
+		h.log(c).Error("failed covert to uuid", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	result, err := h.taskService.DeleteAssignmentsBulk(ctx, input.BulkMode.ToDomain(), ids)
+	h.bulkResponse(c, result, err)
+}
+
+// BulkUpdateDeadlines changes the deadlines of up to 1000 assignments, null removes a deadline.
+func (h *Handler) BulkUpdateDeadlines(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.BulkDeadlines
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	updates, err := input.ToDomainUpdates()
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	result, err := h.taskService.UpdateDeadlinesBulk(ctx, input.BulkMode.ToDomain(), updates)
+	h.bulkResponse(c, result, err)
+}
+
+// bulkResponse answers 422 when an atomic request was rolled back and 200 otherwise, with the per-item results in both cases.
+func (h *Handler) bulkResponse(c *gin.Context, result *domain.BulkResult, err error) {
+	if err != nil {
+		h.log(c).Error("failed bulk request", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	if result.Mode == domain.BulkModeAtomic && result.Failed() {
+		c.JSON(http.StatusUnprocessableEntity, response.NewBulkResultResponse(result))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewBulkResultResponse(result))
+}
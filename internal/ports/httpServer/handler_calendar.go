@@ -0,0 +1,169 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+	"task/internal/domain"
+	"task/internal/ports/httpServer/response"
+	"task/pkg/ical"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const calendarProdID = "-//task//Tasks API//RU"
+
+// ClassCalendar serves the deadlines of the class's published assignments as an iCalendar feed.
+// The link with its token comes from ClassCalendarLink.
+func (h *Handler) ClassCalendar(c *gin.Context) {
+	ctx := c.Request.Context()
+	class := c.Param("class")
+	if !h.checkCalendarToken(c, classCalendarPath(class)) {
+		return
+	}
+
+	tasks, err := h.taskService.GetTaskByClass(ctx, class)
+	if err != nil {
+		h.log(c).Error("failed to get tasks by class", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	h.writeCalendar(c, class, tasks)
+}
+
+// StudentCalendar serves the class's deadlines as the student has them, extensions included, as an iCalendar feed.
+func (h *Handler) StudentCalendar(c *gin.Context) {
+	ctx := c.Request.Context()
+	class := c.Param("class")
+	studentID, ok := h.studentID(c)
+	if !ok || !h.checkCalendarToken(c, studentCalendarPath(class, studentID)) {
+		return
+	}
+
+	tasks, err := h.taskService.GetStudentTasks(ctx, class, studentID)
+	if err != nil {
+		h.log(c).Error("failed to get student tasks", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	h.writeCalendar(c, class, tasks)
+}
+
+// ClassCalendarLink hands a teacher of the class a feed link with its token.
+func (h *Handler) ClassCalendarLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	class := c.Param("class")
+	if !h.calendarEnabled(c) {
+		return
+	}
+
+	if err := h.taskService.AuthorizeCalendarLink(ctx, class, nil); err != nil {
+		h.log(c).Error("failed to authorize calendar link", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	link := h.calendarLink(domain.TenantFromContext(ctx), classCalendarPath(class))
+	c.JSON(http.StatusOK, response.NewCalendarLinkResponse(link))
+}
+
+// StudentCalendarLink hands the student, or a teacher of the class, a link to the student's feed with its token.
+func (h *Handler) StudentCalendarLink(c *gin.Context) {
+	ctx := c.Request.Context()
+	class := c.Param("class")
+	studentID, ok := h.studentID(c)
+	if !ok || !h.calendarEnabled(c) {
+		return
+	}
+
+	if err := h.taskService.AuthorizeCalendarLink(ctx, class, &studentID); err != nil {
+		h.log(c).Error("failed to authorize calendar link", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	link := h.calendarLink(domain.TenantFromContext(ctx), studentCalendarPath(class, studentID))
+	c.JSON(http.StatusOK, response.NewCalendarLinkResponse(link))
+}
+
+func (h *Handler) studentID(c *gin.Context) (uuid.UUID, bool) {
+	studentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return uuid.Nil, false
+	}
+
+	return studentID, true
+}
+
+func (h *Handler) calendarEnabled(c *gin.Context) bool {
+	if len(h.calendarSecret) == 0 {
+		h.log(c).Error("calendar feeds are disabled, calendar secret is not set")
+		h.problem(c, http.StatusNotFound, "calendar feeds are disabled")
+		return false
+	}
+
+	return true
+}
+
+func (h *Handler) checkCalendarToken(c *gin.Context, feedPath string) bool {
+	if !h.calendarEnabled(c) {
+		return false
+	}
+
+	if !h.validCalendarToken(domain.TenantFromContext(c.Request.Context()), feedPath, c.Query("token")) {
+		h.log(c).Error("invalid calendar token", slog.String("feed", feedPath))
+		h.problem(c, http.StatusForbidden, "invalid calendar token")
+		return false
+	}
+
+	return true
+}
+
+// writeCalendar renders tasks with a deadline as events. The UID is the assignment ID and the sequence is
+// the assignment version, so calendar apps update an event in place when the deadline moves.
+func (h *Handler) writeCalendar(c *gin.Context, class string, tasks []*domain.LessonTask) {
+	calendar := &ical.Calendar{
+		ProdID: calendarProdID,
+		Name:   "Дедлайны " + class,
+		Events: make([]ical.Event, 0, len(tasks)),
+	}
+	for _, task := range tasks {
+		if task.Deadline == nil {
+			continue
+		}
+
+		calendar.Events = append(calendar.Events, ical.Event{
+			UID:         task.TaskID.String() + "@task",
+			Sequence:    task.Version,
+			Start:       *task.Deadline,
+			Summary:     calendarSummary(task.Payload),
+			Description: task.Payload,
+		})
+	}
+
+	c.Header("Content-Type", ical.ContentType)
+	c.Header("Cache-Control", "private, max-age=300")
+	c.Status(http.StatusOK)
+	if err := ical.Write(c.Writer, calendar, time.Now()); err != nil {
+		h.log(c).Error("failed to write calendar", slog.String("error", err.Error()))
+	}
+}
+
+// calendarSummary is the first line of the payload, short enough for a calendar cell.
+func calendarSummary(payload string) string {
+	const maxRunes = 80
+
+	summary, _, _ := strings.Cut(payload, "\n")
+	runes := []rune(strings.TrimSpace(summary))
+	if len(runes) > maxRunes {
+		return string(runes[:maxRunes-1]) + "…"
+	}
+
+	return string(runes)
+}
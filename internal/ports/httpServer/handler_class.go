@@ -0,0 +1,91 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/domain"
+	"task/internal/ports/httpServer/request"
+	"task/internal/ports/httpServer/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetClasses returns the classes of the school that tasks can be assigned to.
+func (h *Handler) GetClasses(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	classes, err := h.taskService.GetClasses(ctx)
+	if err != nil {
+		h.log(c).Error("failed to get classes", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewClassesResponse(classes))
+}
+
+// CreateClass creates a class of the school. The name is normalized, so 5a and 5A are the same class.
+func (h *Handler) CreateClass(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.NewClass
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	class := input.ToDomain()
+
+	_, err := h.taskService.CreateClass(ctx, class)
+	if err != nil {
+		h.log(c).Error("failed to create class", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewClassResponse(class))
+}
+
+// GetLessons returns the lessons of the class that assignments can be tied to.
+func (h *Handler) GetLessons(c *gin.Context) {
+	ctx := c.Request.Context()
+	class := domain.NormalizeClassName(c.Param("class"))
+
+	lessons, err := h.taskService.GetLessons(ctx, class)
+	if err != nil {
+		h.log(c).Error("failed to get lessons", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewLessonsResponse(class, lessons))
+}
+
+// CreateLesson creates a lesson of the class, keeping the ID from the schedule when one is given.
+func (h *Handler) CreateLesson(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.Lesson
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	lesson, err := input.ToDomain(c.Param("class"))
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	_, err = h.taskService.CreateLesson(ctx, lesson)
+	if err != nil {
+		h.log(c).Error("failed to create lesson", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewLessonResponse(lesson))
+}
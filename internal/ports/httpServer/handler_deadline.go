@@ -0,0 +1,93 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/ports/httpServer/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SetAssignmentLatePolicy sets whether late marks of the assignment are accepted, penalized per day or rejected.
+func (h *Handler) SetAssignmentLatePolicy(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.AssignmentLatePolicy
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	policy, err := input.ToDomain()
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.SetAssignmentLatePolicy(ctx, policy)
+	if err != nil {
+		h.log(c).Error("failed to set late policy", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "OK")
+}
+
+// SetDeadlineExtension gives the student a deadline of their own on the assignment.
+func (h *Handler) SetDeadlineExtension(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.DeadlineExtension
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	extension, err := input.ToDomain()
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.SetDeadlineExtension(ctx, extension)
+	if err != nil {
+		h.log(c).Error("failed to set deadline extension", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "OK")
+}
+
+// DeleteDeadlineExtension takes the student's own deadline on the assignment back.
+func (h *Handler) DeleteDeadlineExtension(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.DeadlineExtensionID
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		h.log(c).Error("failed to bind query", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	assignmentID, userID, err := input.ToUUIDs()
+	if err != nil {
+		h.log(c).Error("failed covert to uuid", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.DeleteDeadlineExtension(ctx, assignmentID, userID)
+	if err != nil {
+		h.log(c).Error("failed to delete deadline extension", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "OK")
+}
@@ -0,0 +1,179 @@
+package httpserver
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"task/internal/domain"
+	"task/internal/ports/httpServer/response"
+	"task/pkg/spreadsheet"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+const ContentTypeNDJSON = "application/x-ndjson"
+
+var exportFormats = []string{spreadsheet.ContentTypeCSV, spreadsheet.ContentTypeXLSX, ContentTypeNDJSON}
+
+// exportTable describes how rows of an export look in a spreadsheet and in newline-delimited JSON.
+type exportTable[T any] struct {
+	name   string
+	sheet  string
+	header []any
+	cells  func(T) []any
+	object func(T) any
+}
+
+var taskExport = exportTable[*domain.Task]{
+	name:   "tasks",
+	sheet:  "tasks",
+	header: []any{"id", "payload", "deadline", "category", "version"},
+	cells: func(task *domain.Task) []any {
+		return []any{task.ID.String(), task.Payload, exportTime(task.Deadline), task.Category, task.Version}
+	},
+	object: func(task *domain.Task) any {
+		return response.NewTaskResponse(task)
+	},
+}
+
+// gradebookExport starts with the columns of the marks import, so an exported gradebook can be imported back.
+var gradebookExport = exportTable[*domain.GradebookEntry]{
+	sheet:  "marks",
+	header: []any{"student_id", "task_id", "lesson_id", "mark", "submitted_at", "class", "task_template_id", "payload", "deadline", "penalty"},
+	cells: func(entry *domain.GradebookEntry) []any {
+		var mark any
+		if entry.Mark != nil {
+			mark = *entry.Mark
+		}
+
+		return []any{
+			entry.StudentID.String(),
+			entry.TaskID.String(),
+			entry.LessonID.String(),
+			mark,
+			exportTime(entry.SubmittedAt),
+			entry.Class,
+			entry.TaskTemplateID.String(),
+			entry.Payload,
+			exportTime(entry.Deadline),
+			entry.Penalty,
+		}
+	},
+	object: func(entry *domain.GradebookEntry) any {
+		return response.NewGradebookEntryResponse(entry)
+	},
+}
+
+// ExportTasks streams the templates the caller sees as CSV, XLSX or NDJSON, picked by the Accept header.
+func (h *Handler) ExportTasks(c *gin.Context) {
+	writeExport(h, c, taskExport, h.taskService.ExportTasks)
+}
+
+// ExportGradebook streams the marks of the class as CSV, XLSX or NDJSON, picked by the Accept header.
+// The CSV and XLSX files can be imported back with ImportMarks.
+func (h *Handler) ExportGradebook(c *gin.Context) {
+	class := c.Param("class")
+	table := gradebookExport
+	table.name = "gradebook-" + class
+
+	writeExport(h, c, table, func(ctx context.Context, fn func(*domain.GradebookEntry) error) error {
+		return h.taskService.ExportGradebook(ctx, class, fn)
+	})
+}
+
+// ExportStudentMarks streams all marks of the student as CSV, XLSX or NDJSON, picked by the Accept header.
+func (h *Handler) ExportStudentMarks(c *gin.Context) {
+	id := c.Param("id")
+	studentID, err := uuid.Parse(id)
+	if err != nil {
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	table := gradebookExport
+	table.name = "marks-" + studentID.String()
+
+	writeExport(h, c, table, func(ctx context.Context, fn func(*domain.GradebookEntry) error) error {
+		return h.taskService.ExportStudentMarks(ctx, studentID, fn)
+	})
+}
+
+// writeExport streams the rows straight into the response in the format negotiated from the Accept header.
+// Once the first rows are sent a failure can't be reported with a status code,
+// so the connection is dropped and the client sees a truncated response instead of a complete one.
+func writeExport[T any](h *Handler, c *gin.Context, table exportTable[T], stream func(ctx context.Context, fn func(T) error) error) {
+	ctx := c.Request.Context()
+
+	format := c.NegotiateFormat(exportFormats...)
+	if format == "" {
+		h.log(c).Error("failed to negotiate export format", slog.String("accept", c.GetHeader("Accept")))
+		h.problem(c, http.StatusNotAcceptable, "supported formats are text/csv, xlsx and application/x-ndjson")
+		return
+	}
+
+	var write func(T) error
+	var finish func() error
+	var extension string
+	switch format {
+	case ContentTypeNDJSON:
+		encoder := json.NewEncoder(c.Writer)
+		write = func(row T) error {
+			return encoder.Encode(table.object(row))
+		}
+		finish = func() error { return nil }
+		extension = "ndjson"
+	default:
+		sheetFormat := spreadsheet.FormatCSV
+		if format == spreadsheet.ContentTypeXLSX {
+			sheetFormat = spreadsheet.FormatXLSX
+		}
+
+		writer, err := spreadsheet.NewWriter(c.Writer, sheetFormat, table.sheet)
+		if err == nil {
+			err = writer.Write(table.header)
+		}
+		if err != nil {
+			h.log(c).Error("failed to start export", slog.String("error", err.Error()))
+			h.fail(c, err)
+			return
+		}
+
+		write = func(row T) error {
+			return writer.Write(table.cells(row))
+		}
+		finish = writer.Close
+		extension = string(sheetFormat)
+	}
+
+	c.Header("Content-Type", format)
+	// Class names are usually not ASCII, so the file name is sent in the RFC 5987 encoding.
+	c.Header("Content-Disposition", "attachment; filename*=UTF-8''"+url.PathEscape(table.name+"."+extension))
+	c.Status(http.StatusOK)
+
+	err := stream(ctx, write)
+	if err == nil {
+		err = finish()
+	}
+	if err != nil {
+		h.log(c).Error("failed to export", slog.String("name", table.name), slog.String("error", err.Error()))
+		if !c.Writer.Written() {
+			c.Header("Content-Disposition", "")
+			h.fail(c, err)
+			return
+		}
+		panic(http.ErrAbortHandler)
+	}
+}
+
+func exportTime(t *time.Time) any {
+	if t == nil {
+		return nil
+	}
+
+	return t.Format(time.RFC3339)
+}
@@ -0,0 +1,29 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/ports/httpServer/response"
+	"task/pkg/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Liveness answers as long as the process serves requests. Dependencies aren't checked,
+// so an outage of one doesn't get the process restarted.
+func (h *Handler) Liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, response.Health{Status: health.StatusUp})
+}
+
+// Readiness checks Postgres, Redis and Kafka, each with its own timeout, and reports every one of them.
+// While the server drains it answers 503.
+func (h *Handler) Readiness(c *gin.Context) {
+	report := h.health.Ready(c.Request.Context())
+	if !report.Ready {
+		h.log(c).Warn("not ready", slog.String("status", report.Status))
+		c.JSON(http.StatusServiceUnavailable, response.NewHealthResponse(report))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewHealthResponse(report))
+}
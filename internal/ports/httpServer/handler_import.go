@@ -0,0 +1,79 @@
+package httpserver
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"task/internal/domain"
+	"task/internal/ports/httpServer/request"
+	"task/internal/ports/httpServer/response"
+	"task/pkg/spreadsheet"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportTasks creates templates from a CSV or XLSX file, none of them when a row is invalid or with dry_run.
+func (h *Handler) ImportTasks(c *gin.Context) {
+	h.importTable(c, h.taskService.ImportTasks)
+}
+
+// ImportMarks sets marks from a CSV or XLSX file, none of them when a row is invalid or with dry_run.
+func (h *Handler) ImportMarks(c *gin.Context) {
+	h.importTable(c, h.taskService.ImportMarks)
+}
+
+type importFunc func(ctx context.Context, table [][]string, dryRun bool) (*domain.ImportReport, error)
+
+func (h *Handler) importTable(c *gin.Context, importRows importFunc) {
+	ctx := c.Request.Context()
+	var input request.Import
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		h.log(c).Error("failed to bind query dry_run", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	file, err := c.FormFile("file")
+	if err != nil {
+		h.log(c).Error("failed to get file", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	format, err := spreadsheet.DetectFormat(file.Filename, file.Header.Get("Content-Type"))
+	if err != nil {
+		h.log(c).Error("failed to detect file format", slog.String("error", err.Error()))
+		h.problem(c, http.StatusUnsupportedMediaType, err.Error())
+		return
+	}
+
+	content, err := file.Open()
+	if err != nil {
+		h.log(c).Error("failed to open file", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+	defer content.Close()
+
+	table, err := spreadsheet.Read(content, format)
+	if err != nil {
+		h.log(c).Error("failed to read file", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	report, err := importRows(ctx, table, input.DryRun)
+	if err != nil {
+		h.log(c).Error("failed to import", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	if !report.DryRun && report.Imported == 0 && len(report.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, response.NewImportReportResponse(report))
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewImportReportResponse(report))
+}
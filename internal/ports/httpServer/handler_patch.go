@@ -0,0 +1,98 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/ports/httpServer/request"
+	"task/internal/ports/httpServer/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// PatchTask applies a JSON Merge Patch to the template: missing fields stay, a null deadline is removed.
+// Both api/v1 and api/v2 route here.
+func (h *Handler) PatchTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var input request.TaskPatch
+	if !h.bindMergePatch(c, &input) {
+		return
+	}
+
+	task, err := h.taskService.PatchTask(ctx, input.ToDomain(taskID, version))
+	if err != nil {
+		h.log(c).Error("failed to patch task", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	setETag(c, task.Version)
+	c.JSON(http.StatusOK, response.NewTaskResponse(task))
+}
+
+// PatchAssignment applies a JSON Merge Patch to the assignment: missing fields stay, a null deadline is removed.
+func (h *Handler) PatchAssignment(c *gin.Context) {
+	ctx := c.Request.Context()
+	var id request.TaskAsignmentID
+
+	if err := c.ShouldBindQuery(&id); err != nil {
+		h.log(c).Error("failed to bind query class_task_id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	assignmentID, err := id.ToUUID()
+	if err != nil {
+		h.log(c).Error("failed covert to uuid", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var input request.AssignmentPatch
+	if !h.bindMergePatch(c, &input) {
+		return
+	}
+
+	assignment, err := h.taskService.PatchAssignment(ctx, input.ToDomain(assignmentID, version))
+	if err != nil {
+		h.log(c).Error("failed to patch assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	setETag(c, assignment.Version)
+	c.JSON(http.StatusOK, response.NewAssignmentResponse(assignment))
+}
+
+func (h *Handler) bindMergePatch(c *gin.Context, v any) bool {
+	if contentType := c.ContentType(); contentType != request.MergePatchContentType && contentType != gin.MIMEJSON {
+		h.log(c).Error("unsupported patch content type", slog.String("content_type", contentType))
+		h.problem(c, http.StatusUnsupportedMediaType, "patch must be sent as "+request.MergePatchContentType)
+		return false
+	}
+
+	if err := request.DecodeMergePatch(c.Request.Body, v); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,37 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/ports/httpServer/request"
+
+	"github.com/gin-gonic/gin"
+)
+
+// PublishAssignment publishes a draft assignment right away instead of at publish_at.
+func (h *Handler) PublishAssignment(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.TaskAsignmentID
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		h.log(c).Error("failed to bind query class_task_id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	assignmentID, err := input.ToUUID()
+	if err != nil {
+		h.log(c).Error("failed covert to uuid", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.PublishAssignment(ctx, assignmentID)
+	if err != nil {
+		h.log(c).Error("failed to publish assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "OK")
+}
@@ -0,0 +1,95 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/ports/httpServer/request"
+	"task/internal/ports/httpServer/response"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateRecurrence assigns the template to the class by an RRULE. The assignments are created ahead of time
+// and each deadline counts from the start of its occurrence.
+func (h *Handler) CreateRecurrence(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.Recurrence
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	recurrence, err := input.ToDomain()
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	id, err := h.taskService.CreateRecurrence(ctx, recurrence)
+	if err != nil {
+		h.log(c).Error("failed to create recurrence", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, response.NewRecurrenceIDResponse(id))
+}
+
+// StopRecurrence stops the recurrence. Published assignments stay, unpublished ones are removed.
+func (h *Handler) StopRecurrence(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.RecurrenceID
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		h.log(c).Error("failed to bind query recurrence_id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	id, err := input.ToUUID()
+	if err != nil {
+		h.log(c).Error("failed covert to uuid", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.StopRecurrence(ctx, id)
+	if err != nil {
+		h.log(c).Error("failed to stop recurrence", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "OK")
+}
+
+// UpdateOccurrence skips a single occurrence or overrides its payload and deadline.
+func (h *Handler) UpdateOccurrence(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.OccurrenceException
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	exception, err := input.ToDomain()
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.SetOccurrenceException(ctx, exception)
+	if err != nil {
+		h.log(c).Error("failed to update occurrence", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "OK")
+}
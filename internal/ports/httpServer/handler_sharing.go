@@ -0,0 +1,125 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/ports/httpServer/request"
+	"task/internal/ports/httpServer/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetTaskShares returns whom the author shared the template with.
+func (h *Handler) GetTaskShares(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+
+	shares, err := h.taskService.GetTaskShares(ctx, taskID)
+	if err != nil {
+		h.log(c).Error("failed to get task shares", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewTaskSharesResponse(taskID, shares))
+}
+
+// ShareTask grants a colleague, or the whole school without user_id, a permission on the template.
+func (h *Handler) ShareTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+
+	var input request.TaskShare
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	share, err := input.ToDomain(taskID)
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.ShareTask(ctx, share)
+	if err != nil {
+		h.log(c).Error("failed to share task", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "OK")
+}
+
+// UnshareTask revokes the permission of a colleague, or of the whole school without user_id, on the template.
+func (h *Handler) UnshareTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+
+	var input request.TaskShareUser
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		h.log(c).Error("failed to bind query user_id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	userID, err := input.ToUUID()
+	if err != nil {
+		h.log(c).Error("failed covert to uuid", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.UnshareTask(ctx, taskID, userID)
+	if err != nil {
+		h.log(c).Error("failed to unshare task", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "OK")
+}
+
+// CopyTask copies a template the caller may use into their own bank.
+func (h *Handler) CopyTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID, ok := h.taskID(c)
+	if !ok {
+		return
+	}
+
+	task, err := h.taskService.CopyTask(ctx, taskID)
+	if err != nil {
+		h.log(c).Error("failed to copy task", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	setETag(c, task.Version)
+	c.JSON(http.StatusCreated, response.NewTaskResponse(task))
+}
+
+func (h *Handler) taskID(c *gin.Context) (uuid.UUID, bool) {
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return uuid.Nil, false
+	}
+
+	return taskID, true
+}
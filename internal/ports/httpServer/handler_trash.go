@@ -0,0 +1,74 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/ports/httpServer/request"
+	"task/internal/ports/httpServer/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// GetTrash returns the deleted templates and assignments the caller may still restore.
+func (h *Handler) GetTrash(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	trash, err := h.taskService.GetTrash(ctx)
+	if err != nil {
+		h.log(c).Error("failed to get trash", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewTrashResponse(trash))
+}
+
+// RestoreTask restores the template and the assignments deleted together with it.
+func (h *Handler) RestoreTask(c *gin.Context) {
+	ctx := c.Request.Context()
+	id := c.Param("id")
+	taskID, err := uuid.Parse(id)
+	if err != nil {
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.RestoreTask(ctx, taskID)
+	if err != nil {
+		h.log(c).Error("failed to restore task", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewTaskIDResponse(taskID))
+}
+
+// RestoreAssignment restores the assignment, its template must not be in the trash.
+func (h *Handler) RestoreAssignment(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.TaskAsignmentID
+
+	if err := c.ShouldBindQuery(&input); err != nil {
+		h.log(c).Error("failed to bind query class_task_id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	assignmentID, err := input.ToUUID()
+	if err != nil {
+		h.log(c).Error("failed covert to uuid", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.RestoreAssignment(ctx, assignmentID)
+	if err != nil {
+		h.log(c).Error("failed to restore assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.String(http.StatusOK, "OK")
+}
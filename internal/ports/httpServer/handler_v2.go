@@ -0,0 +1,290 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/domain"
+	"task/internal/ports/httpServer/request"
+	"task/internal/ports/httpServer/response"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+)
+
+// The v2 handlers of the routes whose v1 handlers can't be reused as they are:
+// v2 takes IDs from the path only, answers creations with 201 and a Location
+// and operations without a result with 204.
+
+// CreateTaskV2 creates a task template and points Location at it.
+func (h *Handler) CreateTaskV2(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.Task
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	domainTask := input.ToDomain()
+	_, err := h.taskService.CreateTask(ctx, domainTask)
+	if err != nil {
+		h.log(c).Error("failed to create task", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	setETag(c, domainTask.Version)
+	setLocation(c, taskLocation(domainTask.ID))
+	c.JSON(http.StatusCreated, response.NewTaskResponse(domainTask))
+}
+
+// DeleteTaskV2 moves the template to the trash together with its assignments. Only the author may delete it.
+func (h *Handler) DeleteTaskV2(c *gin.Context) {
+	ctx := c.Request.Context()
+	taskID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	err = h.taskService.DeleteTask(ctx, taskID, version)
+	if err != nil {
+		h.log(c).Error("failed to delete task", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// CreateAssignmentV2 assigns the template to a lesson of a class and points Location at the assignment.
+func (h *Handler) CreateAssignmentV2(c *gin.Context) {
+	ctx := c.Request.Context()
+	var input request.Assignment
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	domainAssignments, err := input.ToDomain()
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	assignments, err := h.taskService.CreateAssignments(ctx, domainAssignments)
+	if err != nil {
+		h.log(c).Error("failed to create assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	if len(assignments) == 0 {
+		h.fail(c, domain.ErrAssignmentExists)
+		return
+	}
+
+	assignment := response.NewAssignmentsResponse(input.TaskID, assignments).ToAssign[0]
+	setLocation(c, assignmentLocation(assignments[0].AssignmentID))
+	c.JSON(http.StatusCreated, assignment)
+}
+
+// GetAssignmentV2 returns the assignment, or 304 without a body when If-None-Match holds its current version.
+func (h *Handler) GetAssignmentV2(c *gin.Context) {
+	ctx := c.Request.Context()
+	assignmentID, ok := h.assignmentID(c)
+	if !ok {
+		return
+	}
+
+	assignment, err := h.taskService.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		h.log(c).Error("failed to get assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	if notModified(c, assignment.Version) {
+		return
+	}
+
+	setETag(c, assignment.Version)
+	c.JSON(http.StatusOK, response.NewAssignmentResponse(assignment))
+}
+
+// UpdateAssignmentV2 replaces the assignment. With If-Match it only succeeds while the version is unchanged.
+func (h *Handler) UpdateAssignmentV2(c *gin.Context) {
+	ctx := c.Request.Context()
+	assignmentID, ok := h.assignmentID(c)
+	if !ok {
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var input request.AssignmentUpdate
+
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	domainAssignment := input.ToDomain(assignmentID)
+	domainAssignment.Version = version
+
+	err := h.taskService.UpdateAssignment(ctx, domainAssignment)
+	if err != nil {
+		h.log(c).Error("failed to update assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	setETag(c, domainAssignment.Version)
+	c.Status(http.StatusNoContent)
+}
+
+// PatchAssignmentV2 applies a JSON Merge Patch to the assignment: missing fields stay, a null deadline is removed.
+func (h *Handler) PatchAssignmentV2(c *gin.Context) {
+	ctx := c.Request.Context()
+	assignmentID, ok := h.assignmentID(c)
+	if !ok {
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	var input request.AssignmentPatch
+	if !h.bindMergePatch(c, &input) {
+		return
+	}
+
+	assignment, err := h.taskService.PatchAssignment(ctx, input.ToDomain(assignmentID, version))
+	if err != nil {
+		h.log(c).Error("failed to patch assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	setETag(c, assignment.Version)
+	c.JSON(http.StatusOK, response.NewAssignmentResponse(assignment))
+}
+
+// DeleteAssignmentV2 moves the assignment to the trash.
+func (h *Handler) DeleteAssignmentV2(c *gin.Context) {
+	ctx := c.Request.Context()
+	assignmentID, ok := h.assignmentID(c)
+	if !ok {
+		return
+	}
+
+	version, ok := h.ifMatchVersion(c)
+	if !ok {
+		return
+	}
+
+	err := h.taskService.DeleteAssignment(ctx, assignmentID, version)
+	if err != nil {
+		h.log(c).Error("failed to delete assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetAssignmentResults returns the marks of the students for the assignment.
+func (h *Handler) GetAssignmentResults(c *gin.Context) {
+	ctx := c.Request.Context()
+	assignmentID, ok := h.assignmentID(c)
+	if !ok {
+		return
+	}
+
+	marks, err := h.taskService.GetMarksByAssignments(ctx, []uuid.UUID{assignmentID})
+	if err != nil {
+		h.log(c).Error("failed to get results", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, response.NewAssignmentResultsResponse(assignmentID.String(), marks[assignmentID]))
+}
+
+// SetAssignmentResults sets the marks of the students, a repeated mark of a student replaces the former one.
+func (h *Handler) SetAssignmentResults(c *gin.Context) {
+	ctx := c.Request.Context()
+	assignmentID, ok := h.assignmentID(c)
+	if !ok {
+		return
+	}
+
+	var input request.AssignmentResults
+	if err := c.ShouldBindJSON(&input); err != nil {
+		h.log(c).Error("failed to bind body", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	assignment, err := h.taskService.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		h.log(c).Error("failed to get assignment", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	taskResults, err := input.ToDomain(assignment)
+	if err != nil {
+		h.log(c).Error("failed assert to domain", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return
+	}
+
+	err = h.taskService.SetTaskResultsByUsers(ctx, taskResults)
+	if err != nil {
+		h.log(c).Error("failed to set result", slog.String("error", err.Error()))
+		h.fail(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+func (h *Handler) assignmentID(c *gin.Context) (uuid.UUID, bool) {
+	assignmentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		h.log(c).Error("failed to parse id", slog.String("error", err.Error()))
+		h.badRequest(c, err)
+		return uuid.Nil, false
+	}
+
+	return assignmentID, true
+}
+
+func setLocation(c *gin.Context, location string) {
+	c.Header("Location", location)
+}
+
+func taskLocation(id uuid.UUID) string {
+	return "/" + apiV2 + "/tasks/" + id.String()
+}
+
+func assignmentLocation(id uuid.UUID) string {
+	return "/" + apiV2 + "/assignments/" + id.String()
+}
@@ -0,0 +1,231 @@
+package httpserver
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+	"task/internal/domain"
+
+	"task/pkg/logging"
+	"task/pkg/problem"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	requestIDHeader = "X-Request-ID"
+	// maxRequestIDLength bounds the request IDs taken from clients, they end up in every log line.
+	maxRequestIDLength = 128
+
+	userIDHeader = "X-User-ID"
+	tenantHeader = "X-Tenant-ID"
+	// gatewaySecretHeader proves that the request came through the gateway, which sets the two headers above.
+	gatewaySecretHeader = "X-Gateway-Secret"
+	// tenantQuery carries the school of calendar feeds, calendar apps can't send headers.
+	tenantQuery = "tenant"
+)
+
+// v1DeprecatedAt is when the api/v1 routes replaced by api/v2 were deprecated.
+var v1DeprecatedAt = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// Deprecated marks a route replaced by successor: the Deprecation header (RFC 9745) tells when
+// it was deprecated and the Link header points at the route to move to.
+func Deprecated(successor string) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", v1DeprecatedAt.Unix())
+	link := fmt.Sprintf(`<%s>; rel="successor-version"`, successor)
+
+	return func(c *gin.Context) {
+		c.Header("Deprecation", deprecation)
+		c.Header("Link", link)
+		c.Next()
+	}
+}
+
+// TenantPolicy tells where the school of a request may come from.
+type TenantPolicy struct {
+	// Require rejects requests without a school with 400. Otherwise they belong to the default school.
+	Require bool
+	// GatewaySecret is shared with the gateway that authenticates the users and sets X-Tenant-ID and X-User-ID.
+	// Requests without the same secret in X-Gateway-Secret are rejected with 401, so clients that bypass
+	// the gateway can't pick the school or the user themselves. While it is empty every such request is rejected.
+	GatewaySecret string
+}
+
+// TenantContext puts the school the request works in, from the X-Tenant-ID header, into the request context.
+func TenantContext(logger *slog.Logger, policy TenantPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !policy.fromGateway(c) {
+			logging.FromContext(c.Request.Context(), logger).Error("request didn't come through the gateway")
+			problem.Abort(c, problem.New(http.StatusUnauthorized, "missing or invalid "+gatewaySecretHeader+" header"))
+			return
+		}
+
+		setTenant(c, logger, policy.Require, c.GetHeader(tenantHeader), tenantHeader+" header")
+	}
+}
+
+// CalendarTenantContext is TenantContext of the calendar feeds, which calendar apps fetch without the gateway.
+// The school comes from the tenant query parameter of the link; the feed token is only valid together with it.
+func CalendarTenantContext(logger *slog.Logger, policy TenantPolicy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		setTenant(c, logger, policy.Require, c.Query(tenantQuery), tenantQuery+" query parameter")
+	}
+}
+
+func (p TenantPolicy) fromGateway(c *gin.Context) bool {
+	if p.GatewaySecret == "" {
+		return false
+	}
+
+	return subtle.ConstantTimeCompare([]byte(c.GetHeader(gatewaySecretHeader)), []byte(p.GatewaySecret)) == 1
+}
+
+func setTenant(c *gin.Context, logger *slog.Logger, require bool, value, source string) {
+	if value == "" {
+		if require {
+			problem.Abort(c, problem.New(http.StatusBadRequest, "missing "+source))
+			return
+		}
+		c.Next()
+		return
+	}
+
+	tenantID, err := uuid.Parse(value)
+	if err != nil {
+		logging.FromContext(c.Request.Context(), logger).Error("failed to parse tenant id", slog.String("error", err.Error()))
+		problem.Abort(c, problem.New(http.StatusBadRequest, "invalid "+source))
+		return
+	}
+
+	ctx := logging.With(c.Request.Context(), logger, slog.String("tenant_id", tenantID.String()))
+	c.Request = c.Request.WithContext(domain.ContextWithTenant(ctx, tenantID))
+	c.Next()
+}
+
+// userRateLimitKey gives every user of a school a budget of their own. Requests without a user share one per school.
+func userRateLimitKey(c *gin.Context) string {
+	ctx := c.Request.Context()
+	key := "task:" + domain.TenantFromContext(ctx).String()
+	if userID, ok := domain.UserIDFromContext(ctx); ok {
+		return key + ":" + userID.String()
+	}
+
+	return key
+}
+
+// feedRateLimitKey gives every calendar feed a budget of its own, so one calendar app polling too often
+// doesn't cut off the other feeds of the school.
+func feedRateLimitKey(c *gin.Context) string {
+	return "task:" + domain.TenantFromContext(c.Request.Context()).String() + ":" + c.Request.URL.Path
+}
+
+// UserContext puts the ID of the calling user from the X-User-ID header into the request context.
+func UserContext(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		header := c.GetHeader(userIDHeader)
+		if header == "" {
+			c.Next()
+			return
+		}
+
+		userID, err := uuid.Parse(header)
+		if err != nil {
+			logging.FromContext(c.Request.Context(), logger).Error("failed to parse user id", slog.String("error", err.Error()))
+			problem.Abort(c, problem.New(http.StatusBadRequest, "invalid "+userIDHeader+" header"))
+			return
+		}
+
+		ctx := logging.With(c.Request.Context(), logger, slog.String("user_id", userID.String()))
+		c.Request = c.Request.WithContext(domain.ContextWithUserID(ctx, userID))
+		c.Next()
+	}
+}
+
+// RequestID takes the request ID from the X-Request-ID header or generates one, echoes it in the response
+// and puts a logger carrying it, the route and the trace into the request context.
+func RequestID(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := c.GetHeader(requestIDHeader)
+		if requestID == "" || len(requestID) > maxRequestIDLength {
+			requestID = uuid.NewString()
+		}
+		c.Header(requestIDHeader, requestID)
+
+		args := []any{
+			slog.String("request_id", requestID),
+			slog.String("method", c.Request.Method),
+			slog.String("route", c.FullPath()),
+		}
+		if span := trace.SpanContextFromContext(c.Request.Context()); span.IsValid() {
+			args = append(args, slog.String("trace_id", span.TraceID().String()))
+		}
+
+		c.Request = c.Request.WithContext(logging.With(c.Request.Context(), logger, args...))
+		c.Next()
+	}
+}
+
+// AccessLog logs every request once it is served, with the logger of the request.
+func AccessLog(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		status := c.Writer.Status()
+		if isProbe(c.Request) && status < http.StatusBadRequest {
+			return
+		}
+
+		level := slog.LevelInfo
+		switch {
+		case status >= http.StatusInternalServerError:
+			level = slog.LevelError
+		case status >= http.StatusBadRequest:
+			level = slog.LevelWarn
+		}
+
+		logging.FromContext(c.Request.Context(), logger).LogAttrs(c.Request.Context(), level, "request",
+			slog.String("path", c.Request.URL.Path),
+			slog.Int("status", status),
+			slog.Duration("duration", time.Since(start)),
+			slog.Int("size", c.Writer.Size()),
+			slog.String("client_ip", c.ClientIP()),
+		)
+	}
+}
+
+// Recovery turns a panic in a handler into a 500 response and logs it with the stack,
+// instead of dropping the connection.
+func Recovery(logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+			if recovered == http.ErrAbortHandler {
+				// The handler aborted the response on purpose, let net/http drop the connection.
+				panic(recovered)
+			}
+
+			logging.FromContext(c.Request.Context(), logger).Error("panic in handler",
+				slog.String("error", fmt.Sprint(recovered)),
+				slog.String("stack", string(debug.Stack())),
+			)
+
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+			problem.Abort(c, problem.New(http.StatusInternalServerError, "internal server error"))
+		}()
+
+		c.Next()
+	}
+}
@@ -0,0 +1,38 @@
+package httpserver
+
+import (
+	"net/http"
+	"task/api/openapi"
+
+	swaggerFiles "github.com/swaggo/files/v2"
+
+	"github.com/gin-gonic/gin"
+)
+
+// swaggerInitializer replaces the one Swagger UI ships with, which opens the petstore example.
+const swaggerInitializer = `window.onload = function() {
+  window.ui = SwaggerUIBundle({
+    url: "/openapi.yaml",
+    dom_id: "#swagger-ui",
+    deepLinking: true,
+    presets: [SwaggerUIBundle.presets.apis, SwaggerUIStandalonePreset],
+    plugins: [SwaggerUIBundle.plugins.DownloadUrl],
+    layout: "StandaloneLayout"
+  });
+};
+`
+
+// registerOpenAPI serves the spec at /openapi.yaml and Swagger UI for it at /swagger/.
+func registerOpenAPI(router *gin.Engine) {
+	router.GET("/openapi.yaml", func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/yaml", openapi.Spec)
+	})
+	router.GET("/swagger/*any", func(c *gin.Context) {
+		if c.Param("any") == "/swagger-initializer.js" {
+			c.Data(http.StatusOK, "text/javascript; charset=utf-8", []byte(swaggerInitializer))
+			return
+		}
+
+		c.FileFromFS(c.Param("any"), http.FS(swaggerFiles.FS))
+	})
+}
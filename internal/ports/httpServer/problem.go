@@ -0,0 +1,80 @@
+package httpserver
+
+import (
+	"log/slog"
+	"net/http"
+	"task/internal/domain"
+	"task/pkg/problem"
+	"task/pkg/validation"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+var kindStatus = map[domain.Kind]int{
+	domain.KindNotFound:     http.StatusNotFound,
+	domain.KindConflict:     http.StatusConflict,
+	domain.KindValidation:   http.StatusUnprocessableEntity,
+	domain.KindForbidden:    http.StatusForbidden,
+	domain.KindLocked:       http.StatusLocked,
+	domain.KindPrecondition: http.StatusPreconditionFailed,
+}
+
+// fail answers with the status of the domain error. Any other error is internal: its text may come
+// from the database, so the client gets a generic message and the details stay in the log.
+func (h *Handler) fail(c *gin.Context, err error) {
+	domainErr, ok := domain.AsError(err)
+	if !ok {
+		h.problem(c, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	status, ok := kindStatus[domainErr.Kind]
+	if !ok {
+		h.problem(c, http.StatusInternalServerError, "internal server error")
+		return
+	}
+
+	p := problem.New(status, domainErr.Message)
+	for _, field := range domainErr.Fields {
+		p.Errors = append(p.Errors, problem.FieldError{Field: field.Field, Message: field.Message})
+	}
+
+	problem.Abort(c, p)
+}
+
+// badRequest answers a request that couldn't be read: 422 with the invalid fields when it failed validation and 400 when it is malformed.
+func (h *Handler) badRequest(c *gin.Context, err error) {
+	if _, ok := domain.AsError(err); ok {
+		h.fail(c, err)
+		return
+	}
+
+	if fields, ok := validation.Fields(err); ok {
+		p := problem.New(http.StatusUnprocessableEntity, "request is not valid")
+		for _, field := range fields {
+			p.Errors = append(p.Errors, problem.FieldError{Field: field.Field, Message: field.Message})
+		}
+		problem.Abort(c, p)
+		return
+	}
+
+	h.problem(c, http.StatusBadRequest, err.Error())
+}
+
+func (h *Handler) problem(c *gin.Context, status int, detail string) {
+	problem.Abort(c, problem.New(status, detail))
+}
+
+// setupValidator adds the request rules to the validator gin binds requests with.
+func setupValidator(logger *slog.Logger) {
+	validate, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+
+	if err := validation.Setup(validate, domain.MaxPayloadLength); err != nil {
+		logger.Error("failed to set up request validation", slog.String("error", err.Error()))
+	}
+}
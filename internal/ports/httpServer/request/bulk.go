@@ -0,0 +1,122 @@
+package request
+
+import (
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type BulkMode struct {
+	Mode string `json:"mode" example:"atomic" enums:"atomic,best_effort"`
+}
+
+// ToDomain defaults to the atomic mode.
+func (b BulkMode) ToDomain() domain.BulkMode {
+	if b.Mode == "" {
+		return domain.BulkModeAtomic
+	}
+
+	return domain.BulkMode(b.Mode)
+}
+
+type BulkTasks struct {
+	BulkMode
+	Tasks []Task `json:"tasks" binding:"required,min=1,max=1000,dive"`
+}
+
+func (t BulkTasks) ToDomain() []*domain.Task {
+	tasks := make([]*domain.Task, 0, len(t.Tasks))
+	for _, task := range t.Tasks {
+		tasks = append(tasks, task.ToDomain())
+	}
+
+	return tasks
+}
+
+type BulkAssignment struct {
+	TaskID   string `json:"template_task_id" binding:"required,uuid"`
+	Class    string `json:"class" binding:"required,notblank,max=32"`
+	LessonID string `json:"lesson_id" binding:"required,uuid"`
+}
+
+type BulkAssignments struct {
+	BulkMode
+	Assignments []BulkAssignment `json:"assignments" binding:"required,min=1,max=1000,dive"`
+	Draft       bool             `json:"draft,omitempty"`
+	PublishAt   *time.Time       `json:"publish_at,omitempty" example:"2025-01-01T08:00:00Z"`
+}
+
+func (t BulkAssignments) ToDomain() (*domain.BulkAssignments, error) {
+	items := make([]domain.BulkAssignment, 0, len(t.Assignments))
+	for i, assignment := range t.Assignments {
+		taskID, err := uuid.Parse(assignment.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("assignment %d: invalid task id = %s with error: %w", i, assignment.TaskID, err)
+		}
+
+		lessonID, err := uuid.Parse(assignment.LessonID)
+		if err != nil {
+			return nil, fmt.Errorf("assignment %d: invalid lesson id = %s with error: %w", i, assignment.LessonID, err)
+		}
+
+		items = append(items, domain.BulkAssignment{
+			TaskID:   taskID,
+			Class:    assignment.Class,
+			LessonID: lessonID,
+		})
+	}
+
+	return &domain.BulkAssignments{
+		Mode:      t.BulkMode.ToDomain(),
+		Items:     items,
+		Draft:     t.Draft,
+		PublishAt: t.PublishAt,
+	}, nil
+}
+
+type BulkAssignmentIDs struct {
+	BulkMode
+	AssignmentIDs []string `json:"class_task_ids" binding:"required,min=1,max=1000,unique,dive,uuid"`
+}
+
+func (t BulkAssignmentIDs) ToUUIDs() ([]uuid.UUID, error) {
+	ids := make([]uuid.UUID, 0, len(t.AssignmentIDs))
+	for _, id := range t.AssignmentIDs {
+		assignmentID, err := uuid.Parse(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assignment id = %s with error: %w", id, err)
+		}
+		ids = append(ids, assignmentID)
+	}
+
+	return ids, nil
+}
+
+type DeadlineUpdate struct {
+	AssignmentID string `json:"class_task_id" binding:"required,uuid"`
+	// Deadline is null to remove the deadline.
+	Deadline *time.Time `json:"deadline" binding:"omitempty,future" example:"2025-01-01T13:00:00Z"`
+}
+
+type BulkDeadlines struct {
+	BulkMode
+	Deadlines []DeadlineUpdate `json:"deadlines" binding:"required,min=1,max=1000,unique=AssignmentID,dive"`
+}
+
+func (t BulkDeadlines) ToDomainUpdates() ([]domain.DeadlineUpdate, error) {
+	updates := make([]domain.DeadlineUpdate, 0, len(t.Deadlines))
+	for _, update := range t.Deadlines {
+		assignmentID, err := uuid.Parse(update.AssignmentID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid assignment id = %s with error: %w", update.AssignmentID, err)
+		}
+		updates = append(updates, domain.DeadlineUpdate{
+			AssignmentID: assignmentID,
+			Deadline:     update.Deadline,
+		})
+	}
+
+	return updates, nil
+}
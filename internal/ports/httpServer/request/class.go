@@ -0,0 +1,44 @@
+package request
+
+import (
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type NewClass struct {
+	Name string `json:"name" binding:"required,notblank,max=32" example:"5A"`
+}
+
+func (c NewClass) ToDomain() *domain.Class {
+	return &domain.Class{
+		Name: c.Name,
+	}
+}
+
+type Lesson struct {
+	// ID of the lesson in the schedule, generated when empty.
+	ID       string     `json:"id,omitempty" binding:"omitempty,uuid"`
+	Subject  string     `json:"subject" binding:"max=100" example:"Математика"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+}
+
+func (l Lesson) ToDomain(class string) (*domain.Lesson, error) {
+	var id uuid.UUID
+	if l.ID != "" {
+		var err error
+		id, err = uuid.Parse(l.ID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid lesson id = %s with error: %w", l.ID, err)
+		}
+	}
+
+	return &domain.Lesson{
+		ID:       id,
+		Class:    class,
+		Subject:  l.Subject,
+		StartsAt: l.StartsAt,
+	}, nil
+}
@@ -0,0 +1,71 @@
+package request
+
+import (
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type AssignmentLatePolicy struct {
+	AssignmentID  string `json:"class_task_id" binding:"required,uuid"`
+	Policy        string `json:"late_policy" binding:"required,oneof=accept penalty reject" example:"penalty" enums:"accept,penalty,reject"`
+	PenaltyPerDay int    `json:"penalty_per_day" binding:"min=0,max=100" example:"10"`
+}
+
+func (t AssignmentLatePolicy) ToDomain() (*domain.AssignmentLatePolicy, error) {
+	assignmentID, err := uuid.Parse(t.AssignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid assignment id = %s with error: %w", t.AssignmentID, err)
+	}
+
+	return &domain.AssignmentLatePolicy{
+		AssignmentID:  assignmentID,
+		Policy:        domain.LatePolicy(t.Policy),
+		PenaltyPerDay: t.PenaltyPerDay,
+	}, nil
+}
+
+type DeadlineExtension struct {
+	AssignmentID string    `json:"class_task_id" binding:"required,uuid"`
+	UserID       string    `json:"user_id" binding:"required,uuid"`
+	Deadline     time.Time `json:"deadline" binding:"required,future" example:"2025-01-01T13:00:00Z"`
+}
+
+func (t DeadlineExtension) ToDomain() (*domain.DeadlineExtension, error) {
+	assignmentID, err := uuid.Parse(t.AssignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid assignment id = %s with error: %w", t.AssignmentID, err)
+	}
+
+	userID, err := uuid.Parse(t.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id = %s with error: %w", t.UserID, err)
+	}
+
+	return &domain.DeadlineExtension{
+		AssignmentID: assignmentID,
+		UserID:       userID,
+		Deadline:     t.Deadline,
+	}, nil
+}
+
+type DeadlineExtensionID struct {
+	AssignmentID string `form:"class_task_id" binding:"required,uuid"`
+	UserID       string `form:"user_id" binding:"required,uuid"`
+}
+
+func (t DeadlineExtensionID) ToUUIDs() (uuid.UUID, uuid.UUID, error) {
+	assignmentID, err := uuid.Parse(t.AssignmentID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid assignment id = %s with error: %w", t.AssignmentID, err)
+	}
+
+	userID, err := uuid.Parse(t.UserID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, fmt.Errorf("invalid user id = %s with error: %w", t.UserID, err)
+	}
+
+	return assignmentID, userID, nil
+}
@@ -0,0 +1,93 @@
+package request
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const MergePatchContentType = "application/merge-patch+json"
+
+// Field is a member of an RFC 7396 merge patch. It remembers whether the member was present at all,
+// so an absent member keeps the field and an explicit null clears it.
+type Field[T any] struct {
+	Set   bool
+	Value *T
+}
+
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Value = nil
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	f.Value = &value
+
+	return nil
+}
+
+func (f Field[T]) ToDomain() domain.PatchField[T] {
+	return domain.PatchField[T]{Set: f.Set, Value: f.Value}
+}
+
+// DecodeMergePatch decodes a merge patch document into v. The document must be a single JSON object
+// without members v doesn't know about.
+func DecodeMergePatch(body io.Reader, v any) error {
+	decoder := json.NewDecoder(body)
+	decoder.DisallowUnknownFields()
+
+	if err := decoder.Decode(v); err != nil {
+		return fmt.Errorf("invalid merge patch: %w", err)
+	}
+
+	if _, err := decoder.Token(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid merge patch: unexpected data after the patch object")
+	}
+
+	return nil
+}
+
+// TaskPatch is a merge patch of a task template: payload can be replaced, deadline and category replaced or cleared with null.
+type TaskPatch struct {
+	Payload  Field[string]    `json:"payload" swaggertype:"string"`
+	Deadline Field[time.Time] `json:"deadline" swaggertype:"string" example:"2025-01-01T13:00:00Z"`
+	Category Field[string]    `json:"category" swaggertype:"string"`
+}
+
+func (t TaskPatch) ToDomain(id uuid.UUID, version int) *domain.TaskPatch {
+	return &domain.TaskPatch{
+		ID:       id,
+		Version:  version,
+		Payload:  t.Payload.ToDomain(),
+		Deadline: t.Deadline.ToDomain(),
+		Category: t.Category.ToDomain(),
+	}
+}
+
+// AssignmentPatch is a merge patch of an assignment: class and payload can be replaced, deadline replaced or cleared with null.
+type AssignmentPatch struct {
+	Class    Field[string]    `json:"class" swaggertype:"string"`
+	Payload  Field[string]    `json:"payload" swaggertype:"string"`
+	Deadline Field[time.Time] `json:"deadline" swaggertype:"string" example:"2025-01-01T13:00:00Z"`
+}
+
+func (t AssignmentPatch) ToDomain(assignmentID uuid.UUID, version int) *domain.AssignmentPatch {
+	return &domain.AssignmentPatch{
+		AssignmentID: assignmentID,
+		Version:      version,
+		Class:        t.Class.ToDomain(),
+		Payload:      t.Payload.ToDomain(),
+		Deadline:     t.Deadline.ToDomain(),
+	}
+}
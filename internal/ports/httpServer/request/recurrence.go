@@ -0,0 +1,92 @@
+package request
+
+import (
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Recurrence struct {
+	TaskID         string    `json:"template_task_id" binding:"required,uuid"`
+	Class          string    `json:"class" binding:"required,notblank,max=32"`
+	LessonID       string    `json:"lesson_id" binding:"required,uuid"`
+	RRule          string    `json:"rrule" binding:"required,max=500" example:"FREQ=WEEKLY;BYDAY=MO,TH;COUNT=10"`
+	StartsAt       time.Time `json:"starts_at" binding:"required" example:"2025-09-01T09:00:00+03:00"`
+	Timezone       string    `json:"timezone,omitempty" binding:"omitempty,timezone" example:"Europe/Moscow"`
+	DeadlineOffset string    `json:"deadline_offset,omitempty" example:"48h"`
+}
+
+func (t Recurrence) ToDomain() (*domain.Recurrence, error) {
+	taskID, err := uuid.Parse(t.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task id = %s with error: %w", t.TaskID, err)
+	}
+
+	lessonID, err := uuid.Parse(t.LessonID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lesson id = %s with error: %w", t.LessonID, err)
+	}
+
+	rule, err := domain.ParseRRule(t.RRule)
+	if err != nil {
+		return nil, err
+	}
+
+	recurrence := &domain.Recurrence{
+		ID:       uuid.New(),
+		TaskID:   taskID,
+		Class:    t.Class,
+		LessonID: lessonID,
+		Rule:     rule,
+		StartsAt: t.StartsAt,
+		Timezone: t.Timezone,
+	}
+
+	if t.DeadlineOffset != "" {
+		offset, err := time.ParseDuration(t.DeadlineOffset)
+		if err != nil {
+			return nil, fmt.Errorf("invalid deadline offset = %s with error: %w", t.DeadlineOffset, err)
+		}
+		recurrence.DeadlineOffset = &offset
+	}
+
+	return recurrence, nil
+}
+
+type RecurrenceID struct {
+	RecurrenceID string `form:"recurrence_id" binding:"required,uuid"`
+}
+
+func (t RecurrenceID) ToUUID() (uuid.UUID, error) {
+	recurrenceID, err := uuid.Parse(t.RecurrenceID)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid recurrence id = %s with error: %w", t.RecurrenceID, err)
+	}
+
+	return recurrenceID, nil
+}
+
+type OccurrenceException struct {
+	RecurrenceID string     `json:"recurrence_id" binding:"required,uuid"`
+	OccurrenceAt time.Time  `json:"occurrence_at" binding:"required" example:"2025-09-04T09:00:00+03:00"`
+	Skip         bool       `json:"skip,omitempty"`
+	Payload      *string    `json:"payload,omitempty" binding:"omitempty,notblank,payload"`
+	Deadline     *time.Time `json:"deadline,omitempty" binding:"omitempty,future" example:"2025-09-06T09:00:00+03:00"`
+}
+
+func (t OccurrenceException) ToDomain() (*domain.OccurrenceException, error) {
+	recurrenceID, err := uuid.Parse(t.RecurrenceID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid recurrence id = %s with error: %w", t.RecurrenceID, err)
+	}
+
+	return &domain.OccurrenceException{
+		RecurrenceID: recurrenceID,
+		OccurrenceAt: t.OccurrenceAt,
+		Skipped:      t.Skip,
+		Payload:      t.Payload,
+		Deadline:     t.Deadline,
+	}, nil
+}
@@ -0,0 +1,45 @@
+package request
+
+import (
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type TaskShare struct {
+	// UserID is empty when the template is shared with the whole school.
+	UserID     string `json:"user_id,omitempty" binding:"omitempty,uuid"`
+	Permission string `json:"permission" binding:"required,oneof=view use edit" example:"use" enums:"view,use,edit"`
+}
+
+func (t TaskShare) ToDomain(taskID uuid.UUID) (*domain.TaskShare, error) {
+	userID, err := TaskShareUser{UserID: t.UserID}.ToUUID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.TaskShare{
+		TaskID:     taskID,
+		UserID:     userID,
+		Permission: domain.Permission(t.Permission),
+	}, nil
+}
+
+type TaskShareUser struct {
+	UserID string `form:"user_id" binding:"omitempty,uuid"`
+}
+
+// ToUUID returns nil for the share with the whole school.
+func (t TaskShareUser) ToUUID() (*uuid.UUID, error) {
+	if t.UserID == "" {
+		return nil, nil
+	}
+
+	userID, err := uuid.Parse(t.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user id = %s with error: %w", t.UserID, err)
+	}
+
+	return &userID, nil
+}
@@ -9,14 +9,16 @@ import (
 )
 
 type Task struct {
-	Payload  string    `json:"payload" binding:"required"`
-	Deadline time.Time `json:"deadline,omitempty" example:"2025-01-01T13:00:00Z"`
+	Payload  string    `json:"payload" binding:"required,notblank,payload"`
+	Deadline time.Time `json:"deadline,omitempty" binding:"omitempty,future" example:"2025-01-01T13:00:00Z"`
+	Category string    `json:"category,omitempty" binding:"max=100" example:"алгебра"`
 }
 
 func (t Task) ToDomain() *domain.Task {
 	task := &domain.Task{
-		ID:      uuid.New(),
-		Payload: t.Payload,
+		ID:       uuid.New(),
+		Payload:  t.Payload,
+		Category: t.Category,
 	}
 
 	if !t.Deadline.IsZero() {
@@ -26,10 +28,19 @@ func (t Task) ToDomain() *domain.Task {
 	return task
 }
 
-func (t Task) ToDomainWithID(id uuid.UUID) *domain.Task {
+// TaskUpdate is Task without the future rule on the deadline, so a template can still be saved
+// after its deadline has passed.
+type TaskUpdate struct {
+	Payload  string    `json:"payload" binding:"required,notblank,payload"`
+	Deadline time.Time `json:"deadline,omitempty" example:"2025-01-01T13:00:00Z"`
+	Category string    `json:"category,omitempty" binding:"max=100" example:"алгебра"`
+}
+
+func (t TaskUpdate) ToDomain(id uuid.UUID) *domain.Task {
 	task := &domain.Task{
-		ID:      id,
-		Payload: t.Payload,
+		ID:       id,
+		Payload:  t.Payload,
+		Category: t.Category,
 	}
 
 	if !t.Deadline.IsZero() {
@@ -40,9 +51,9 @@ func (t Task) ToDomainWithID(id uuid.UUID) *domain.Task {
 }
 
 type TaskAsignment struct {
-	AssignmentID string `json:"class_task_id" binding:"required"`
-	Class        string `json:"class" binding:"required"`
-	Payload      string `json:"payload" binding:"required"`
+	AssignmentID string `json:"class_task_id" binding:"required,uuid"`
+	Class        string `json:"class" binding:"required,notblank,max=32"`
+	Payload      string `json:"payload" binding:"required,notblank,payload"`
 }
 
 func (t TaskAsignment) ToDomain() (*domain.TaskAsignment, error) {
@@ -61,13 +72,16 @@ func (t TaskAsignment) ToDomain() (*domain.TaskAsignment, error) {
 }
 
 type ClassLesson struct {
-	Class    string `json:"class" binding:"required"`
-	LessonID string `json:"lesson_id" binding:"required"`
+	Class    string `json:"class" binding:"required,notblank,max=32"`
+	LessonID string `json:"lesson_id" binding:"required,uuid"`
 }
 
 type TaskAsignments struct {
-	ToAssign []ClassLesson `json:"assign_to" binding:"required"`
-	TaskID   string        `json:"template_task_id" binding:"required"`
+	// ToAssign names every lesson once.
+	ToAssign  []ClassLesson `json:"assign_to" binding:"required,min=1,max=100,unique=LessonID,dive"`
+	TaskID    string        `json:"template_task_id" binding:"required,uuid"`
+	Draft     bool          `json:"draft,omitempty"`
+	PublishAt *time.Time    `json:"publish_at,omitempty" example:"2025-01-01T08:00:00Z"`
 }
 
 func (t TaskAsignments) ToDomain() (*domain.TaskAsignments, error) {
@@ -89,16 +103,20 @@ func (t TaskAsignments) ToDomain() (*domain.TaskAsignments, error) {
 	}
 
 	return &domain.TaskAsignments{
-		TaskID:   taskID,
-		ToAssign: classLesson,
+		TaskID:    taskID,
+		ToAssign:  classLesson,
+		Draft:     t.Draft,
+		PublishAt: t.PublishAt,
 	}, nil
 }
 
 type TaskWithAsignment struct {
-	Class    string    `json:"class" binding:"required"`
-	LessonID string    `json:"lesson_id" binding:"required"`
-	Payload  string    `json:"payload" binding:"required"`
-	Deadline time.Time `json:"deadline,omitempty" example:"2025-01-01T13:00:00Z"`
+	Class     string     `json:"class" binding:"required,notblank,max=32"`
+	LessonID  string     `json:"lesson_id" binding:"required,uuid"`
+	Payload   string     `json:"payload" binding:"required,notblank,payload"`
+	Deadline  time.Time  `json:"deadline,omitempty" binding:"omitempty,future" example:"2025-01-01T13:00:00Z"`
+	Draft     bool       `json:"draft,omitempty"`
+	PublishAt *time.Time `json:"publish_at,omitempty" example:"2025-01-01T08:00:00Z"`
 }
 
 func (t TaskWithAsignment) ToDomain() (*domain.TaskWithAsignment, error) {
@@ -109,10 +127,12 @@ func (t TaskWithAsignment) ToDomain() (*domain.TaskWithAsignment, error) {
 	}
 
 	taskWithAssignment := &domain.TaskWithAsignment{
-		Class:    t.Class,
-		LessonID: lessonID,
-		TaskID:   uuid.New(),
-		Payload:  t.Payload,
+		Class:     t.Class,
+		LessonID:  lessonID,
+		TaskID:    uuid.New(),
+		Payload:   t.Payload,
+		Draft:     t.Draft,
+		PublishAt: t.PublishAt,
 	}
 
 	if !t.Deadline.IsZero() {
@@ -123,9 +143,10 @@ func (t TaskWithAsignment) ToDomain() (*domain.TaskWithAsignment, error) {
 }
 
 type TaskResult struct {
-	UsersResult []UserResult `json:"users_result" binding:"required"`
-	TaskID      string       `json:"task_id" binding:"required"`
-	LessonID    string       `json:"lesson_id" binding:"required"`
+	// UsersResult has one result per student.
+	UsersResult []UserResult `json:"users_result" binding:"required,min=1,max=1000,unique=UserID,dive"`
+	TaskID      string       `json:"task_id" binding:"required,uuid"`
+	LessonID    string       `json:"lesson_id" binding:"required,uuid"`
 }
 
 func (t TaskResult) ToDomain() (*domain.TaskResult, error) {
@@ -136,9 +157,9 @@ func (t TaskResult) ToDomain() (*domain.TaskResult, error) {
 		return nil, fmt.Errorf("invalid task id = %s with error: %w", t.TaskID, err)
 	}
 
-	lessonID, err := uuid.Parse(t.TaskID)
+	lessonID, err := uuid.Parse(t.LessonID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid lesson id = %s with error: %w", t.TaskID, err)
+		return nil, fmt.Errorf("invalid lesson id = %s with error: %w", t.LessonID, err)
 	}
 
 	for _, ur := range t.UsersResult {
@@ -147,8 +168,9 @@ func (t TaskResult) ToDomain() (*domain.TaskResult, error) {
 			return nil, fmt.Errorf("invalid user id = %s with error: %w", ur.UserID, err)
 		}
 		usersResult = append(usersResult, domain.UserResult{
-			UserID: userID,
-			Mark:   ur.Mark,
+			UserID:      userID,
+			Mark:        *ur.Mark,
+			SubmittedAt: ur.SubmittedAt,
 		})
 	}
 
@@ -160,16 +182,17 @@ func (t TaskResult) ToDomain() (*domain.TaskResult, error) {
 }
 
 type UserResult struct {
-	UserID string `json:"user_id" binding:"required"`
-	Mark   int    `json:"mark" binding:"required"`
+	UserID      string    `json:"user_id" binding:"required,uuid"`
+	Mark        *int      `json:"mark" binding:"required,min=0,max=100" example:"5"`
+	SubmittedAt time.Time `json:"submitted_at,omitempty" example:"2025-01-01T13:00:00Z"`
 }
 
 type Class struct {
-	Class string `form:"class"`
+	Class string `form:"class" binding:"max=32"`
 }
 
 type TaskAsignmentID struct {
-	AssignmentID string `form:"class_task_id" binding:"required"`
+	AssignmentID string `form:"class_task_id" binding:"required,uuid"`
 }
 
 func (t TaskAsignmentID) ToUUID() (uuid.UUID, error) {
@@ -180,3 +203,76 @@ func (t TaskAsignmentID) ToUUID() (uuid.UUID, error) {
 
 	return assignmentID, nil
 }
+
+type Import struct {
+	DryRun bool `form:"dry_run"`
+}
+
+// Assignment assigns a task template to one lesson of a class.
+type Assignment struct {
+	TaskID    string     `json:"template_task_id" binding:"required,uuid"`
+	Class     string     `json:"class" binding:"required,notblank,max=32"`
+	LessonID  string     `json:"lesson_id" binding:"required,uuid"`
+	Draft     bool       `json:"draft,omitempty"`
+	PublishAt *time.Time `json:"publish_at,omitempty" example:"2025-01-01T08:00:00Z"`
+}
+
+func (a Assignment) ToDomain() (*domain.TaskAsignments, error) {
+	taskID, err := uuid.Parse(a.TaskID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid task id = %s with error: %w", a.TaskID, err)
+	}
+
+	lessonID, err := uuid.Parse(a.LessonID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lesson id = %s with error: %w", a.LessonID, err)
+	}
+
+	return &domain.TaskAsignments{
+		TaskID:    taskID,
+		ToAssign:  []domain.ClassLesson{{Class: a.Class, LessonID: lessonID}},
+		Draft:     a.Draft,
+		PublishAt: a.PublishAt,
+	}, nil
+}
+
+// AssignmentUpdate is TaskAsignment without the ID, which comes from the path.
+type AssignmentUpdate struct {
+	Class   string `json:"class" binding:"required,notblank,max=32"`
+	Payload string `json:"payload" binding:"required,notblank,payload"`
+}
+
+func (a AssignmentUpdate) ToDomain(assignmentID uuid.UUID) *domain.TaskAsignment {
+	return &domain.TaskAsignment{
+		AssignmentID: assignmentID,
+		Class:        a.Class,
+		Payload:      a.Payload,
+	}
+}
+
+// AssignmentResults is TaskResult without the assignment and its lesson, which come from the path.
+type AssignmentResults struct {
+	// UsersResult has one result per student.
+	UsersResult []UserResult `json:"users_result" binding:"required,min=1,max=1000,unique=UserID,dive"`
+}
+
+func (r AssignmentResults) ToDomain(assignment *domain.TaskAsignment) (*domain.TaskResult, error) {
+	usersResult := make([]domain.UserResult, 0, len(r.UsersResult))
+	for _, ur := range r.UsersResult {
+		userID, err := uuid.Parse(ur.UserID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user id = %s with error: %w", ur.UserID, err)
+		}
+		usersResult = append(usersResult, domain.UserResult{
+			UserID:      userID,
+			Mark:        *ur.Mark,
+			SubmittedAt: ur.SubmittedAt,
+		})
+	}
+
+	return &domain.TaskResult{
+		UsersResult: usersResult,
+		TaskID:      assignment.AssignmentID,
+		LessonID:    assignment.LessonID,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package response
+
+import (
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type BulkItem struct {
+	Index  int    `json:"index"`
+	ID     string `json:"id,omitempty"`
+	Status string `json:"status" example:"done" enums:"done,failed,rolled_back"`
+	Error  string `json:"error,omitempty"`
+}
+
+type BulkResult struct {
+	Mode    string     `json:"mode" example:"atomic"`
+	Applied int        `json:"applied"`
+	Items   []BulkItem `json:"items"`
+}
+
+func NewBulkResultResponse(result *domain.BulkResult) *BulkResult {
+	items := make([]BulkItem, 0, len(result.Items))
+	for _, item := range result.Items {
+		bulkItem := BulkItem{
+			Index:  item.Index,
+			Status: string(item.Status),
+		}
+		if item.ID != uuid.Nil {
+			bulkItem.ID = item.ID.String()
+		}
+		if item.Err != nil {
+			bulkItem.Error = itemError(item.Err)
+		}
+		items = append(items, bulkItem)
+	}
+
+	return &BulkResult{
+		Mode:    string(result.Mode),
+		Applied: result.Applied(),
+		Items:   items,
+	}
+}
+
+// itemError hides the text of errors the client didn't cause, it may come from the database.
+func itemError(err error) string {
+	if _, ok := domain.AsError(err); ok {
+		return err.Error()
+	}
+
+	return "internal error"
+}
@@ -0,0 +1,11 @@
+package response
+
+type CalendarLink struct {
+	URL string `json:"url" example:"/api/v1/class/9A/calendar.ics?token=..."`
+}
+
+func NewCalendarLinkResponse(url string) *CalendarLink {
+	return &CalendarLink{
+		URL: url,
+	}
+}
@@ -0,0 +1,64 @@
+package response
+
+import (
+	"task/internal/domain"
+	"time"
+)
+
+type Class struct {
+	ID   string `json:"id"`
+	Name string `json:"name" example:"5A"`
+}
+
+func NewClassResponse(class *domain.Class) *Class {
+	return &Class{
+		ID:   class.ID.String(),
+		Name: class.Name,
+	}
+}
+
+type Classes struct {
+	Classes []Class `json:"classes"`
+}
+
+func NewClassesResponse(classes []domain.Class) *Classes {
+	response := &Classes{
+		Classes: make([]Class, 0, len(classes)),
+	}
+	for i := range classes {
+		response.Classes = append(response.Classes, *NewClassResponse(&classes[i]))
+	}
+
+	return response
+}
+
+type Lesson struct {
+	ID       string     `json:"id"`
+	Subject  string     `json:"subject"`
+	StartsAt *time.Time `json:"starts_at,omitempty"`
+}
+
+func NewLessonResponse(lesson *domain.Lesson) *Lesson {
+	return &Lesson{
+		ID:       lesson.ID.String(),
+		Subject:  lesson.Subject,
+		StartsAt: lesson.StartsAt,
+	}
+}
+
+type Lessons struct {
+	Class   string   `json:"class" example:"5A"`
+	Lessons []Lesson `json:"lessons"`
+}
+
+func NewLessonsResponse(class string, lessons []domain.Lesson) *Lessons {
+	response := &Lessons{
+		Class:   class,
+		Lessons: make([]Lesson, 0, len(lessons)),
+	}
+	for i := range lessons {
+		response.Lessons = append(response.Lessons, *NewLessonResponse(&lessons[i]))
+	}
+
+	return response
+}
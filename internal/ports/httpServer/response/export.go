@@ -0,0 +1,51 @@
+package response
+
+import (
+	"task/internal/domain"
+	"time"
+)
+
+type GradebookEntry struct {
+	StudentID      string     `json:"student_id"`
+	TaskID         string     `json:"task_id"`
+	LessonID       string     `json:"lesson_id"`
+	Class          string     `json:"class"`
+	TaskTemplateID string     `json:"task_template_id"`
+	Payload        string     `json:"payload"`
+	Deadline       *time.Time `json:"deadline,omitempty" example:"2025-01-01T13:00:00Z"`
+	Mark           *int       `json:"mark"`
+	Penalty        int        `json:"penalty"`
+	SubmittedAt    *time.Time `json:"submitted_at,omitempty" example:"2025-01-01T13:00:00Z"`
+}
+
+func NewGradebookEntryResponse(entry *domain.GradebookEntry) *GradebookEntry {
+	return &GradebookEntry{
+		StudentID:      entry.StudentID.String(),
+		TaskID:         entry.TaskID.String(),
+		LessonID:       entry.LessonID.String(),
+		Class:          entry.Class,
+		TaskTemplateID: entry.TaskTemplateID.String(),
+		Payload:        entry.Payload,
+		Deadline:       entry.Deadline,
+		Mark:           entry.Mark,
+		Penalty:        entry.Penalty,
+		SubmittedAt:    entry.SubmittedAt,
+	}
+}
+
+type AssignmentResults struct {
+	AssignmentID string           `json:"class_task_id"`
+	Results      []GradebookEntry `json:"results"`
+}
+
+func NewAssignmentResultsResponse(assignmentID string, entries []domain.GradebookEntry) *AssignmentResults {
+	results := make([]GradebookEntry, 0, len(entries))
+	for i := range entries {
+		results = append(results, *NewGradebookEntryResponse(&entries[i]))
+	}
+
+	return &AssignmentResults{
+		AssignmentID: assignmentID,
+		Results:      results,
+	}
+}
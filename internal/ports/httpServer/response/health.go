@@ -0,0 +1,31 @@
+package response
+
+import "task/pkg/health"
+
+type HealthCheck struct {
+	Status string `json:"status" example:"up" enums:"up,down"`
+	Error  string `json:"error,omitempty"`
+	// DurationMS is how long the check took in milliseconds.
+	DurationMS int64 `json:"duration_ms"`
+}
+
+type Health struct {
+	Status string                 `json:"status" example:"up" enums:"up,down,draining"`
+	Checks map[string]HealthCheck `json:"checks,omitempty"`
+}
+
+func NewHealthResponse(report *health.Report) *Health {
+	response := &Health{
+		Status: report.Status,
+		Checks: make(map[string]HealthCheck, len(report.Checks)),
+	}
+	for name, check := range report.Checks {
+		response.Checks[name] = HealthCheck{
+			Status:     check.Status,
+			Error:      check.Error,
+			DurationMS: check.Duration.Milliseconds(),
+		}
+	}
+
+	return response
+}
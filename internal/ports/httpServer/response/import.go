@@ -0,0 +1,34 @@
+package response
+
+import "task/internal/domain"
+
+type ImportError struct {
+	Row    int    `json:"row" example:"2"`
+	Column string `json:"column,omitempty" example:"deadline"`
+	Error  string `json:"error"`
+}
+
+type ImportReport struct {
+	DryRun   bool          `json:"dry_run"`
+	Rows     int           `json:"rows"`
+	Imported int           `json:"imported"`
+	Errors   []ImportError `json:"errors"`
+}
+
+func NewImportReportResponse(report *domain.ImportReport) *ImportReport {
+	errs := make([]ImportError, 0, len(report.Errors))
+	for _, rowErr := range report.Errors {
+		errs = append(errs, ImportError{
+			Row:    rowErr.Row,
+			Column: rowErr.Column,
+			Error:  rowErr.Err.Error(),
+		})
+	}
+
+	return &ImportReport{
+		DryRun:   report.DryRun,
+		Rows:     report.Rows,
+		Imported: report.Imported,
+		Errors:   errs,
+	}
+}
@@ -0,0 +1,37 @@
+package response
+
+import (
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+type TaskShare struct {
+	// UserID is empty when the template is shared with the whole school.
+	UserID     string `json:"user_id,omitempty"`
+	Permission string `json:"permission" example:"use"`
+}
+
+type TaskShares struct {
+	TaskID string      `json:"task_id"`
+	Shares []TaskShare `json:"shares"`
+}
+
+func NewTaskSharesResponse(taskID uuid.UUID, shares []domain.TaskShare) *TaskShares {
+	response := &TaskShares{
+		TaskID: taskID.String(),
+		Shares: make([]TaskShare, 0, len(shares)),
+	}
+	for _, share := range shares {
+		var userID string
+		if share.UserID != nil {
+			userID = share.UserID.String()
+		}
+		response.Shares = append(response.Shares, TaskShare{
+			UserID:     userID,
+			Permission: string(share.Permission),
+		})
+	}
+
+	return response
+}
@@ -11,16 +11,24 @@ type Task struct {
 	ID       string     `json:"id"`
 	Payload  string     `json:"payload" binding:"required"`
 	Deadline *time.Time `json:"deadline,omitempty" example:"2025-01-01T13:00:00Z"`
+	Category string     `json:"category,omitempty" example:"алгебра"`
+	Version  int        `json:"version" example:"1"`
+	AuthorID string     `json:"author_id,omitempty"`
 }
 
 func NewTaskResponse(task *domain.Task) *Task {
 	response := &Task{
-		ID:      task.ID.String(),
-		Payload: task.Payload,
+		ID:       task.ID.String(),
+		Payload:  task.Payload,
+		Category: task.Category,
+		Version:  task.Version,
 	}
 	if task.Deadline != nil {
 		response.Deadline = task.Deadline
 	}
+	if task.AuthorID != nil {
+		response.AuthorID = task.AuthorID.String()
+	}
 
 	return response
 }
@@ -33,15 +41,7 @@ func NewTasksResponse(tasks []*domain.Task) *Tasks {
 	var t Tasks
 	t.Tasks = make([]Task, 0, len(tasks))
 	for _, task := range tasks {
-		response := &Task{
-			ID:      task.ID.String(),
-			Payload: task.Payload,
-		}
-		if task.Deadline != nil {
-			response.Deadline = task.Deadline
-		}
-
-		t.Tasks = append(t.Tasks, *response)
+		t.Tasks = append(t.Tasks, *NewTaskResponse(task))
 	}
 
 	return &t
@@ -53,6 +53,7 @@ type LessonTask struct {
 	Payload        string     `json:"payload" binding:"required"`
 	Deadline       *time.Time `json:"deadline,omitempty" example:"2025-01-01T13:00:00Z"`
 	TaskTemplateID string     `json:"task_template_id"`
+	Version        int        `json:"version" example:"1"`
 }
 
 type TaskID struct {
@@ -87,6 +88,7 @@ func NewClassTasksResponse(class string, lt []*domain.LessonTask) *ClassTasks {
 			Payload:        domainLessonTask.Payload,
 			Deadline:       domainLessonTask.Deadline,
 			TaskTemplateID: domainLessonTask.TaskTemplateID.String(),
+			Version:        domainLessonTask.Version,
 		})
 	}
 	return &ClassTasks{
@@ -142,6 +144,7 @@ type Assignments struct {
 	AssignmentID string `json:"class_task_id" binding:"required"`
 	Class        string `json:"class" binding:"required"`
 	LessonID     string `json:"lesson_id" binding:"required"`
+	Status       string `json:"status" example:"published"`
 }
 
 type TaskAssignments struct {
@@ -156,6 +159,7 @@ func NewAssignmentsResponse(taskID string, a []domain.Assignment) *TaskAssignmen
 			AssignmentID: val.AssignmentID.String(),
 			Class:        val.Class,
 			LessonID:     val.LessonID.String(),
+			Status:       string(val.Status),
 		})
 	}
 
@@ -164,3 +168,33 @@ func NewAssignmentsResponse(taskID string, a []domain.Assignment) *TaskAssignmen
 		TaskID:   taskID,
 	}
 }
+
+type RecurrenceID struct {
+	ID string `json:"recurrence_id"`
+}
+
+func NewRecurrenceIDResponse(id uuid.UUID) *RecurrenceID {
+	return &RecurrenceID{
+		ID: id.String(),
+	}
+}
+
+type Assignment struct {
+	AssignmentID string     `json:"class_task_id"`
+	Class        string     `json:"class"`
+	LessonID     string     `json:"lesson_id"`
+	Payload      string     `json:"payload"`
+	Deadline     *time.Time `json:"deadline,omitempty" example:"2025-01-01T13:00:00Z"`
+	Version      int        `json:"version" example:"1"`
+}
+
+func NewAssignmentResponse(assignment *domain.TaskAsignment) *Assignment {
+	return &Assignment{
+		AssignmentID: assignment.AssignmentID.String(),
+		Class:        assignment.Class,
+		LessonID:     assignment.LessonID.String(),
+		Payload:      assignment.Payload,
+		Deadline:     assignment.Deadline,
+		Version:      assignment.Version,
+	}
+}
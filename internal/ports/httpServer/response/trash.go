@@ -0,0 +1,72 @@
+package response
+
+import (
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type DeletedTask struct {
+	ID        string     `json:"id"`
+	Payload   string     `json:"payload"`
+	Deadline  *time.Time `json:"deadline,omitempty" example:"2025-01-01T13:00:00Z"`
+	DeletedAt time.Time  `json:"deleted_at" example:"2025-01-01T13:00:00Z"`
+	DeletedBy *string    `json:"deleted_by,omitempty"`
+}
+
+type DeletedAssignment struct {
+	AssignmentID   string    `json:"class_task_id"`
+	TaskTemplateID string    `json:"task_template_id"`
+	Class          string    `json:"class"`
+	LessonID       string    `json:"lesson_id"`
+	Payload        string    `json:"payload"`
+	DeletedAt      time.Time `json:"deleted_at" example:"2025-01-01T13:00:00Z"`
+	DeletedBy      *string   `json:"deleted_by,omitempty"`
+}
+
+type Trash struct {
+	Tasks       []DeletedTask       `json:"tasks"`
+	Assignments []DeletedAssignment `json:"assignments"`
+}
+
+func NewTrashResponse(trash *domain.Trash) *Trash {
+	tasks := make([]DeletedTask, 0, len(trash.Tasks))
+	for _, task := range trash.Tasks {
+		tasks = append(tasks, DeletedTask{
+			ID:        task.ID.String(),
+			Payload:   task.Payload,
+			Deadline:  task.Deadline,
+			DeletedAt: task.DeletedAt,
+			DeletedBy: uuidToString(task.DeletedBy),
+		})
+	}
+
+	assignments := make([]DeletedAssignment, 0, len(trash.Assignments))
+	for _, assignment := range trash.Assignments {
+		assignments = append(assignments, DeletedAssignment{
+			AssignmentID:   assignment.AssignmentID.String(),
+			TaskTemplateID: assignment.TaskID.String(),
+			Class:          assignment.Class,
+			LessonID:       assignment.LessonID.String(),
+			Payload:        assignment.Payload,
+			DeletedAt:      assignment.DeletedAt,
+			DeletedBy:      uuidToString(assignment.DeletedBy),
+		})
+	}
+
+	return &Trash{
+		Tasks:       tasks,
+		Assignments: assignments,
+	}
+}
+
+func uuidToString(id *uuid.UUID) *string {
+	if id == nil {
+		return nil
+	}
+
+	s := id.String()
+
+	return &s
+}
@@ -2,48 +2,157 @@ package httpserver
 
 import (
 	"log/slog"
+	"net/http"
 
-	"github.com/go-redis/redis_rate/v9"
-	swaggoFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
-
-	// Register swagger docs.
-	_ "task/docs/swagger"
+	"task/pkg/metrics"
 	ratelimiter "task/pkg/rate-limiter"
 
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis_rate/v9"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
 )
 
-// New 		godoc
-// @title 	Tasks API
-// @version 1.0
-func New(handler *Handler, logger *slog.Logger, rL *redis_rate.Limiter) *gin.Engine {
+const (
+	serviceName = "task"
+	apiV1       = "api/v1"
+	apiV2       = "api/v2"
+)
+
+// New builds the router of the API described by api/openapi/task.yaml. validate checks the requests of api/v1 and api/v2
+// against the spec once the tenant and the user are known.
+func New(handler *Handler, logger *slog.Logger, rL *redis_rate.Limiter, idempotent, graphQL, validate gin.HandlerFunc,
+	tenants TenantPolicy) *gin.Engine {
+	setupValidator(logger)
+
 	router := gin.New()
-	registerSwagger(router)
-	registerGroup(router, handler, logger, rL)
+	router.Use(otelgin.Middleware(serviceName, otelgin.WithFilter(func(r *http.Request) bool { return !isProbe(r) })))
+	router.Use(metrics.HTTP())
+	// Recovery is the innermost, so the access log and the metrics see the 500 of a panic.
+	router.Use(RequestID(logger), AccessLog(logger), Recovery(logger))
+	registerOpenAPI(router)
+	registerHealth(router, handler)
+	registerMetrics(router)
+	registerGroup(router, handler, logger, rL, idempotent, graphQL, validate, tenants)
+	registerCalendarFeeds(router, handler, logger, validate, tenants)
+	registerV2(router, handler, logger, idempotent, validate, tenants)
 
 	return router
 }
 
-func registerGroup(e *gin.Engine, handler *Handler, logger *slog.Logger, rL *redis_rate.Limiter) {
-	r := e.Group("api/v1")
+func registerGroup(e *gin.Engine, handler *Handler, logger *slog.Logger, rL *redis_rate.Limiter, idempotent, graphQL, validate gin.HandlerFunc,
+	tenants TenantPolicy) {
+	r := e.Group(apiV1)
 
 	ratelimiter.Limiter = rL
-	r.Use(ratelimiter.RateLimit(logger))
-
-	r.POST("/task", handler.CreateTask)
-	r.POST("task/create-with-assignment", handler.CreateTaskWithAssignment)
-	r.POST("/task/assignment", handler.AssignTaskToClasses)
-	r.PUT("/task/assignment-update", handler.UpdateTaskAssignment)
-	r.POST("/task/result", handler.TaskResult)
-	r.GET("/task/all", handler.GetTasks)
-	r.GET("/task/:id", handler.GetTask)
-	r.GET("/task/get-by-class", handler.GetTaskByClass)
-	r.PUT("/task/:id/update", handler.UpdateTask)
-	r.DELETE("/task/:id/delete", handler.DeleteTask)
-	r.DELETE("/task/assignment-delete", handler.DeleteAssignment)
+	r.Use(TenantContext(logger, tenants))
+	r.Use(UserContext(logger))
+	r.Use(ratelimiter.RateLimit(logger, userRateLimitKey))
+	r.Use(validate)
+
+	tasks := Deprecated("/" + apiV2 + "/tasks")
+	assignments := Deprecated("/" + apiV2 + "/assignments")
+
+	r.POST("/task", tasks, idempotent, handler.CreateTask)
+	r.POST("task/create-with-assignment", idempotent, handler.CreateTaskWithAssignment)
+	r.POST("/task/assignment", assignments, handler.AssignTaskToClasses)
+	r.PUT("/task/assignment-update", assignments, handler.UpdateTaskAssignment)
+	r.POST("/task/result", assignments, idempotent, handler.TaskResult)
+	r.GET("/task/all", tasks, handler.GetTasks)
+	r.GET("/task/:id", tasks, handler.GetTask)
+	r.GET("/task/get-by-class", assignments, handler.GetTaskByClass)
+	r.PUT("/task/:id/update", tasks, handler.UpdateTask)
+	r.PATCH("/task/:id", tasks, handler.PatchTask)
+	r.PATCH("/task/assignment", assignments, handler.PatchAssignment)
+	r.DELETE("/task/:id/delete", tasks, handler.DeleteTask)
+	r.DELETE("/task/assignment-delete", assignments, handler.DeleteAssignment)
+	r.PUT("/task/assignment-late-policy", handler.SetAssignmentLatePolicy)
+	r.PUT("/task/assignment-extension", handler.SetDeadlineExtension)
+	r.DELETE("/task/assignment-extension", handler.DeleteDeadlineExtension)
+	r.PUT("/task/assignment-publish", handler.PublishAssignment)
+	r.POST("/task/recurrence", handler.CreateRecurrence)
+	r.DELETE("/task/recurrence", handler.StopRecurrence)
+	r.PUT("/task/recurrence-occurrence", handler.UpdateOccurrence)
+	r.GET("/task/trash", handler.GetTrash)
+	r.PUT("/task/:id/restore", handler.RestoreTask)
+	r.PUT("/task/assignment-restore", handler.RestoreAssignment)
+	r.POST("/task/bulk", idempotent, handler.BulkCreateTasks)
+	r.POST("/task/assignment-bulk", idempotent, handler.BulkAssignTasks)
+	r.POST("/task/assignment-bulk-delete", handler.BulkDeleteAssignments)
+	r.PUT("/task/assignment-bulk-deadline", handler.BulkUpdateDeadlines)
+	r.POST("/task/import", handler.ImportTasks)
+	r.POST("/task/result/import", handler.ImportMarks)
+	r.GET("/task/export", handler.ExportTasks)
+	r.GET("/class/:class/gradebook", handler.ExportGradebook)
+	r.GET("/student/:id/marks", handler.ExportStudentMarks)
+	r.GET("/class/:class/calendar-link", handler.ClassCalendarLink)
+	r.GET("/class/:class/student/:id/calendar-link", handler.StudentCalendarLink)
+	r.GET("/task/:id/share", handler.GetTaskShares)
+	r.PUT("/task/:id/share", handler.ShareTask)
+	r.DELETE("/task/:id/share", handler.UnshareTask)
+	r.POST("/task/:id/copy", idempotent, handler.CopyTask)
+	r.GET("/class", handler.GetClasses)
+	r.POST("/class", handler.CreateClass)
+	r.GET("/class/:class/lesson", handler.GetLessons)
+	r.POST("/class/:class/lesson", handler.CreateLesson)
+	r.POST("/graphql", graphQL)
+}
+
+// registerCalendarFeeds puts the calendar feeds in api/v1 apart from the other routes: calendar apps fetch them
+// without the gateway, so the school comes from the link and the feed token stands in for the user.
+func registerCalendarFeeds(e *gin.Engine, handler *Handler, logger *slog.Logger, validate gin.HandlerFunc, tenants TenantPolicy) {
+	r := e.Group(apiV1)
+
+	r.Use(CalendarTenantContext(logger, tenants))
+	r.Use(ratelimiter.RateLimit(logger, feedRateLimitKey))
+	r.Use(validate)
+
+	r.GET("/class/:class/calendar.ics", handler.ClassCalendar)
+	r.GET("/class/:class/student/:id/calendar.ics", handler.StudentCalendar)
+}
+
+// registerV2 puts the resource-oriented routes in api/v2. The v1 routes they replace stay, marked Deprecated.
+// The rate limiter is shared with api/v1, so a user can't double their budget by mixing the versions.
+func registerV2(e *gin.Engine, handler *Handler, logger *slog.Logger, idempotent, validate gin.HandlerFunc, tenants TenantPolicy) {
+	r := e.Group(apiV2)
+
+	r.Use(TenantContext(logger, tenants))
+	r.Use(UserContext(logger))
+	r.Use(ratelimiter.RateLimit(logger, userRateLimitKey))
+	r.Use(validate)
+
+	r.GET("/tasks", handler.GetTasks)
+	r.POST("/tasks", idempotent, handler.CreateTaskV2)
+	r.GET("/tasks/:id", handler.GetTask)
+	r.PUT("/tasks/:id", handler.UpdateTask)
+	r.PATCH("/tasks/:id", handler.PatchTask)
+	r.DELETE("/tasks/:id", handler.DeleteTaskV2)
+	r.GET("/assignments", handler.GetTaskByClass)
+	r.POST("/assignments", idempotent, handler.CreateAssignmentV2)
+	r.GET("/assignments/:id", handler.GetAssignmentV2)
+	r.PUT("/assignments/:id", handler.UpdateAssignmentV2)
+	r.PATCH("/assignments/:id", handler.PatchAssignmentV2)
+	r.DELETE("/assignments/:id", handler.DeleteAssignmentV2)
+	r.GET("/assignments/:id/results", handler.GetAssignmentResults)
+	r.PUT("/assignments/:id/results", handler.SetAssignmentResults)
+}
+
+// isProbe tells the probes and the scrapes apart. They are left out of the traces
+// and the access log unless they fail, as they would only drown the requests.
+func isProbe(r *http.Request) bool {
+	switch r.URL.Path {
+	case "/healthz", "/readyz", "/metrics":
+		return true
+	}
+
+	return false
+}
+
+// registerHealth puts the probes outside api/v1, so neither the tenant nor the rate limit applies to them.
+func registerHealth(router *gin.Engine, handler *Handler) {
+	router.GET("/healthz", handler.Liveness)
+	router.GET("/readyz", handler.Readiness)
 }
 
-func registerSwagger(router *gin.Engine) {
-	router.GET("/swagger/*any", ginSwagger.WrapHandler(swaggoFiles.Handler))
+func registerMetrics(router *gin.Engine) {
+	router.GET("/metrics", gin.WrapH(metrics.Handler()))
 }
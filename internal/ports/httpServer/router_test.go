@@ -0,0 +1,330 @@
+package httpserver_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"task/api/openapi"
+	"task/internal/app"
+	"task/internal/domain"
+	httpserver "task/internal/ports/httpServer"
+	openapivalidator "task/pkg/openapi-validator"
+	"task/pkg/problem"
+	"task/pkg/taskclient"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/gin-gonic/gin"
+	redisLimiter "github.com/go-redis/redis/v8"
+	"github.com/go-redis/redis_rate/v9"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// taskService keeps the tasks it is given in memory.
+type taskService struct {
+	httpserver.TaskService
+	tasks map[uuid.UUID]*domain.Task
+}
+
+func (s *taskService) CreateTask(_ context.Context, task *domain.Task) (uuid.UUID, error) {
+	task.Version = 1
+	s.tasks[task.ID] = task
+	return task.ID, nil
+}
+
+func (s *taskService) GetTask(_ context.Context, id uuid.UUID) (*domain.Task, error) {
+	task, ok := s.tasks[id]
+	if !ok {
+		return nil, domain.ErrTaskNotFound
+	}
+
+	return task, nil
+}
+
+func (s *taskService) GetTasks(context.Context) ([]*domain.Task, error) {
+	tasks := make([]*domain.Task, 0, len(s.tasks))
+	for _, task := range s.tasks {
+		tasks = append(tasks, task)
+	}
+
+	return tasks, nil
+}
+
+func passThrough(c *gin.Context) {
+	c.Next()
+}
+
+const gatewaySecret = "gateway"
+
+// newRouter builds the router with the response validation on, so every test also checks
+// that the responses match the spec.
+func newRouter(t *testing.T) (*gin.Engine, *taskService) {
+	t.Helper()
+
+	return newTenantRouter(t, httpserver.TenantPolicy{GatewaySecret: gatewaySecret})
+}
+
+func newTenantRouter(t *testing.T, tenants httpserver.TenantPolicy) (*gin.Engine, *taskService) {
+	t.Helper()
+
+	redis := miniredis.RunT(t)
+	limiter := redis_rate.NewLimiter(redisLimiter.NewClient(&redisLimiter.Options{Addr: redis.Addr()}))
+
+	logger := app.InitLogger()
+	validate, err := openapivalidator.Middleware(openapi.Spec, logger, true)
+	require.NoError(t, err)
+
+	service := &taskService{tasks: make(map[uuid.UUID]*domain.Task)}
+	handler := httpserver.NewHandler(logger, service, nil, false, "")
+	gin.SetMode(gin.TestMode)
+
+	return httpserver.New(handler, logger, limiter, passThrough, passThrough, validate, tenants), service
+}
+
+// serve sends the request as a new school, so the rate limit of one test doesn't leak into another.
+func serve(router http.Handler, method, target, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("X-Tenant-ID", uuid.NewString())
+	req.Header.Set("X-Gateway-Secret", gatewaySecret)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestRoutesAreInSpec(t *testing.T) {
+	router, _ := newRouter(t)
+	doc, err := openapi3.NewLoader().LoadFromData(openapi.Spec)
+	require.NoError(t, err)
+
+	for _, route := range router.Routes() {
+		if !strings.HasPrefix(route.Path, "/api/") {
+			continue
+		}
+
+		path := route.Path
+		for _, segment := range strings.Split(route.Path, "/") {
+			if strings.HasPrefix(segment, ":") {
+				path = strings.Replace(path, segment, "{"+segment[1:]+"}", 1)
+			}
+		}
+		item := doc.Paths.Find(path)
+		if assert.NotNil(t, item, "%s %s", route.Method, path) {
+			assert.NotNil(t, item.GetOperation(route.Method), "%s %s", route.Method, path)
+		}
+	}
+}
+
+func TestTenantPolicy(t *testing.T) {
+	router, _ := newTenantRouter(t, httpserver.TenantPolicy{Require: true, GatewaySecret: gatewaySecret})
+	tenantID := uuid.NewString()
+
+	tests := []struct {
+		name    string
+		target  string
+		headers map[string]string
+		status  int
+	}{
+		{
+			name:    "through the gateway",
+			target:  "/api/v2/tasks",
+			headers: map[string]string{"X-Tenant-ID": tenantID, "X-Gateway-Secret": gatewaySecret},
+			status:  http.StatusOK,
+		},
+		{
+			name:    "missing tenant",
+			target:  "/api/v2/tasks",
+			headers: map[string]string{"X-Gateway-Secret": gatewaySecret},
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:    "tenant in the query",
+			target:  "/api/v2/tasks?tenant=" + tenantID,
+			headers: map[string]string{"X-Gateway-Secret": gatewaySecret},
+			status:  http.StatusBadRequest,
+		},
+		{
+			name:    "bypassing the gateway",
+			target:  "/api/v2/tasks",
+			headers: map[string]string{"X-Tenant-ID": tenantID},
+			status:  http.StatusUnauthorized,
+		},
+		{
+			name:    "wrong gateway secret",
+			target:  "/api/v2/tasks",
+			headers: map[string]string{"X-Tenant-ID": tenantID, "X-Gateway-Secret": "client"},
+			status:  http.StatusUnauthorized,
+		},
+		{
+			// The feeds are disabled in the tests, so 404 means the request got past the tenant.
+			name:   "calendar feed",
+			target: "/api/v1/class/9A/calendar.ics?token=token&tenant=" + tenantID,
+			status: http.StatusNotFound,
+		},
+		{
+			name:   "calendar feed without tenant",
+			target: "/api/v1/class/9A/calendar.ics?token=token",
+			status: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.target, nil)
+			for name, value := range tt.headers {
+				req.Header.Set(name, value)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestRateLimitPerUser(t *testing.T) {
+	router, _ := newRouter(t)
+	tenantID := uuid.NewString()
+
+	get := func(userID string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v2/tasks", nil)
+		req.Header.Set("X-Tenant-ID", tenantID)
+		req.Header.Set("X-User-ID", userID)
+		req.Header.Set("X-Gateway-Secret", gatewaySecret)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		return rec.Code
+	}
+
+	teacher := uuid.NewString()
+	for range 10 {
+		require.Equal(t, http.StatusOK, get(teacher))
+	}
+	assert.Equal(t, http.StatusTooManyRequests, get(teacher))
+	assert.Equal(t, http.StatusOK, get(uuid.NewString()), "another user of the school keeps their budget")
+}
+
+func TestRequestValidation(t *testing.T) {
+	router, _ := newRouter(t)
+
+	tests := []struct {
+		name        string
+		method      string
+		target      string
+		contentType string
+		body        string
+		status      int
+		errors      []problem.FieldError
+	}{
+		{
+			name:        "missing field",
+			method:      http.MethodPost,
+			target:      "/api/v2/tasks",
+			contentType: "application/json",
+			body:        `{"category": "алгебра"}`,
+			status:      http.StatusUnprocessableEntity,
+			errors:      []problem.FieldError{{Field: "payload", Message: "is required"}},
+		},
+		{
+			name:        "nested fields",
+			method:      http.MethodPost,
+			target:      "/api/v1/task/assignment",
+			contentType: "application/json",
+			body:        `{"template_task_id": "` + uuid.NewString() + `", "assign_to": [{"class": "5A", "lesson_id": "1"}]}`,
+			status:      http.StatusUnprocessableEntity,
+			errors:      []problem.FieldError{{Field: "assign_to[0].lesson_id", Message: "must be a UUID"}},
+		},
+		{
+			name:   "path parameter",
+			method: http.MethodGet,
+			target: "/api/v2/tasks/1",
+			status: http.StatusUnprocessableEntity,
+			errors: []problem.FieldError{{Field: "id", Message: "must be a UUID"}},
+		},
+		{
+			name:        "unknown patch field",
+			method:      http.MethodPatch,
+			target:      "/api/v2/tasks/" + uuid.NewString(),
+			contentType: "application/merge-patch+json",
+			body:        `{"title": "x"}`,
+			status:      http.StatusUnprocessableEntity,
+			errors:      []problem.FieldError{{Field: "title", Message: "is not supported"}},
+		},
+		{
+			name:        "unsupported media type",
+			method:      http.MethodPatch,
+			target:      "/api/v2/tasks/" + uuid.NewString(),
+			contentType: "text/plain",
+			body:        `payload`,
+			status:      http.StatusUnsupportedMediaType,
+		},
+		{
+			name:        "malformed body",
+			method:      http.MethodPost,
+			target:      "/api/v2/tasks",
+			contentType: "application/json",
+			body:        `{"payload": `,
+			status:      http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(router, tt.method, tt.target, tt.contentType, tt.body)
+
+			require.Equal(t, tt.status, rec.Code, rec.Body.String())
+			assert.Equal(t, problem.ContentType, rec.Header().Get("Content-Type"))
+			var details problem.Details
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &details))
+			assert.Equal(t, tt.errors, details.Errors)
+		})
+	}
+}
+
+func TestClient(t *testing.T) {
+	router, _ := newRouter(t)
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	client, err := taskclient.NewClientWithResponses(server.URL, taskclient.WithTenant(uuid.New()),
+		taskclient.WithGatewaySecret(gatewaySecret))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	deadline := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+	created, err := client.CreateTaskWithResponse(ctx, &taskclient.CreateTaskParams{}, taskclient.TaskInput{
+		Payload:  "Решить задачи 1-5",
+		Deadline: &deadline,
+	})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, created.StatusCode(), string(created.Body))
+	require.NotNil(t, created.JSON201)
+	assert.Equal(t, "/api/v2/tasks/"+created.JSON201.Id.String(), created.HTTPResponse.Header.Get("Location"))
+
+	task, err := client.GetTaskWithResponse(ctx, created.JSON201.Id, &taskclient.GetTaskParams{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, task.StatusCode(), string(task.Body))
+	assert.Equal(t, "Решить задачи 1-5", task.JSON200.Payload)
+	assert.True(t, deadline.Equal(*task.JSON200.Deadline))
+
+	missing, err := client.GetTaskWithResponse(ctx, uuid.New(), &taskclient.GetTaskParams{})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, missing.StatusCode())
+	require.NotNil(t, missing.ApplicationproblemJSON404)
+	assert.Equal(t, http.StatusNotFound, missing.ApplicationproblemJSON404.Status)
+
+	tasks, err := client.ListTasksWithResponse(ctx)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, tasks.StatusCode(), string(tasks.Body))
+	assert.Len(t, tasks.JSON200.Tasks, 1)
+}
@@ -2,26 +2,45 @@ package httpserver
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
+	"task/api/openapi"
 	"task/internal/config"
+	"task/pkg/health"
+	"task/pkg/idempotency"
+	openapivalidator "task/pkg/openapi-validator"
 	"time"
 
+	"github.com/gin-gonic/gin"
 	"github.com/go-redis/redis_rate/v9"
 )
 
 type Server struct {
 	server          *http.Server
 	logger          *slog.Logger
+	health          *health.Checker
+	drainDelay      time.Duration
 	shutDownTimeout time.Duration
 }
 
-func NewHTTPServer(config *config.ServerConfig, logger *slog.Logger, taskService TaskService, limiter *redis_rate.Limiter) (*Server, error) {
-	httpHandler := NewHandler(logger, taskService)
+func NewHTTPServer(config *config.ServerConfig, logger *slog.Logger, taskService TaskService, limiter *redis_rate.Limiter,
+	idempotencyStore idempotency.Store, checker *health.Checker, graphQL gin.HandlerFunc) (*Server, error) {
+	if config.GatewaySecret == "" {
+		return nil, errors.New("the gateway secret is not set, so nothing could tell the gateway's requests from others")
+	}
+
+	httpHandler := NewHandler(logger, taskService, checker, config.RequireIfMatch, config.CalendarSecret)
+	idempotent := idempotency.Middleware(idempotencyStore, config.IdempotencyTTL, logger)
+	validate, err := openapivalidator.Middleware(openapi.Spec, logger, config.ValidateResponses)
+	if err != nil {
+		return nil, err
+	}
+	tenants := TenantPolicy{Require: config.RequireTenant, GatewaySecret: config.GatewaySecret}
 	server := &http.Server{
 		Addr:         ":" + config.Port,
-		Handler:      New(httpHandler, logger, limiter),
+		Handler:      New(httpHandler, logger, limiter, idempotent, graphQL, validate, tenants),
 		ReadTimeout:  config.ReadTimeout,
 		WriteTimeout: config.WriteTimeout,
 	}
@@ -29,6 +48,8 @@ func NewHTTPServer(config *config.ServerConfig, logger *slog.Logger, taskService
 	return &Server{
 		server:          server,
 		logger:          logger,
+		health:          checker,
+		drainDelay:      config.DrainDelay,
 		shutDownTimeout: config.ShutdownTimeout,
 	}, nil
 }
@@ -51,7 +72,15 @@ func (s *Server) Run(ctx context.Context) error {
 	return err
 }
 
+// Stop fails the readiness probe first and keeps serving for the drain delay,
+// so load balancers move the traffic away before the server stops accepting it.
 func (s *Server) Stop() {
+	s.health.Drain()
+	if s.drainDelay > 0 {
+		s.logger.Info("draining before shutdown", slog.Duration("delay", s.drainDelay))
+		time.Sleep(s.drainDelay)
+	}
+
 	ctx, cancel := context.WithTimeout(context.Background(), s.shutDownTimeout)
 	defer cancel()
 	err := s.server.Shutdown(ctx)
@@ -0,0 +1,292 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func validateBulk(mode domain.BulkMode, n int) error {
+	if !mode.IsValid() {
+		return domain.ErrInvalidBulkRequest.WithField("mode", fmt.Sprintf("unknown mode %q", mode))
+	}
+
+	if n == 0 || n > domain.MaxBulkItems {
+		return domain.ErrInvalidBulkRequest.WithField("items", fmt.Sprintf("expected 1 to %d items, got %d", domain.MaxBulkItems, n))
+	}
+
+	return nil
+}
+
+// CreateTasksBulk creates many task templates at once. Invalid items fail on their own in best effort mode
+// and fail the whole request in atomic mode.
+func (u *TaskService) CreateTasksBulk(ctx context.Context, mode domain.BulkMode, tasks []*domain.Task) (*domain.BulkResult, error) {
+	if err := validateBulk(mode, len(tasks)); err != nil {
+		return nil, err
+	}
+
+	authorID, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result := domain.NewBulkResult(mode, len(tasks))
+	valid := make([]*domain.Task, 0, len(tasks))
+	for i, task := range tasks {
+		task.AuthorID = authorID
+		result.Items[i].ID = task.ID
+		if task.Payload == "" {
+			result.Fail(i, domain.ErrInvalidBulkItem.WithField("payload", "is required"))
+			continue
+		}
+		valid = append(valid, task)
+	}
+
+	if len(valid) > 0 && !(mode == domain.BulkModeAtomic && result.Failed()) {
+		err := u.db.CreateTasks(ctx, valid)
+		if err != nil {
+			return nil, fmt.Errorf("failed create tasks: %w", err)
+		}
+	}
+
+	result.Finish()
+
+	return result, nil
+}
+
+// AssignTasksBulk assigns many templates to many class and lesson pairs at once
+// and notifies the classes about the published assignments in one batch.
+// Items whose template the caller may not use or whose lesson isn't in the class fail without reaching the database.
+func (u *TaskService) AssignTasksBulk(ctx context.Context, bulk *domain.BulkAssignments) (*domain.BulkResult, error) {
+	if err := validateBulk(bulk.Mode, len(bulk.Items)); err != nil {
+		return nil, err
+	}
+
+	if _, err := requireCaller(ctx); err != nil {
+		return nil, err
+	}
+
+	bulk.Status = domain.NewAssignmentStatus(bulk.Draft, bulk.PublishAt, time.Now())
+
+	taskIDs := make([]uuid.UUID, 0, len(bulk.Items))
+	pairs := make([]domain.ClassLesson, 0, len(bulk.Items))
+	for i := range bulk.Items {
+		item := &bulk.Items[i]
+		item.Class = domain.NormalizeClassName(item.Class)
+		taskIDs = append(taskIDs, item.TaskID)
+		pairs = append(pairs, domain.ClassLesson{Class: item.Class, LessonID: item.LessonID})
+	}
+
+	access, err := u.db.GetTaskAccess(ctx, taskIDs, callerID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed get task access: %w", err)
+	}
+
+	referenceErrs, err := u.db.ValidateClassLessons(ctx, pairs)
+	if err != nil {
+		return nil, fmt.Errorf("failed validate classes and lessons: %w", err)
+	}
+
+	result := domain.NewBulkResult(bulk.Mode, len(bulk.Items))
+	allowed := *bulk
+	allowed.Items = make([]domain.BulkAssignment, 0, len(bulk.Items))
+	indexes := make([]int, 0, len(bulk.Items))
+	for i, item := range bulk.Items {
+		taskAccess, ok := access[item.TaskID]
+		if !ok {
+			result.Fail(i, domain.ErrTaskNotFound)
+			continue
+		}
+		if err := taskAccess.Permission(callerID(ctx)).Check(domain.PermissionUse); err != nil {
+			result.Fail(i, err)
+			continue
+		}
+		if referenceErrs[i] != nil {
+			result.Fail(i, referenceErrs[i])
+			continue
+		}
+		allowed.Items = append(allowed.Items, item)
+		indexes = append(indexes, i)
+	}
+
+	if len(allowed.Items) == 0 || (bulk.Mode == domain.BulkModeAtomic && result.Failed()) {
+		result.Finish()
+		return result, nil
+	}
+
+	assignments, itemErrs, err := u.db.CreateAssignmentsBulk(ctx, &allowed)
+	if err != nil {
+		return nil, fmt.Errorf("failed assign tasks: %w", err)
+	}
+
+	for j, itemErr := range itemErrs {
+		i := indexes[j]
+		result.Items[i].ID = assignments[j].AssignmentID
+		if itemErr != nil {
+			result.Fail(i, itemErr)
+		}
+	}
+	result.Finish()
+
+	if bulk.Status == domain.AssignmentStatusPublished {
+		var published []domain.Assignment
+		for j, i := range indexes {
+			if result.Items[i].Status == domain.BulkItemDone {
+				published = append(published, assignments[j])
+			}
+		}
+
+		events := make([]domain.Event, 0, len(published))
+		for _, event := range domain.NewTaskAssignedToUserEvent(published) {
+			events = append(events, event)
+		}
+		u.produceBatch(ctx, events)
+	}
+
+	return result, nil
+}
+
+// DeleteAssignmentsBulk deletes many assignments at once. Items whose template the caller may not use
+// fail without reaching the database.
+func (u *TaskService) DeleteAssignmentsBulk(ctx context.Context, mode domain.BulkMode, assignmentIDs []uuid.UUID) (*domain.BulkResult, error) {
+	if err := validateBulk(mode, len(assignmentIDs)); err != nil {
+		return nil, err
+	}
+
+	result := domain.NewBulkResult(mode, len(assignmentIDs))
+	for i, id := range assignmentIDs {
+		result.Items[i].ID = id
+	}
+
+	indexes, err := u.authorizeAssignments(ctx, result, assignmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(indexes) == 0 || (mode == domain.BulkModeAtomic && result.Failed()) {
+		result.Finish()
+		return result, nil
+	}
+
+	allowed := make([]uuid.UUID, 0, len(indexes))
+	for _, i := range indexes {
+		allowed = append(allowed, assignmentIDs[i])
+	}
+
+	itemErrs, err := u.db.DeleteAssignments(ctx, mode, allowed, callerID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed delete assignments: %w", err)
+	}
+
+	for j, itemErr := range itemErrs {
+		if itemErr != nil {
+			result.Fail(indexes[j], itemErr)
+		}
+	}
+	result.Finish()
+
+	return result, nil
+}
+
+// UpdateDeadlinesBulk moves many assignment deadlines at once and emits AssignmentChanged for each of them in one batch.
+// Items whose template the caller may not use fail without reaching the database.
+func (u *TaskService) UpdateDeadlinesBulk(ctx context.Context, mode domain.BulkMode, updates []domain.DeadlineUpdate) (*domain.BulkResult, error) {
+	if err := validateBulk(mode, len(updates)); err != nil {
+		return nil, err
+	}
+
+	result := domain.NewBulkResult(mode, len(updates))
+	assignmentIDs := make([]uuid.UUID, 0, len(updates))
+	for i, update := range updates {
+		result.Items[i].ID = update.AssignmentID
+		assignmentIDs = append(assignmentIDs, update.AssignmentID)
+	}
+
+	indexes, err := u.authorizeAssignments(ctx, result, assignmentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(indexes) == 0 || (mode == domain.BulkModeAtomic && result.Failed()) {
+		result.Finish()
+		return result, nil
+	}
+
+	allowed := make([]domain.DeadlineUpdate, 0, len(indexes))
+	for _, i := range indexes {
+		allowed = append(allowed, updates[i])
+	}
+
+	assignments, itemErrs, err := u.db.UpdateDeadlines(ctx, mode, allowed)
+	if err != nil {
+		return nil, fmt.Errorf("failed update deadlines: %w", err)
+	}
+
+	for j, itemErr := range itemErrs {
+		if itemErr != nil {
+			result.Fail(indexes[j], itemErr)
+		}
+	}
+	result.Finish()
+
+	var events []domain.Event
+	for j, i := range indexes {
+		if result.Items[i].Status == domain.BulkItemDone {
+			events = append(events, domain.NewAssignmentChangedEvent(&assignments[j], []string{"deadline"}))
+		}
+	}
+	u.produceBatch(ctx, events)
+
+	return result, nil
+}
+
+// authorizeAssignments fails the items of result whose template the caller may not use, like usableAssignment,
+// and returns the indexes of the others.
+func (u *TaskService) authorizeAssignments(ctx context.Context, result *domain.BulkResult, assignmentIDs []uuid.UUID) ([]int, error) {
+	caller, err := requireCaller(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	access, err := u.db.GetAssignmentAccess(ctx, assignmentIDs, caller)
+	if err != nil {
+		return nil, fmt.Errorf("failed get assignment access: %w", err)
+	}
+
+	indexes := make([]int, 0, len(assignmentIDs))
+	for i, id := range assignmentIDs {
+		assignmentAccess, ok := access[id]
+		if !ok {
+			result.Fail(i, domain.ErrAssignmentNotFound)
+			continue
+		}
+		if err := assignmentAccess.Permission(caller).Check(domain.PermissionUse); err != nil {
+			result.Fail(i, err)
+			continue
+		}
+		indexes = append(indexes, i)
+	}
+
+	return indexes, nil
+}
+
+// produceBatch stamps the events with the school of ctx and sends them together.
+func (u *TaskService) produceBatch(ctx context.Context, events []domain.Event) {
+	if len(events) == 0 {
+		return
+	}
+
+	tenantID := domain.TenantFromContext(ctx)
+	for _, event := range events {
+		event.SetTenant(tenantID)
+	}
+
+	err := u.producer.ProduceBatch(ctx, events)
+	if err != nil {
+		u.log(ctx).Error("failed to send events:", slog.String("error", err.Error()))
+	}
+}
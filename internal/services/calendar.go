@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// GetStudentTasks returns the published tasks of the class with the deadlines the student actually has,
+// that is with the student's deadline extensions applied.
+func (u *TaskService) GetStudentTasks(ctx context.Context, class string, studentID uuid.UUID) ([]*domain.LessonTask, error) {
+	tasks, err := u.GetTaskByClass(ctx, class)
+	if err != nil {
+		return nil, err
+	}
+
+	extensions, err := u.db.GetStudentExtensions(ctx, domain.NormalizeClassName(class), studentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed get deadline extensions: %w", err)
+	}
+
+	for _, task := range tasks {
+		if extension, ok := extensions[task.TaskID]; ok {
+			task.Deadline = &extension
+		}
+	}
+
+	return tasks, nil
+}
+
+// AuthorizeCalendarLink checks that the caller may subscribe to the calendar of the class or, when studentID is set,
+// of the student in it. The teachers of the class get both, a student only their own.
+func (u *TaskService) AuthorizeCalendarLink(ctx context.Context, class string, studentID *uuid.UUID) error {
+	caller := callerID(ctx)
+	if caller == nil {
+		return domain.ErrCalendarForbidden
+	}
+
+	if studentID != nil && *studentID == *caller {
+		return nil
+	}
+
+	teaches, err := u.db.TeachesClass(ctx, domain.NormalizeClassName(class), *caller)
+	if err != nil {
+		return fmt.Errorf("failed check class teacher: %w", err)
+	}
+
+	if !teaches {
+		return domain.ErrCalendarForbidden
+	}
+
+	return nil
+}
@@ -0,0 +1,96 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+func (u *TaskService) CreateClass(ctx context.Context, class *domain.Class) (uuid.UUID, error) {
+	class.Name = domain.NormalizeClassName(class.Name)
+	if class.Name == "" {
+		return uuid.Nil, domain.ErrInvalidClass.WithField("name", "can't be empty")
+	}
+
+	if class.ID == uuid.Nil {
+		class.ID = uuid.New()
+	}
+
+	err := u.db.CreateClass(ctx, class)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed create class: %w", err)
+	}
+
+	return class.ID, nil
+}
+
+func (u *TaskService) GetClasses(ctx context.Context) ([]domain.Class, error) {
+	classes, err := u.db.GetClasses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed get classes: %w", err)
+	}
+
+	return classes, nil
+}
+
+// CreateLesson adds a lesson to the class. The ID is kept when given, so lessons of the schedule service keep theirs.
+func (u *TaskService) CreateLesson(ctx context.Context, lesson *domain.Lesson) (uuid.UUID, error) {
+	lesson.Class = domain.NormalizeClassName(lesson.Class)
+	if lesson.ID == uuid.Nil {
+		lesson.ID = uuid.New()
+	}
+
+	err := u.db.CreateLesson(ctx, lesson)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed create lesson: %w", err)
+	}
+
+	return lesson.ID, nil
+}
+
+func (u *TaskService) GetLessons(ctx context.Context, class string) ([]domain.Lesson, error) {
+	lessons, err := u.db.GetLessons(ctx, domain.NormalizeClassName(class))
+	if err != nil {
+		return nil, fmt.Errorf("failed get lessons of class %s: %w", class, err)
+	}
+
+	return lessons, nil
+}
+
+// GetLessonsByClasses returns the lessons of every class in one query, keyed by the normalized class name.
+func (u *TaskService) GetLessonsByClasses(ctx context.Context, classes []string) (map[string][]domain.Lesson, error) {
+	normalized := make([]string, 0, len(classes))
+	for _, class := range classes {
+		normalized = append(normalized, domain.NormalizeClassName(class))
+	}
+
+	lessons, err := u.db.GetLessonsByClasses(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed get lessons of classes: %w", err)
+	}
+
+	byClass := make(map[string][]domain.Lesson, len(classes))
+	for _, lesson := range lessons {
+		byClass[lesson.Class] = append(byClass[lesson.Class], lesson)
+	}
+
+	return byClass, nil
+}
+
+// checkClassLessons returns the first invalid class or lesson reference of the pairs.
+func (u *TaskService) checkClassLessons(ctx context.Context, pairs ...domain.ClassLesson) error {
+	errs, err := u.db.ValidateClassLessons(ctx, pairs)
+	if err != nil {
+		return fmt.Errorf("failed validate classes and lessons: %w", err)
+	}
+
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,54 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// ExportTasks passes the templates the caller may see to fn without loading them into memory. The task is reused between calls.
+func (u *TaskService) ExportTasks(ctx context.Context, fn func(*domain.Task) error) error {
+	err := u.db.StreamTasks(ctx, callerID(ctx), fn)
+	if err != nil {
+		return fmt.Errorf("failed export tasks: %w", err)
+	}
+
+	return nil
+}
+
+// ExportGradebook passes the marks of the class to fn without loading them into memory. The entry is reused between calls.
+func (u *TaskService) ExportGradebook(ctx context.Context, class string, fn func(*domain.GradebookEntry) error) error {
+	err := u.db.StreamGradebook(ctx, domain.NormalizeClassName(class), fn)
+	if err != nil {
+		return fmt.Errorf("failed export gradebook of class %s: %w", class, err)
+	}
+
+	return nil
+}
+
+// GetMarksByAssignments returns the marks of every assignment in one query, keyed by the assignment.
+func (u *TaskService) GetMarksByAssignments(ctx context.Context, assignmentIDs []uuid.UUID) (map[uuid.UUID][]domain.GradebookEntry, error) {
+	marks, err := u.db.GetMarksByAssignments(ctx, assignmentIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed get marks of assignments: %w", err)
+	}
+
+	byAssignment := make(map[uuid.UUID][]domain.GradebookEntry, len(assignmentIDs))
+	for _, mark := range marks {
+		byAssignment[mark.TaskID] = append(byAssignment[mark.TaskID], mark)
+	}
+
+	return byAssignment, nil
+}
+
+// ExportStudentMarks passes the marks of the student to fn without loading them into memory. The entry is reused between calls.
+func (u *TaskService) ExportStudentMarks(ctx context.Context, studentID uuid.UUID, fn func(*domain.GradebookEntry) error) error {
+	err := u.db.StreamStudentMarks(ctx, studentID, fn)
+	if err != nil {
+		return fmt.Errorf("failed export marks of student %s: %w", studentID, err)
+	}
+
+	return nil
+}
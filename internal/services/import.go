@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// ImportTasks creates task templates from spreadsheet rows. Nothing is written when a row is invalid
+// or in dry run mode. Every template goes through CreateTask, so it is cached the same way.
+func (u *TaskService) ImportTasks(ctx context.Context, table [][]string, dryRun bool) (*domain.ImportReport, error) {
+	if _, err := requireCaller(ctx); err != nil {
+		return nil, err
+	}
+
+	rows, errs := domain.ParseTaskImport(table)
+	report := &domain.ImportReport{DryRun: dryRun, Rows: len(rows), Errors: errs}
+	if dryRun || len(errs) > 0 {
+		return report, nil
+	}
+
+	for _, row := range rows {
+		_, err := u.CreateTask(ctx, &row.Task)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			report.Errors = append(report.Errors, domain.ImportRowError{Row: row.Row, Err: err})
+			continue
+		}
+		report.Imported++
+	}
+
+	return report, nil
+}
+
+type markGroup struct {
+	taskID   uuid.UUID
+	lessonID uuid.UUID
+}
+
+// ImportMarks sets marks from spreadsheet rows. Rows of the same task and lesson are submitted together
+// through SetTaskResultsByUsers, so late policies apply and StudentsGotMark is emitted once per group.
+// Nothing is written when a row is invalid. A dry run applies the late policies and writes the marks
+// in a transaction that is rolled back, so rows referencing a missing assignment fail as they would for real.
+func (u *TaskService) ImportMarks(ctx context.Context, table [][]string, dryRun bool) (*domain.ImportReport, error) {
+	rows, errs := domain.ParseMarkImport(table)
+	report := &domain.ImportReport{DryRun: dryRun, Rows: len(rows), Errors: errs}
+	if len(errs) > 0 {
+		return report, nil
+	}
+
+	var order []markGroup
+	groups := make(map[markGroup][]domain.MarkImportRow)
+	for _, row := range rows {
+		group := markGroup{taskID: row.TaskID, lessonID: row.LessonID}
+		if _, ok := groups[group]; !ok {
+			order = append(order, group)
+		}
+		groups[group] = append(groups[group], row)
+	}
+
+	var (
+		checked     []*domain.TaskResult
+		checkedRows [][]domain.MarkImportRow
+	)
+	for _, group := range order {
+		groupRows := groups[group]
+		results := &domain.TaskResult{
+			TaskID:      group.taskID,
+			LessonID:    group.lessonID,
+			UsersResult: make([]domain.UserResult, 0, len(groupRows)),
+		}
+		for _, row := range groupRows {
+			results.UsersResult = append(results.UsersResult, row.Result)
+		}
+
+		var err error
+		if dryRun {
+			err = u.applyLatePolicy(ctx, results)
+		} else {
+			err = u.SetTaskResultsByUsers(ctx, results)
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			report.Errors = appendMarkErrors(report.Errors, groupRows, err)
+			continue
+		}
+
+		if dryRun {
+			checked = append(checked, results)
+			checkedRows = append(checkedRows, groupRows)
+			continue
+		}
+		report.Imported += len(groupRows)
+	}
+
+	if len(checked) > 0 {
+		checkErrs, err := u.db.CheckTaskResults(ctx, checked)
+		if err != nil {
+			return nil, fmt.Errorf("failed check marks: %w", err)
+		}
+		for i, err := range checkErrs {
+			if err != nil {
+				report.Errors = appendMarkErrors(report.Errors, checkedRows[i], err)
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func appendMarkErrors(errs []domain.ImportRowError, rows []domain.MarkImportRow, err error) []domain.ImportRowError {
+	for _, row := range rows {
+		errs = append(errs, domain.ImportRowError{Row: row.Row, Err: err})
+	}
+
+	return errs
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"slices"
+	"task/internal/domain"
+	"time"
+)
+
+// PatchTask applies a merge patch to the task. The task is written back only if it still has
+// the version the patch was applied to, so a concurrent update is never silently overwritten.
+func (u *TaskService) PatchTask(ctx context.Context, patch *domain.TaskPatch) (*domain.Task, error) {
+	task, err := u.db.GetTaskByID(ctx, patch.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed get task: %w", err)
+	}
+
+	if err := u.authorizeTask(ctx, task, domain.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	if patch.Version != 0 && patch.Version != task.Version {
+		return nil, domain.ErrVersionMismatch
+	}
+
+	fields, err := patch.Apply(task)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return task, nil
+	}
+
+	err = u.db.UpdateTask(ctx, task)
+	if err != nil {
+		return nil, fmt.Errorf("failed update task: %w", err)
+	}
+
+	rtask, err := json.Marshal(*task)
+	if err != nil {
+		u.log(ctx).Error("serialize task", slog.String("message", err.Error()))
+	}
+
+	err = u.cache.Set(ctx, cacheKey(ctx, task.ID), rtask, time.Hour)
+	if err != nil {
+		u.log(ctx).Error("redis insertion error", slog.String("message", err.Error()))
+	}
+
+	u.produce(ctx, domain.NewTaskChangedEvent(task, fields))
+
+	return task, nil
+}
+
+// PatchAssignment applies a merge patch to the assignment the same way PatchTask does.
+func (u *TaskService) PatchAssignment(ctx context.Context, patch *domain.AssignmentPatch) (*domain.TaskAsignment, error) {
+	assignment, err := u.usableAssignment(ctx, patch.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if patch.Version != 0 && patch.Version != assignment.Version {
+		return nil, domain.ErrVersionMismatch
+	}
+
+	fields, err := patch.Apply(assignment)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		return assignment, nil
+	}
+
+	if slices.Contains(fields, "class") {
+		assignment.Class = domain.NormalizeClassName(assignment.Class)
+		if err := u.checkClassLessons(ctx, domain.ClassLesson{Class: assignment.Class, LessonID: assignment.LessonID}); err != nil {
+			return nil, err
+		}
+	}
+
+	err = u.db.PatchAssignment(ctx, assignment)
+	if err != nil {
+		return nil, fmt.Errorf("failed update assignment: %w", err)
+	}
+
+	u.produce(ctx, domain.NewAssignmentChangedEvent(assignment, fields))
+
+	return assignment, nil
+}
@@ -0,0 +1,113 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+func (u *TaskService) CreateRecurrence(ctx context.Context, recurrence *domain.Recurrence) (uuid.UUID, error) {
+	if recurrence.Timezone == "" {
+		recurrence.Timezone = time.UTC.String()
+	}
+
+	location, err := time.LoadLocation(recurrence.Timezone)
+	if err != nil {
+		return uuid.Nil, domain.ErrInvalidRecurrence.WithField("timezone", fmt.Sprintf("unknown timezone %q", recurrence.Timezone))
+	}
+	recurrence.StartsAt = recurrence.StartsAt.In(location)
+
+	if recurrence.DeadlineOffset != nil && *recurrence.DeadlineOffset < 0 {
+		return uuid.Nil, domain.ErrInvalidRecurrence.WithField("deadline_offset", "can't be negative")
+	}
+
+	if err := u.authorize(ctx, recurrence.TaskID, domain.PermissionUse); err != nil {
+		return uuid.Nil, err
+	}
+
+	recurrence.Class = domain.NormalizeClassName(recurrence.Class)
+	if err := u.checkClassLessons(ctx, domain.ClassLesson{Class: recurrence.Class, LessonID: recurrence.LessonID}); err != nil {
+		return uuid.Nil, err
+	}
+
+	err = u.db.CreateRecurrence(ctx, recurrence)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed create recurrence: %w", err)
+	}
+
+	return recurrence.ID, nil
+}
+
+// StopRecurrence stops the series; only those who may edit its template do.
+func (u *TaskService) StopRecurrence(ctx context.Context, id uuid.UUID) error {
+	if _, err := u.editableRecurrence(ctx, id); err != nil {
+		return err
+	}
+
+	err := u.db.StopRecurrence(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed stop recurrence: %w", err)
+	}
+
+	return nil
+}
+
+// SetOccurrenceException skips or edits one occurrence of a recurrence, whether it is materialized yet or not.
+// Only those who may edit the template of the recurrence do.
+func (u *TaskService) SetOccurrenceException(ctx context.Context, exception *domain.OccurrenceException) error {
+	recurrence, err := u.editableRecurrence(ctx, exception.RecurrenceID)
+	if err != nil {
+		return err
+	}
+
+	occurrenceAt := exception.OccurrenceAt.In(recurrence.StartsAt.Location())
+	if !recurrence.Rule.IsOccurrence(recurrence.StartsAt, occurrenceAt) {
+		return domain.ErrOccurrenceNotFound
+	}
+
+	err = u.db.SetOccurrenceException(ctx, exception)
+	if err != nil {
+		return fmt.Errorf("failed set occurrence exception: %w", err)
+	}
+
+	return nil
+}
+
+// editableRecurrence returns the recurrence if the caller may edit its template.
+func (u *TaskService) editableRecurrence(ctx context.Context, id uuid.UUID) (*domain.Recurrence, error) {
+	recurrence, err := u.db.GetRecurrence(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed get recurrence: %w", err)
+	}
+
+	if err := u.authorize(ctx, recurrence.TaskID, domain.PermissionEdit); err != nil {
+		return nil, err
+	}
+
+	return recurrence, nil
+}
+
+// MaterializeRecurrences creates the assignments of all recurrences for the coming horizon.
+// Occurrences that are due already are announced right away, the rest by the publisher.
+func (u *TaskService) MaterializeRecurrences(ctx context.Context, horizon time.Duration) (int, error) {
+	now := time.Now()
+
+	assignments, err := u.db.MaterializeRecurrences(ctx, now, now.Add(horizon))
+	if err != nil {
+		return 0, fmt.Errorf("failed materialize recurrences: %w", err)
+	}
+
+	published := make([]domain.Assignment, 0, len(assignments))
+	for _, assignment := range assignments {
+		if assignment.Status == domain.AssignmentStatusPublished {
+			published = append(published, assignment)
+		}
+	}
+
+	u.produceTaskAssignedByTenant(ctx, published)
+
+	return len(assignments), nil
+}
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"task/internal/domain"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -10,13 +11,56 @@ import (
 type Database interface {
 	CreateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error)
 	GetTaskByID(ctx context.Context, id uuid.UUID) (*domain.Task, error)
-	GetTasks(ctx context.Context) ([]*domain.Task, error)
+	GetTasks(ctx context.Context, userID *uuid.UUID) ([]*domain.Task, error)
+	GetTasksByIDs(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID) ([]*domain.Task, error)
 	UpdateTask(ctx context.Context, task *domain.Task) error
-	DeleteTask(ctx context.Context, id uuid.UUID) error
+	DeleteTask(ctx context.Context, id uuid.UUID, version int, deletedBy *uuid.UUID) error
 	CreateAssignments(ctx context.Context, taskAssignments *domain.TaskAsignments) (assignments []domain.Assignment, err error)
 	GetTaskByClass(ctx context.Context, class string) ([]*domain.LessonTask, error)
+	GetTasksByClasses(ctx context.Context, classes []string) (map[string][]*domain.LessonTask, error)
 	SetTaskResultsByUsers(ctx context.Context, taskResults *domain.TaskResult) error
-	DeleteAssignment(ctx context.Context, assignmentID uuid.UUID) error
+	CheckTaskResults(ctx context.Context, taskResults []*domain.TaskResult) ([]error, error)
+	DeleteAssignment(ctx context.Context, assignmentID uuid.UUID, version int, deletedBy *uuid.UUID) error
 	CreateTaskWithAssignments(ctx context.Context, assignment *domain.TaskWithAsignment) (uuid.UUID, error)
 	UpdateAssignment(ctx context.Context, task *domain.TaskAsignment) error
+	SetAssignmentLatePolicy(ctx context.Context, policy *domain.AssignmentLatePolicy) error
+	SetDeadlineExtension(ctx context.Context, extension *domain.DeadlineExtension) error
+	DeleteDeadlineExtension(ctx context.Context, assignmentID, userID uuid.UUID) error
+	GetAssignmentDeadline(ctx context.Context, assignmentID uuid.UUID) (*domain.AssignmentDeadline, error)
+	PublishAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.Assignment, error)
+	PublishDueAssignments(ctx context.Context, now time.Time) ([]domain.Assignment, error)
+	ClaimDeadlineReminders(ctx context.Context, now time.Time, windows []time.Duration, send func([]domain.DeadlineReminder) error) (int, error)
+	CreateRecurrence(ctx context.Context, recurrence *domain.Recurrence) error
+	GetRecurrence(ctx context.Context, id uuid.UUID) (*domain.Recurrence, error)
+	StopRecurrence(ctx context.Context, id uuid.UUID) error
+	SetOccurrenceException(ctx context.Context, exception *domain.OccurrenceException) error
+	MaterializeRecurrences(ctx context.Context, now, until time.Time) ([]domain.Assignment, error)
+	GetTrash(ctx context.Context, userID *uuid.UUID) (*domain.Trash, error)
+	GetDeletedAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.DeletedAssignment, error)
+	RestoreTask(ctx context.Context, id uuid.UUID) error
+	RestoreAssignment(ctx context.Context, assignmentID uuid.UUID) error
+	PurgeTrash(ctx context.Context, before time.Time) (int64, error)
+	GetAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.TaskAsignment, error)
+	PatchAssignment(ctx context.Context, assignment *domain.TaskAsignment) error
+	CreateTasks(ctx context.Context, tasks []*domain.Task) error
+	CreateAssignmentsBulk(ctx context.Context, bulk *domain.BulkAssignments) ([]domain.Assignment, []error, error)
+	DeleteAssignments(ctx context.Context, mode domain.BulkMode, assignmentIDs []uuid.UUID, deletedBy *uuid.UUID) ([]error, error)
+	UpdateDeadlines(ctx context.Context, mode domain.BulkMode, updates []domain.DeadlineUpdate) ([]domain.TaskAsignment, []error, error)
+	StreamTasks(ctx context.Context, userID *uuid.UUID, fn func(*domain.Task) error) error
+	StreamGradebook(ctx context.Context, class string, fn func(*domain.GradebookEntry) error) error
+	StreamStudentMarks(ctx context.Context, studentID uuid.UUID, fn func(*domain.GradebookEntry) error) error
+	GetMarksByAssignments(ctx context.Context, assignmentIDs []uuid.UUID) ([]domain.GradebookEntry, error)
+	GetStudentExtensions(ctx context.Context, class string, studentID uuid.UUID) (map[uuid.UUID]time.Time, error)
+	TeachesClass(ctx context.Context, class string, userID uuid.UUID) (bool, error)
+	GetTaskAccess(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error)
+	GetAssignmentAccess(ctx context.Context, assignmentIDs []uuid.UUID, userID *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error)
+	ShareTask(ctx context.Context, share *domain.TaskShare) error
+	DeleteTaskShare(ctx context.Context, taskID uuid.UUID, userID *uuid.UUID) error
+	GetTaskShares(ctx context.Context, taskID uuid.UUID) ([]domain.TaskShare, error)
+	CreateClass(ctx context.Context, class *domain.Class) error
+	GetClasses(ctx context.Context) ([]domain.Class, error)
+	CreateLesson(ctx context.Context, lesson *domain.Lesson) error
+	GetLessons(ctx context.Context, class string) ([]domain.Lesson, error)
+	GetLessonsByClasses(ctx context.Context, classes []string) ([]domain.Lesson, error)
+	ValidateClassLessons(ctx context.Context, pairs []domain.ClassLesson) ([]error, error)
 }
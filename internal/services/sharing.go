@@ -0,0 +1,149 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+
+	"github.com/google/uuid"
+)
+
+// ShareTask lets a colleague, or the whole school when share.UserID is nil, view, use or edit the template.
+// Only the author shares a template.
+func (u *TaskService) ShareTask(ctx context.Context, share *domain.TaskShare) error {
+	if !share.Permission.IsShareable() {
+		return domain.ErrInvalidPermission.WithField("permission", fmt.Sprintf("%q, expected view, use or edit", share.Permission))
+	}
+
+	if err := u.authorize(ctx, share.TaskID, domain.PermissionOwner); err != nil {
+		return err
+	}
+
+	err := u.db.ShareTask(ctx, share)
+	if err != nil {
+		return fmt.Errorf("failed share task: %w", err)
+	}
+
+	return nil
+}
+
+// UnshareTask revokes what was shared with the user, or with the whole school when userID is nil.
+func (u *TaskService) UnshareTask(ctx context.Context, taskID uuid.UUID, userID *uuid.UUID) error {
+	if err := u.authorize(ctx, taskID, domain.PermissionOwner); err != nil {
+		return err
+	}
+
+	err := u.db.DeleteTaskShare(ctx, taskID, userID)
+	if err != nil {
+		return fmt.Errorf("failed unshare task: %w", err)
+	}
+
+	return nil
+}
+
+func (u *TaskService) GetTaskShares(ctx context.Context, taskID uuid.UUID) ([]domain.TaskShare, error) {
+	if err := u.authorize(ctx, taskID, domain.PermissionOwner); err != nil {
+		return nil, err
+	}
+
+	shares, err := u.db.GetTaskShares(ctx, taskID)
+	if err != nil {
+		return nil, fmt.Errorf("failed get task shares: %w", err)
+	}
+
+	return shares, nil
+}
+
+// CopyTask copies a template the caller may use into their own bank, where they are the author of the copy.
+func (u *TaskService) CopyTask(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	task, err := u.GetTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.authorizeTask(ctx, task, domain.PermissionUse); err != nil {
+		return nil, err
+	}
+
+	cp := &domain.Task{
+		ID:       uuid.New(),
+		Payload:  task.Payload,
+		Deadline: task.Deadline,
+		Category: task.Category,
+	}
+
+	_, err = u.CreateTask(ctx, cp)
+	if err != nil {
+		return nil, err
+	}
+
+	return cp, nil
+}
+
+// authorize returns ErrTaskNotFound when the caller can't see the template
+// and ErrForbidden when they may not do what required allows. Anything but viewing takes a user.
+func (u *TaskService) authorize(ctx context.Context, taskID uuid.UUID, required domain.Permission) error {
+	if required != domain.PermissionView {
+		if _, err := requireCaller(ctx); err != nil {
+			return err
+		}
+	}
+
+	access, err := u.db.GetTaskAccess(ctx, []uuid.UUID{taskID}, callerID(ctx))
+	if err != nil {
+		return fmt.Errorf("failed get task access: %w", err)
+	}
+
+	a, ok := access[taskID]
+	if !ok {
+		return domain.ErrTaskNotFound
+	}
+
+	return a.Permission(callerID(ctx)).Check(required)
+}
+
+// authorizeTask is authorize for a loaded template. Only the permissions shared with the caller
+// take a lookup.
+func (u *TaskService) authorizeTask(ctx context.Context, task *domain.Task, required domain.Permission) error {
+	access := domain.TaskAccess{AuthorID: task.AuthorID}
+	if access.Permission(callerID(ctx)).Includes(required) {
+		return nil
+	}
+
+	return u.authorize(ctx, task.ID, required)
+}
+
+// usableAssignment returns the assignment if the caller may use its template, which is what
+// changing an assignment takes, the same as assigning the template anew.
+func (u *TaskService) usableAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.TaskAsignment, error) {
+	assignment, err := u.db.GetAssignment(ctx, assignmentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed get assignment: %w", err)
+	}
+
+	if err := u.authorize(ctx, assignment.TaskID, domain.PermissionUse); err != nil {
+		return nil, err
+	}
+
+	return assignment, nil
+}
+
+// requireCaller returns the user performing the request or ErrUserRequired, so nothing is written anonymously.
+func requireCaller(ctx context.Context) (*uuid.UUID, error) {
+	caller := callerID(ctx)
+	if caller == nil {
+		return nil, domain.ErrUserRequired
+	}
+
+	return caller, nil
+}
+
+// callerID returns the user performing the request, nil when it is unknown.
+func callerID(ctx context.Context) *uuid.UUID {
+	userID, ok := domain.UserIDFromContext(ctx)
+	if !ok {
+		return nil
+	}
+
+	return &userID
+}
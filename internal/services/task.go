@@ -10,13 +10,18 @@ import (
 	"time"
 
 	"task/pkg/cache"
+	"task/pkg/logging"
+	"task/pkg/metrics"
 
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
 type Producer interface {
-	Produce(event domain.Event) error
+	Produce(ctx context.Context, event domain.Event) error
+	ProduceBatch(ctx context.Context, events []domain.Event) error
+	// DeliverBatch returns only once the broker has acknowledged every event, or the error of the ones it didn't.
+	DeliverBatch(ctx context.Context, events []domain.Event) error
 }
 
 type TaskService struct {
@@ -35,7 +40,13 @@ func New(logger *slog.Logger, db Database, cache cache.Cache, producer Producer)
 	}
 }
 
+// CreateTask creates a template authored by the caller.
 func (u *TaskService) CreateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error) {
+	authorID, err := requireCaller(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	task.AuthorID = authorID
 
 	id, err := u.db.CreateTask(ctx, task)
 	if err != nil {
@@ -45,39 +56,58 @@ func (u *TaskService) CreateTask(ctx context.Context, task *domain.Task) (uuid.U
 	//store in the redis
 	rtask, err := json.Marshal(*task)
 	if err != nil {
-		u.logger.Error("serialize task", slog.String("message", err.Error()))
+		u.log(ctx).Error("serialize task", slog.String("message", err.Error()))
 	}
 
-	err = u.cache.Set(ctx, id, rtask, time.Hour)
+	err = u.cache.Set(ctx, cacheKey(ctx, id), rtask, time.Hour)
 	if err != nil {
-		u.logger.Error("redis insertion error", slog.String("message", err.Error()))
+		u.log(ctx).Error("redis insertion error", slog.String("message", err.Error()))
 	}
 
 	return id, nil
 }
 
+// GetTask returns the template if the caller may see it.
 func (u *TaskService) GetTask(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	task, err := u.getTask(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := u.authorizeTask(ctx, task, domain.PermissionView); err != nil {
+		return nil, err
+	}
+
+	return task, nil
+}
+
+func (u *TaskService) getTask(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
 
 	//use trategy cashe aside
 	//first check in redis
-	redisTask, err := u.cache.Get(ctx, id)
+	redisTask, err := u.cache.Get(ctx, cacheKey(ctx, id))
 	if err == nil {
 		redisTaskBytes, ok := redisTask.(string)
 		if !ok {
-			u.logger.Error("failed convert redis data to bytes")
+			u.log(ctx).Error("failed convert redis data to bytes")
 		}
 		var task domain.Task
 		err := json.Unmarshal([]byte(redisTaskBytes), &task)
 		if err != nil {
-			u.logger.Error("failed convert redis data to domain", slog.String("message", err.Error()))
+			metrics.CacheError()
+			u.log(ctx).Error("failed convert redis data to domain", slog.String("message", err.Error()))
 		}
 		if err == nil {
-			u.logger.Info("success", slog.String("message", redisTaskBytes))
+			metrics.CacheHit()
+			u.log(ctx).Info("success", slog.String("message", redisTaskBytes))
 			return &task, nil
 		}
 	} else {
-		if !errors.Is(err, redis.Nil) {
-			u.logger.Error("redis error", slog.String("message", err.Error()))
+		if errors.Is(err, redis.Nil) {
+			metrics.CacheMiss()
+		} else {
+			metrics.CacheError()
+			u.log(ctx).Error("redis error", slog.String("message", err.Error()))
 		}
 	}
 
@@ -92,21 +122,22 @@ func (u *TaskService) GetTask(ctx context.Context, id uuid.UUID) (*domain.Task,
 	//store in the redis
 	rtask, err := json.Marshal(task)
 	if err != nil {
-		u.logger.Error("serialize task", slog.String("message", err.Error()))
+		u.log(ctx).Error("serialize task", slog.String("message", err.Error()))
 	}
 
 	if err == nil {
-		err = u.cache.Set(ctx, id, rtask, time.Hour)
+		err = u.cache.Set(ctx, cacheKey(ctx, id), rtask, time.Hour)
 		if err != nil {
-			u.logger.Error("redis insertion error", slog.String("message", err.Error()))
+			u.log(ctx).Error("redis insertion error", slog.String("message", err.Error()))
 		}
 	}
 
 	return task, nil
 }
 
+// GetTasks returns the templates the caller may see.
 func (u *TaskService) GetTasks(ctx context.Context) ([]*domain.Task, error) {
-	task, err := u.db.GetTasks(ctx)
+	task, err := u.db.GetTasks(ctx, callerID(ctx))
 	if err != nil {
 		return nil, fmt.Errorf("failed get task: %w", err)
 	}
@@ -114,7 +145,20 @@ func (u *TaskService) GetTasks(ctx context.Context) ([]*domain.Task, error) {
 	return task, nil
 }
 
+// GetTasksByIDs returns the templates of ids the caller may see, in one query. Missing and hidden ones are left out.
+func (u *TaskService) GetTasksByIDs(ctx context.Context, ids []uuid.UUID) ([]*domain.Task, error) {
+	tasks, err := u.db.GetTasksByIDs(ctx, ids, callerID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed get tasks: %w", err)
+	}
+
+	return tasks, nil
+}
+
 func (u *TaskService) UpdateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error) {
+	if err := u.authorize(ctx, task.ID, domain.PermissionEdit); err != nil {
+		return uuid.Nil, err
+	}
 
 	err := u.db.UpdateTask(ctx, task)
 	if err != nil {
@@ -123,50 +167,66 @@ func (u *TaskService) UpdateTask(ctx context.Context, task *domain.Task) (uuid.U
 
 	rtask, err := json.Marshal(*task)
 	if err != nil {
-		u.logger.Error("serialize task", slog.String("message", err.Error()))
+		u.log(ctx).Error("serialize task", slog.String("message", err.Error()))
 	}
 
-	err = u.cache.Set(ctx, task.ID, rtask, time.Hour)
+	err = u.cache.Set(ctx, cacheKey(ctx, task.ID), rtask, time.Hour)
 	if err != nil {
-		u.logger.Error("redis insertion error", slog.String("message", err.Error()))
+		u.log(ctx).Error("redis insertion error", slog.String("message", err.Error()))
 	}
 
 	return task.ID, nil
 }
 
-func (u *TaskService) DeleteTask(ctx context.Context, id uuid.UUID) error {
+// DeleteTask deletes the task if it still has the given version; zero skips the check.
+// Only the author deletes a template.
+func (u *TaskService) DeleteTask(ctx context.Context, id uuid.UUID, version int) error {
+	if err := u.authorize(ctx, id, domain.PermissionOwner); err != nil {
+		return err
+	}
 
-	err := u.db.DeleteTask(ctx, id)
+	err := u.db.DeleteTask(ctx, id, version, callerID(ctx))
 	if err != nil {
 		return fmt.Errorf("failed delete task: %w", err)
 	}
 
-	err = u.cache.Del(ctx, id)
+	err = u.cache.Del(ctx, cacheKey(ctx, id))
 	if err != nil {
-		u.logger.Error("delete from redis", slog.String("message", err.Error()))
+		u.log(ctx).Error("delete from redis", slog.String("message", err.Error()))
 	}
 
 	return nil
 }
 
 func (u *TaskService) CreateAssignments(ctx context.Context, taskAssignments *domain.TaskAsignments) ([]domain.Assignment, error) {
+	if err := u.authorize(ctx, taskAssignments.TaskID, domain.PermissionUse); err != nil {
+		return nil, err
+	}
+
+	for i := range taskAssignments.ToAssign {
+		taskAssignments.ToAssign[i].Class = domain.NormalizeClassName(taskAssignments.ToAssign[i].Class)
+	}
+
+	if err := u.checkClassLessons(ctx, taskAssignments.ToAssign...); err != nil {
+		return nil, err
+	}
+
+	taskAssignments.Status = domain.NewAssignmentStatus(taskAssignments.Draft, taskAssignments.PublishAt, time.Now())
+
 	assignments, err := u.db.CreateAssignments(ctx, taskAssignments)
 	if err != nil {
 		return nil, fmt.Errorf("failed assignment task to users task: %w", err)
 	}
 
-	for _, event := range domain.NewTaskAssignedToUserEvent(assignments) {
-		err = u.producer.Produce(event)
-		if err != nil {
-			u.logger.Error("failed to send event:", slog.String("error", err.Error()))
-		}
+	if taskAssignments.Status == domain.AssignmentStatusPublished {
+		u.produceTaskAssigned(ctx, assignments)
 	}
 
-	return assignments, err
+	return assignments, nil
 }
 
 func (u *TaskService) GetTaskByClass(ctx context.Context, class string) ([]*domain.LessonTask, error) {
-	tasks, err := u.db.GetTaskByClass(ctx, class)
+	tasks, err := u.db.GetTaskByClass(ctx, domain.NormalizeClassName(class))
 	if err != nil {
 		return nil, fmt.Errorf("failed get task: %w", err)
 	}
@@ -174,22 +234,63 @@ func (u *TaskService) GetTaskByClass(ctx context.Context, class string) ([]*doma
 	return tasks, err
 }
 
+// GetTasksByClasses returns the published tasks of every class in one query, keyed by the normalized class name.
+func (u *TaskService) GetTasksByClasses(ctx context.Context, classes []string) (map[string][]*domain.LessonTask, error) {
+	normalized := make([]string, 0, len(classes))
+	for _, class := range classes {
+		normalized = append(normalized, domain.NormalizeClassName(class))
+	}
+
+	tasks, err := u.db.GetTasksByClasses(ctx, normalized)
+	if err != nil {
+		return nil, fmt.Errorf("failed get tasks of classes: %w", err)
+	}
+
+	return tasks, nil
+}
+
 func (u *TaskService) SetTaskResultsByUsers(ctx context.Context, taskResults *domain.TaskResult) error {
-	err := u.db.SetTaskResultsByUsers(ctx, taskResults)
+	err := u.applyLatePolicy(ctx, taskResults)
+	if err != nil {
+		return err
+	}
+
+	err = u.db.SetTaskResultsByUsers(ctx, taskResults)
 	if err != nil {
 		return fmt.Errorf("failed assignment task to users task: %w", err)
 	}
 
-	err = u.producer.Produce(domain.NewStudentsGotMarkEvent(taskResults))
+	u.produce(ctx, domain.NewStudentsGotMarkEvent(taskResults))
+
+	return nil
+}
+
+// applyLatePolicy stamps the marks with their submission time and penalty by the late policy of the assignment.
+func (u *TaskService) applyLatePolicy(ctx context.Context, taskResults *domain.TaskResult) error {
+	deadline, err := u.db.GetAssignmentDeadline(ctx, taskResults.TaskID)
 	if err != nil {
-		u.logger.Error("failed to send event:", slog.String("error", err.Error()))
+		return fmt.Errorf("failed get assignment deadline: %w", err)
+	}
+
+	now := time.Now()
+	for i := range taskResults.UsersResult {
+		result := &taskResults.UsersResult[i]
+		err = deadline.ApplyLatePolicy(result, now)
+		if err != nil {
+			return fmt.Errorf("user %s: %w", result.UserID, err)
+		}
 	}
 
 	return nil
 }
 
-func (u *TaskService) DeleteAssignment(ctx context.Context, assignmentID uuid.UUID) error {
-	err := u.db.DeleteAssignment(ctx, assignmentID)
+// DeleteAssignment deletes the assignment if it still has the given version; zero skips the check.
+func (u *TaskService) DeleteAssignment(ctx context.Context, assignmentID uuid.UUID, version int) error {
+	if _, err := u.usableAssignment(ctx, assignmentID); err != nil {
+		return err
+	}
+
+	err := u.db.DeleteAssignment(ctx, assignmentID, version, callerID(ctx))
 	if err != nil {
 		return fmt.Errorf("failed assignment task to users task: %w", err)
 	}
@@ -198,34 +299,199 @@ func (u *TaskService) DeleteAssignment(ctx context.Context, assignmentID uuid.UU
 }
 
 func (u *TaskService) CreateTaskWithAssignments(ctx context.Context, assignment *domain.TaskWithAsignment) (uuid.UUID, error) {
+	authorID, err := requireCaller(ctx)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	assignment.Class = domain.NormalizeClassName(assignment.Class)
+	if err := u.checkClassLessons(ctx, domain.ClassLesson{Class: assignment.Class, LessonID: assignment.LessonID}); err != nil {
+		return uuid.Nil, err
+	}
+
+	assignment.AuthorID = authorID
+	assignment.Status = domain.NewAssignmentStatus(assignment.Draft, assignment.PublishAt, time.Now())
+
 	id, err := u.db.CreateTaskWithAssignments(ctx, assignment)
 	if err != nil {
 		return uuid.Nil, fmt.Errorf("failed create task with assignment: %w", err)
 	}
 
-	events := []domain.Assignment{
-		{
-			AssignmentID: id,
-			Class:        assignment.Class,
-			LessonID:     assignment.LessonID,
-		},
+	if assignment.Status == domain.AssignmentStatusPublished {
+		u.produceTaskAssigned(ctx, []domain.Assignment{
+			{
+				AssignmentID: id,
+				Class:        assignment.Class,
+				LessonID:     assignment.LessonID,
+				Status:       assignment.Status,
+			},
+		})
 	}
 
-	domainEvents := domain.NewTaskAssignedToUserEvent(events)
+	return id, nil
+}
 
-	err = u.producer.Produce(domainEvents[0])
+func (u *TaskService) GetAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.TaskAsignment, error) {
+	assignment, err := u.db.GetAssignment(ctx, assignmentID)
 	if err != nil {
-		u.logger.Error("failed to send event:", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("failed get assignment: %w", err)
 	}
 
-	return id, nil
+	return assignment, nil
 }
 
+// UpdateAssignment moves the assignment to another class only if its lesson belongs to that class too.
 func (u *TaskService) UpdateAssignment(ctx context.Context, assignment *domain.TaskAsignment) error {
-	err := u.db.UpdateAssignment(ctx, assignment)
+	current, err := u.usableAssignment(ctx, assignment.AssignmentID)
+	if err != nil {
+		return err
+	}
+
+	assignment.Class = domain.NormalizeClassName(assignment.Class)
+	if assignment.Class != current.Class {
+		if err := u.checkClassLessons(ctx, domain.ClassLesson{Class: assignment.Class, LessonID: current.LessonID}); err != nil {
+			return err
+		}
+	}
+
+	err = u.db.UpdateAssignment(ctx, assignment)
 	if err != nil {
 		return fmt.Errorf("failed create task with assignment: %w", err)
 	}
 
 	return nil
 }
+
+func (u *TaskService) SetAssignmentLatePolicy(ctx context.Context, policy *domain.AssignmentLatePolicy) error {
+	if !policy.Policy.IsValid() {
+		return domain.ErrInvalidLatePolicy.WithField("late_policy", "expected accept, penalty or reject")
+	}
+
+	if policy.PenaltyPerDay < 0 || policy.PenaltyPerDay > 100 {
+		return domain.ErrInvalidLatePolicy.WithField("penalty_per_day", "must be between 0 and 100")
+	}
+
+	if policy.Policy != domain.LatePolicyPenalty {
+		policy.PenaltyPerDay = 0
+	}
+
+	if _, err := u.usableAssignment(ctx, policy.AssignmentID); err != nil {
+		return err
+	}
+
+	err := u.db.SetAssignmentLatePolicy(ctx, policy)
+	if err != nil {
+		return fmt.Errorf("failed set late policy: %w", err)
+	}
+
+	return nil
+}
+
+func (u *TaskService) SetDeadlineExtension(ctx context.Context, extension *domain.DeadlineExtension) error {
+	if _, err := u.usableAssignment(ctx, extension.AssignmentID); err != nil {
+		return err
+	}
+
+	err := u.db.SetDeadlineExtension(ctx, extension)
+	if err != nil {
+		return fmt.Errorf("failed set deadline extension: %w", err)
+	}
+
+	return nil
+}
+
+func (u *TaskService) DeleteDeadlineExtension(ctx context.Context, assignmentID, userID uuid.UUID) error {
+	if _, err := u.usableAssignment(ctx, assignmentID); err != nil {
+		return err
+	}
+
+	err := u.db.DeleteDeadlineExtension(ctx, assignmentID, userID)
+	if err != nil {
+		return fmt.Errorf("failed delete deadline extension: %w", err)
+	}
+
+	return nil
+}
+
+func (u *TaskService) PublishAssignment(ctx context.Context, assignmentID uuid.UUID) error {
+	if _, err := u.usableAssignment(ctx, assignmentID); err != nil {
+		return err
+	}
+
+	assignment, err := u.db.PublishAssignment(ctx, assignmentID)
+	if err != nil {
+		return fmt.Errorf("failed publish assignment: %w", err)
+	}
+
+	u.produceTaskAssigned(ctx, []domain.Assignment{*assignment})
+
+	return nil
+}
+
+// PublishDueAssignments publishes scheduled drafts whose publish time has passed
+// and only then notifies the classes about them.
+func (u *TaskService) PublishDueAssignments(ctx context.Context) (int, error) {
+	assignments, err := u.db.PublishDueAssignments(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed publish due assignments: %w", err)
+	}
+
+	u.produceTaskAssignedByTenant(ctx, assignments)
+
+	return len(assignments), nil
+}
+
+// SendDeadlineReminders emits DeadlineApproaching for every window a deadline entered
+// and DeadlinePassed once it is over. Each assignment is notified once per window: the reminders
+// are claimed only once the broker acknowledged the events, otherwise the next run sends them again.
+// A claim that fails to commit after the delivery sends them again as well, so consumers may see a reminder twice.
+func (u *TaskService) SendDeadlineReminders(ctx context.Context, windows []time.Duration) (int, error) {
+	sent, err := u.db.ClaimDeadlineReminders(ctx, time.Now(), windows, func(reminders []domain.DeadlineReminder) error {
+		events := make([]domain.Event, 0, len(reminders))
+		for _, reminder := range reminders {
+			event := domain.NewDeadlineEvent(reminder)
+			event.SetTenant(reminder.TenantID)
+			events = append(events, event)
+		}
+
+		return u.producer.DeliverBatch(ctx, events)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed send deadline reminders: %w", err)
+	}
+
+	return sent, nil
+}
+
+func (u *TaskService) produceTaskAssigned(ctx context.Context, assignments []domain.Assignment) {
+	for _, event := range domain.NewTaskAssignedToUserEvent(assignments) {
+		u.produce(ctx, event)
+	}
+}
+
+// produceTaskAssignedByTenant announces assignments of background jobs, which belong to different schools.
+func (u *TaskService) produceTaskAssignedByTenant(ctx context.Context, assignments []domain.Assignment) {
+	for _, assignment := range assignments {
+		u.produceTaskAssigned(domain.ContextWithTenant(ctx, assignment.TenantID), []domain.Assignment{assignment})
+	}
+}
+
+// produce stamps the event with the school of ctx and sends it.
+func (u *TaskService) produce(ctx context.Context, event domain.Event) {
+	event.SetTenant(domain.TenantFromContext(ctx))
+
+	err := u.producer.Produce(ctx, event)
+	if err != nil {
+		u.log(ctx).Error("failed to send event:", slog.String("error", err.Error()))
+	}
+}
+
+// log returns the logger of the request ctx belongs to, or the service logger in background jobs.
+func (u *TaskService) log(ctx context.Context) *slog.Logger {
+	return logging.FromContext(ctx, u.logger)
+}
+
+// cacheKey keeps the cached tasks of different schools apart.
+func cacheKey(ctx context.Context, id uuid.UUID) string {
+	return domain.TenantFromContext(ctx).String() + ":" + id.String()
+}
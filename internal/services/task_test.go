@@ -3,32 +3,36 @@ package services_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"task/internal/app"
 	"task/internal/domain"
 	"task/internal/services"
-	repoMock "task/mocks"
+	repoMock "task/mocks/mocks"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
 func TestCreateTask(t *testing.T) {
-	ctx := context.Background()
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
 	mockService := new(repoMock.Database)
 	cacheMock := new(repoMock.Cache)
 	producerMock := new(repoMock.Producer)
 	id := uuid.New()
 	task := &domain.Task{
-		ID:      id,
-		Payload: "5+5 = ?",
+		ID:       id,
+		Payload:  "5+5 = ?",
+		AuthorID: &userID,
 	}
 	rtask, err := json.Marshal(*task)
 	require.NoError(t, err)
 	mockService.On("CreateTask", ctx, task).Return(id, nil)
-	cacheMock.On("Set", ctx, id, rtask, time.Hour).Return(nil)
+	cacheMock.On("Set", ctx, uuid.Nil.String()+":"+id.String(), rtask, time.Hour).Return(nil)
 	logger := app.InitLogger()
 	usecase := services.New(logger, mockService, cacheMock, producerMock)
 
@@ -40,7 +44,8 @@ func TestCreateTask(t *testing.T) {
 }
 
 func TestUpdateTask(t *testing.T) {
-	ctx := context.Background()
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
 	mockService := new(repoMock.Database)
 	cacheMock := new(repoMock.Cache)
 	producerMock := new(repoMock.Producer)
@@ -51,8 +56,9 @@ func TestUpdateTask(t *testing.T) {
 	}
 	rtask, err := json.Marshal(*task)
 	require.NoError(t, err)
+	openTasks(ctx, mockService, &userID, id)
 	mockService.On("UpdateTask", ctx, task).Return(nil)
-	cacheMock.On("Set", ctx, id, rtask, time.Hour).Return(nil)
+	cacheMock.On("Set", ctx, uuid.Nil.String()+":"+id.String(), rtask, time.Hour).Return(nil)
 	logger := app.InitLogger()
 	usecase := services.New(logger, mockService, cacheMock, producerMock)
 
@@ -63,19 +69,45 @@ func TestUpdateTask(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestGetTaskCachedPerTenant(t *testing.T) {
+	tenantID := uuid.New()
+	ctx := domain.ContextWithTenant(context.Background(), tenantID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	id := uuid.New()
+	task := &domain.Task{
+		ID:      id,
+		Payload: "5+5 = ?",
+	}
+	rtask, err := json.Marshal(*task)
+	require.NoError(t, err)
+	cacheMock.On("Get", ctx, tenantID.String()+":"+id.String()).Return(string(rtask), nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	got, err := usecase.GetTask(ctx, id)
+	require.NoError(t, err)
+
+	assert.Equal(t, task, got)
+	mockService.AssertNotCalled(t, "GetTaskByID", mock.Anything, mock.Anything)
+}
+
 func TestDeleteTask(t *testing.T) {
-	ctx := context.Background()
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
 	mockService := new(repoMock.Database)
 	cacheMock := new(repoMock.Cache)
 	producerMock := new(repoMock.Producer)
 	id := uuid.New()
 
-	mockService.On("DeleteTask", ctx, id).Return(nil)
-	cacheMock.On("Del", ctx, id).Return(nil)
+	openTasks(ctx, mockService, &userID, id)
+	mockService.On("DeleteTask", ctx, id, 0, &userID).Return(nil)
+	cacheMock.On("Del", ctx, uuid.Nil.String()+":"+id.String()).Return(nil)
 	logger := app.InitLogger()
 	usecase := services.New(logger, mockService, cacheMock, producerMock)
 
-	err := usecase.DeleteTask(ctx, id)
+	err := usecase.DeleteTask(ctx, id, 0)
 
 	assert.NoError(t, err)
 }
@@ -104,7 +136,7 @@ func TestGetTaskByClass(t *testing.T) {
 }
 
 func TestCreateTaskWithAssignment(t *testing.T) {
-	ctx := context.Background()
+	ctx := domain.ContextWithUserID(context.Background(), uuid.New())
 	mockService := new(repoMock.Database)
 	cacheMock := new(repoMock.Cache)
 	producerMock := new(repoMock.Producer)
@@ -128,8 +160,9 @@ func TestCreateTaskWithAssignment(t *testing.T) {
 
 	domainEvents := domain.NewTaskAssignedToUserEvent(events)
 
+	validReferences(ctx, mockService)
 	mockService.On("CreateTaskWithAssignments", ctx, assignment).Return(id, nil)
-	producerMock.On("Produce", domainEvents[0]).Return(nil)
+	producerMock.On("Produce", ctx, domainEvents[0]).Return(nil)
 	logger := app.InitLogger()
 	usecase := services.New(logger, mockService, cacheMock, producerMock)
 
@@ -139,7 +172,8 @@ func TestCreateTaskWithAssignment(t *testing.T) {
 }
 
 func TestUpdateAssignment(t *testing.T) {
-	ctx := context.Background()
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
 	mockService := new(repoMock.Database)
 	cacheMock := new(repoMock.Cache)
 	producerMock := new(repoMock.Producer)
@@ -152,6 +186,9 @@ func TestUpdateAssignment(t *testing.T) {
 		Payload:      "what?",
 	}
 
+	taskID := uuid.New()
+	mockService.On("GetAssignment", ctx, id).Return(&domain.TaskAsignment{AssignmentID: id, TaskID: taskID, Class: class, LessonID: uuid.New()}, nil)
+	openTasks(ctx, mockService, &userID, taskID)
 	mockService.On("UpdateAssignment", ctx, assignment).Return(nil)
 	logger := app.InitLogger()
 	usecase := services.New(logger, mockService, cacheMock, producerMock)
@@ -161,16 +198,964 @@ func TestUpdateAssignment(t *testing.T) {
 }
 
 func TestDeletAssignment(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+
+	id := uuid.New()
+	taskID := uuid.New()
+	mockService.On("GetAssignment", ctx, id).Return(&domain.TaskAsignment{AssignmentID: id, TaskID: taskID}, nil)
+	openTasks(ctx, mockService, &userID, taskID)
+	mockService.On("DeleteAssignment", ctx, id, 0, &userID).Return(nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err := usecase.DeleteAssignment(ctx, id, 0)
+	assert.NoError(t, err)
+}
+
+func TestSetTaskResultsByUsersLatePenalty(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+
+	assignmentID := uuid.New()
+	onTimeUser := uuid.New()
+	lateUser := uuid.New()
+	extendedUser := uuid.New()
+	deadline := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	submittedAt := deadline.Add(36 * time.Hour)
+
+	taskResults := &domain.TaskResult{
+		TaskID:   assignmentID,
+		LessonID: uuid.New(),
+		UsersResult: []domain.UserResult{
+			{UserID: onTimeUser, Mark: 5, SubmittedAt: deadline.Add(-time.Hour)},
+			{UserID: lateUser, Mark: 100, SubmittedAt: submittedAt},
+			{UserID: extendedUser, Mark: 100, SubmittedAt: submittedAt},
+		},
+	}
+
+	mockService.On("GetAssignmentDeadline", ctx, assignmentID).Return(&domain.AssignmentDeadline{
+		AssignmentID:  assignmentID,
+		Deadline:      &deadline,
+		Policy:        domain.LatePolicyPenalty,
+		PenaltyPerDay: 10,
+		Extensions: map[uuid.UUID]time.Time{
+			extendedUser: deadline.Add(48 * time.Hour),
+		},
+	}, nil)
+	mockService.On("SetTaskResultsByUsers", ctx, taskResults).Return(nil)
+	producerMock.On("Produce", mock.Anything, mock.Anything).Return(nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err := usecase.SetTaskResultsByUsers(ctx, taskResults)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, taskResults.UsersResult[0].Mark)
+	assert.Equal(t, 0, taskResults.UsersResult[0].Penalty)
+	assert.Equal(t, 80, taskResults.UsersResult[1].Mark)
+	assert.Equal(t, 20, taskResults.UsersResult[1].Penalty)
+	assert.Equal(t, 100, taskResults.UsersResult[2].Mark)
+	assert.Equal(t, 0, taskResults.UsersResult[2].Penalty)
+}
+
+func TestSetTaskResultsByUsersLateRejected(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+
+	assignmentID := uuid.New()
+	deadline := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	taskResults := &domain.TaskResult{
+		TaskID:   assignmentID,
+		LessonID: uuid.New(),
+		UsersResult: []domain.UserResult{
+			{UserID: uuid.New(), Mark: 5, SubmittedAt: deadline.Add(time.Minute)},
+		},
+	}
+
+	mockService.On("GetAssignmentDeadline", ctx, assignmentID).Return(&domain.AssignmentDeadline{
+		AssignmentID: assignmentID,
+		Deadline:     &deadline,
+		Policy:       domain.LatePolicyReject,
+	}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err := usecase.SetTaskResultsByUsers(ctx, taskResults)
+	assert.ErrorIs(t, err, domain.ErrLateSubmissionRejected)
+	mockService.AssertNotCalled(t, "SetTaskResultsByUsers", ctx, taskResults)
+}
+
+func TestSetTaskResultsByUsersCorrectionKeepsSubmission(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+
+	assignmentID := uuid.New()
+	userID := uuid.New()
+	deadline := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	submittedAt := deadline.Add(-time.Hour)
+
+	taskResults := &domain.TaskResult{
+		TaskID:      assignmentID,
+		LessonID:    uuid.New(),
+		UsersResult: []domain.UserResult{{UserID: userID, Mark: 100}},
+	}
+
+	mockService.On("GetAssignmentDeadline", ctx, assignmentID).Return(&domain.AssignmentDeadline{
+		AssignmentID: assignmentID,
+		Deadline:     &deadline,
+		Policy:       domain.LatePolicyReject,
+		Submissions: map[uuid.UUID]domain.Submission{
+			userID: {SubmittedAt: submittedAt, Penalty: 10},
+		},
+	}, nil)
+	mockService.On("SetTaskResultsByUsers", ctx, taskResults).Return(nil)
+	producerMock.On("Produce", mock.Anything, mock.Anything).Return(nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err := usecase.SetTaskResultsByUsers(ctx, taskResults)
+	require.NoError(t, err)
+
+	assert.Equal(t, submittedAt, taskResults.UsersResult[0].SubmittedAt)
+	assert.Equal(t, 10, taskResults.UsersResult[0].Penalty)
+	assert.Equal(t, 90, taskResults.UsersResult[0].Mark)
+}
+
+func TestSetTaskResultsByUsersRequiresSubmissionTimeAfterDeadline(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+
+	assignmentID := uuid.New()
+	deadline := time.Now().Add(-time.Hour)
+
+	taskResults := &domain.TaskResult{
+		TaskID:      assignmentID,
+		LessonID:    uuid.New(),
+		UsersResult: []domain.UserResult{{UserID: uuid.New(), Mark: 5}},
+	}
+
+	mockService.On("GetAssignmentDeadline", ctx, assignmentID).Return(&domain.AssignmentDeadline{
+		AssignmentID:  assignmentID,
+		Deadline:      &deadline,
+		Policy:        domain.LatePolicyPenalty,
+		PenaltyPerDay: 10,
+	}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err := usecase.SetTaskResultsByUsers(ctx, taskResults)
+
+	assert.ErrorIs(t, err, domain.ErrSubmittedAtRequired)
+	mockService.AssertNotCalled(t, "SetTaskResultsByUsers", mock.Anything, mock.Anything)
+}
+
+func TestCreateScheduledAssignments(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+
+	publishAt := time.Now().Add(24 * time.Hour)
+	taskAssignments := &domain.TaskAsignments{
+		TaskID:    uuid.New(),
+		ToAssign:  []domain.ClassLesson{{Class: "9A", LessonID: uuid.New()}},
+		PublishAt: &publishAt,
+	}
+
+	openTasks(ctx, mockService, &userID, taskAssignments.TaskID)
+	validReferences(ctx, mockService)
+	mockService.On("CreateAssignments", ctx, taskAssignments).Return([]domain.Assignment{
+		{
+			AssignmentID: uuid.New(),
+			Class:        "9A",
+			LessonID:     taskAssignments.ToAssign[0].LessonID,
+			Status:       domain.AssignmentStatusDraft,
+		},
+	}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	_, err := usecase.CreateAssignments(ctx, taskAssignments)
+	require.NoError(t, err)
+
+	assert.Equal(t, domain.AssignmentStatusDraft, taskAssignments.Status)
+	producerMock.AssertNotCalled(t, "Produce", mock.Anything, mock.Anything)
+}
+
+func TestPublishDueAssignments(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+
+	assignments := []domain.Assignment{
+		{AssignmentID: uuid.New(), Class: "9A", LessonID: uuid.New(), Status: domain.AssignmentStatusPublished, TenantID: uuid.New()},
+		{AssignmentID: uuid.New(), Class: "9A", LessonID: uuid.New(), Status: domain.AssignmentStatusPublished, TenantID: uuid.New()},
+	}
+
+	mockService.On("PublishDueAssignments", ctx, mock.AnythingOfType("time.Time")).Return(assignments, nil)
+	for i, event := range domain.NewTaskAssignedToUserEvent(assignments) {
+		event.SetTenant(assignments[i].TenantID)
+		producerMock.On("Produce", mock.Anything, event).Return(nil).Once()
+	}
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	published, err := usecase.PublishDueAssignments(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, published)
+	producerMock.AssertExpectations(t)
+}
+
+func TestSendDeadlineReminders(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+
+	windows := []time.Duration{24 * time.Hour, time.Hour}
+	deadline := time.Now().Add(30 * time.Minute)
+	reminders := []domain.DeadlineReminder{
+		{AssignmentID: uuid.New(), Class: "9A", LessonID: uuid.New(), Deadline: deadline, Window: time.Hour, TenantID: uuid.New()},
+		{AssignmentID: uuid.New(), Class: "9B", LessonID: uuid.New(), Deadline: time.Now().Add(-time.Minute), TenantID: uuid.New()},
+	}
+
+	claimOnSend(mockService, windows, reminders)
+	producerMock.On("DeliverBatch", ctx, mock.MatchedBy(func(events []domain.Event) bool {
+		approaching, ok := events[0].(*domain.DeadlineApproachingEvent)
+		if !ok || approaching.TenantID != reminders[0].TenantID {
+			return false
+		}
+		passed, ok := events[1].(*domain.DeadlinePassedEvent)
+		return ok && passed.TenantID == reminders[1].TenantID
+	})).Return(nil).Once()
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	sent, err := usecase.SendDeadlineReminders(ctx, windows)
+	require.NoError(t, err)
+
+	assert.Equal(t, 2, sent)
+	producerMock.AssertExpectations(t)
+}
+
+func TestSendDeadlineRemindersRetriesFailedSend(t *testing.T) {
 	ctx := context.Background()
 	mockService := new(repoMock.Database)
 	cacheMock := new(repoMock.Cache)
 	producerMock := new(repoMock.Producer)
 
+	windows := []time.Duration{time.Hour}
+	reminders := []domain.DeadlineReminder{
+		{AssignmentID: uuid.New(), Class: "9A", LessonID: uuid.New(), Deadline: time.Now().Add(30 * time.Minute), Window: time.Hour},
+	}
+	sendErr := errors.New("broker is down")
+
+	claimOnSend(mockService, windows, reminders)
+	producerMock.On("DeliverBatch", ctx, mock.Anything).Return(sendErr).Once()
+	producerMock.On("DeliverBatch", ctx, mock.Anything).Return(nil).Once()
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	_, err := usecase.SendDeadlineReminders(ctx, windows)
+	require.ErrorIs(t, err, sendErr)
+
+	sent, err := usecase.SendDeadlineReminders(ctx, windows)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sent)
+
+	sent, err = usecase.SendDeadlineReminders(ctx, windows)
+	require.NoError(t, err)
+	assert.Zero(t, sent)
+	producerMock.AssertExpectations(t)
+}
+
+// claimOnSend hands the reminders to send until it succeeds, as the repository commits the claim only then.
+func claimOnSend(mockService *repoMock.Database, windows []time.Duration, reminders []domain.DeadlineReminder) {
+	claimed := false
+	mockService.On("ClaimDeadlineReminders", mock.Anything, mock.AnythingOfType("time.Time"), windows, mock.Anything).
+		Return(func(_ context.Context, _ time.Time, _ []time.Duration, send func([]domain.DeadlineReminder) error) (int, error) {
+			if claimed {
+				return 0, nil
+			}
+			if err := send(reminders); err != nil {
+				return 0, err
+			}
+			claimed = true
+			return len(reminders), nil
+		})
+}
+
+func TestSetOccurrenceExceptionRejectsUnknownOccurrence(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+
+	rule, err := domain.ParseRRule("FREQ=WEEKLY;BYDAY=MO")
+	require.NoError(t, err)
+
+	recurrence := &domain.Recurrence{
+		ID:       uuid.New(),
+		TaskID:   uuid.New(),
+		Class:    "9A",
+		LessonID: uuid.New(),
+		Rule:     rule,
+		StartsAt: time.Date(2025, 9, 1, 9, 0, 0, 0, time.UTC),
+		Timezone: "UTC",
+	}
+	exception := &domain.OccurrenceException{
+		RecurrenceID: recurrence.ID,
+		OccurrenceAt: time.Date(2025, 9, 2, 9, 0, 0, 0, time.UTC),
+		Skipped:      true,
+	}
+
+	mockService.On("GetRecurrence", ctx, recurrence.ID).Return(recurrence, nil)
+	openTasks(ctx, mockService, &userID, recurrence.TaskID)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err = usecase.SetOccurrenceException(ctx, exception)
+	assert.ErrorIs(t, err, domain.ErrOccurrenceNotFound)
+	mockService.AssertNotCalled(t, "SetOccurrenceException", ctx, exception)
+}
+
+func TestStopRecurrenceOfTemplateSharedForUseIsForbidden(t *testing.T) {
+	userID := uuid.New()
+	authorID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	recurrence := &domain.Recurrence{ID: uuid.New(), TaskID: uuid.New(), Class: "9A"}
+
+	mockService.On("GetRecurrence", ctx, recurrence.ID).Return(recurrence, nil)
+	mockService.On("GetTaskAccess", ctx, []uuid.UUID{recurrence.TaskID}, &userID).Return(map[uuid.UUID]domain.TaskAccess{
+		recurrence.TaskID: {AuthorID: &authorID, Shared: domain.PermissionUse},
+	}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err := usecase.StopRecurrence(ctx, recurrence.ID)
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockService.AssertNotCalled(t, "StopRecurrence", mock.Anything, mock.Anything)
+}
+
+func TestCreateTaskWithoutUserIsForbidden(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	_, err := usecase.CreateTask(ctx, &domain.Task{ID: uuid.New(), Payload: "5+5 = ?"})
+
+	assert.ErrorIs(t, err, domain.ErrUserRequired)
+	mockService.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+func TestUpdateTaskWithoutAuthorTakesUser(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	task := &domain.Task{ID: uuid.New(), Payload: "5+5 = ?"}
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	_, err := usecase.UpdateTask(ctx, task)
+
+	assert.ErrorIs(t, err, domain.ErrUserRequired)
+	mockService.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+}
+
+func TestPublishAssignmentOfTemplateSharedForViewIsForbidden(t *testing.T) {
+	userID := uuid.New()
+	authorID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	assignment := &domain.TaskAsignment{AssignmentID: uuid.New(), TaskID: uuid.New(), Class: "9A"}
+
+	mockService.On("GetAssignment", ctx, assignment.AssignmentID).Return(assignment, nil)
+	mockService.On("GetTaskAccess", ctx, []uuid.UUID{assignment.TaskID}, &userID).Return(map[uuid.UUID]domain.TaskAccess{
+		assignment.TaskID: {AuthorID: &authorID, Shared: domain.PermissionView},
+	}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err := usecase.PublishAssignment(ctx, assignment.AssignmentID)
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockService.AssertNotCalled(t, "PublishAssignment", mock.Anything, mock.Anything)
+}
+
+func TestDeleteAssignmentsBulkSkipsForbiddenItems(t *testing.T) {
+	userID := uuid.New()
+	authorID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	own, shared, missing := uuid.New(), uuid.New(), uuid.New()
+	ids := []uuid.UUID{own, shared, missing}
+
+	mockService.On("GetAssignmentAccess", ctx, ids, &userID).Return(map[uuid.UUID]domain.TaskAccess{
+		own:    {AuthorID: &userID},
+		shared: {AuthorID: &authorID, Shared: domain.PermissionView},
+	}, nil)
+	mockService.On("DeleteAssignments", ctx, domain.BulkModeBestEffort, []uuid.UUID{own}, &userID).Return([]error{nil}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	result, err := usecase.DeleteAssignmentsBulk(ctx, domain.BulkModeBestEffort, ids)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Applied())
+	assert.Equal(t, own, result.Items[0].ID)
+	assert.ErrorIs(t, result.Items[1].Err, domain.ErrForbidden)
+	assert.ErrorIs(t, result.Items[2].Err, domain.ErrAssignmentNotFound)
+	mockService.AssertExpectations(t)
+}
+
+func TestGetTrashOfCaller(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	trash := &domain.Trash{Tasks: []domain.DeletedTask{{Task: domain.Task{ID: uuid.New(), AuthorID: &userID}}}}
+
+	mockService.On("GetTrash", ctx, &userID).Return(trash, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	got, err := usecase.GetTrash(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, trash, got)
+}
+
+func TestRestoreAssignmentOfTemplateSharedForViewIsForbidden(t *testing.T) {
+	userID := uuid.New()
+	authorID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	assignment := &domain.DeletedAssignment{AssignmentID: uuid.New(), TaskID: uuid.New(), Class: "9A"}
+
+	mockService.On("GetDeletedAssignment", ctx, assignment.AssignmentID).Return(assignment, nil)
+	mockService.On("GetTaskAccess", ctx, []uuid.UUID{assignment.TaskID}, &userID).Return(map[uuid.UUID]domain.TaskAccess{
+		assignment.TaskID: {AuthorID: &authorID, Shared: domain.PermissionView},
+	}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err := usecase.RestoreAssignment(ctx, assignment.AssignmentID)
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockService.AssertNotCalled(t, "RestoreAssignment", mock.Anything, mock.Anything)
+}
+
+func TestDeleteTaskRecordsDeletingUser(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
 	id := uuid.New()
-	mockService.On("DeleteAssignment", ctx, id).Return(nil)
+
+	openTasks(ctx, mockService, &userID, id)
+	mockService.On("DeleteTask", ctx, id, 0, &userID).Return(nil)
+	cacheMock.On("Del", ctx, uuid.Nil.String()+":"+id.String()).Return(nil)
 	logger := app.InitLogger()
 	usecase := services.New(logger, mockService, cacheMock, producerMock)
 
-	err := usecase.DeleteAssignment(ctx, id)
+	err := usecase.DeleteTask(ctx, id, 0)
+
 	assert.NoError(t, err)
+	mockService.AssertExpectations(t)
+}
+
+func TestUpdateTaskVersionMismatch(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	task := &domain.Task{
+		ID:      uuid.New(),
+		Payload: "5+5 = ?",
+		Version: 3,
+	}
+
+	openTasks(ctx, mockService, &userID, task.ID)
+	mockService.On("UpdateTask", ctx, task).Return(domain.ErrVersionMismatch)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	_, err := usecase.UpdateTask(ctx, task)
+
+	assert.ErrorIs(t, err, domain.ErrVersionMismatch)
+	cacheMock.AssertNotCalled(t, "Set", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPatchTaskClearsDeadline(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	deadline := time.Now().Add(24 * time.Hour)
+	id := uuid.New()
+	stored := &domain.Task{ID: id, Payload: "5+5 = ?", Deadline: &deadline, Version: 2}
+	patch := &domain.TaskPatch{ID: id, Deadline: domain.PatchField[time.Time]{Set: true}}
+
+	mockService.On("GetTaskByID", ctx, id).Return(stored, nil)
+	mockService.On("UpdateTask", ctx, mock.MatchedBy(func(task *domain.Task) bool {
+		return task.Payload == "5+5 = ?" && task.Deadline == nil && task.Version == 2
+	})).Run(func(args mock.Arguments) {
+		args.Get(1).(*domain.Task).Version = 3
+	}).Return(nil)
+	cacheMock.On("Set", ctx, uuid.Nil.String()+":"+id.String(), mock.Anything, time.Hour).Return(nil)
+	producerMock.On("Produce", ctx, &domain.TaskChangedEvent{TaskID: id.String(), Fields: []string{"deadline"}, Version: 3}).Return(nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	task, err := usecase.PatchTask(ctx, patch)
+
+	require.NoError(t, err)
+	assert.Nil(t, task.Deadline)
+	assert.Equal(t, 3, task.Version)
+	producerMock.AssertExpectations(t)
+}
+
+func TestPatchAssignmentRejectsNullClass(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	id := uuid.New()
+	patch := &domain.AssignmentPatch{AssignmentID: id, Class: domain.PatchField[string]{Set: true}}
+
+	taskID := uuid.New()
+	mockService.On("GetAssignment", ctx, id).Return(&domain.TaskAsignment{AssignmentID: id, TaskID: taskID, Class: "9A", Payload: "5+5 = ?", Version: 1}, nil)
+	openTasks(ctx, mockService, &userID, taskID)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	_, err := usecase.PatchAssignment(ctx, patch)
+
+	assert.ErrorIs(t, err, domain.ErrInvalidPatch)
+	domainErr, ok := domain.AsError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.KindValidation, domainErr.Kind)
+	assert.Equal(t, []domain.FieldError{{Field: "class", Message: "can't be empty"}}, domainErr.Fields)
+	assert.Empty(t, domain.ErrInvalidPatch.Fields)
+	mockService.AssertNotCalled(t, "PatchAssignment", mock.Anything, mock.Anything)
+}
+
+func TestCreateTasksBulkAtomicRejectsInvalidItem(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	tasks := []*domain.Task{
+		{ID: uuid.New(), Payload: "5+5 = ?"},
+		{ID: uuid.New()},
+	}
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	result, err := usecase.CreateTasksBulk(ctx, domain.BulkModeAtomic, tasks)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.BulkItemRolledBack, result.Items[0].Status)
+	assert.Equal(t, domain.BulkItemFailed, result.Items[1].Status)
+	assert.ErrorIs(t, result.Items[1].Err, domain.ErrInvalidBulkItem)
+	assert.Zero(t, result.Applied())
+	mockService.AssertNotCalled(t, "CreateTasks", mock.Anything, mock.Anything)
+}
+
+func TestAssignTasksBulkBestEffort(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	bulk := &domain.BulkAssignments{
+		Mode: domain.BulkModeBestEffort,
+		Items: []domain.BulkAssignment{
+			{TaskID: uuid.New(), Class: "9A", LessonID: uuid.New()},
+			{TaskID: uuid.New(), Class: "9B", LessonID: uuid.New()},
+		},
+	}
+	created := domain.Assignment{AssignmentID: uuid.New(), Class: "9A", LessonID: bulk.Items[0].LessonID, Status: domain.AssignmentStatusPublished}
+
+	openTasks(ctx, mockService, &userID, bulk.Items[0].TaskID, bulk.Items[1].TaskID)
+	validReferences(ctx, mockService)
+	mockService.On("CreateAssignmentsBulk", ctx, bulk).Return(
+		[]domain.Assignment{created, {Class: "9B", LessonID: bulk.Items[1].LessonID}},
+		[]error{nil, domain.ErrTaskNotFound},
+		nil,
+	)
+	producerMock.On("ProduceBatch", ctx, []domain.Event{
+		&domain.TaskAssignmentToClassEvent{Class: "9A", LessonID: created.LessonID.String(), TaskID: created.AssignmentID.String()},
+	}).Return(nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	result, err := usecase.AssignTasksBulk(ctx, bulk)
+
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Applied())
+	assert.Equal(t, domain.BulkItemFailed, result.Items[1].Status)
+	assert.ErrorIs(t, result.Items[1].Err, domain.ErrTaskNotFound)
+	producerMock.AssertExpectations(t)
+}
+
+func TestImportTasksInvalidRowWritesNothing(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	table := [][]string{
+		{"payload", "deadline", "category"},
+		{"5+5 = ?", "2025-01-01", "алгебра"},
+		{"", "01.01.2025", ""},
+		{"2+2 = ?", "tomorrow", ""},
+	}
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	report, err := usecase.ImportTasks(ctx, table, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, report.Rows)
+	assert.Zero(t, report.Imported)
+	require.Len(t, report.Errors, 2)
+	assert.Equal(t, 3, report.Errors[0].Row)
+	assert.Equal(t, "payload", report.Errors[0].Column)
+	assert.Equal(t, 4, report.Errors[1].Row)
+	assert.Equal(t, "deadline", report.Errors[1].Column)
+	assert.ErrorIs(t, report.Errors[1], domain.ErrInvalidImport)
+	mockService.AssertNotCalled(t, "CreateTask", mock.Anything, mock.Anything)
+}
+
+func TestImportMarksDryRunChecksReferences(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	lessonID := uuid.New()
+	existing, missing, rejected := uuid.New(), uuid.New(), uuid.New()
+	table := [][]string{
+		{"student_id", "task_id", "lesson_id", "mark"},
+		{uuid.NewString(), existing.String(), lessonID.String(), "5"},
+		{uuid.NewString(), missing.String(), lessonID.String(), "4"},
+		{uuid.NewString(), rejected.String(), lessonID.String(), "3"},
+	}
+	for _, assignmentID := range []uuid.UUID{existing, rejected} {
+		mockService.On("GetAssignmentDeadline", ctx, assignmentID).Return(&domain.AssignmentDeadline{AssignmentID: assignmentID}, nil)
+	}
+	mockService.On("GetAssignmentDeadline", ctx, missing).Return(nil, domain.ErrAssignmentNotFound)
+	mockService.On("CheckTaskResults", ctx, mock.MatchedBy(func(results []*domain.TaskResult) bool {
+		return len(results) == 2 && results[0].TaskID == existing && results[1].TaskID == rejected
+	})).Return([]error{nil, domain.ErrInvalidImport}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	report, err := usecase.ImportMarks(ctx, table, true)
+
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Zero(t, report.Imported)
+	require.Len(t, report.Errors, 2)
+	assert.Equal(t, 3, report.Errors[0].Row)
+	assert.ErrorIs(t, report.Errors[0], domain.ErrAssignmentNotFound)
+	assert.Equal(t, 4, report.Errors[1].Row)
+	assert.ErrorIs(t, report.Errors[1], domain.ErrInvalidImport)
+	mockService.AssertNotCalled(t, "SetTaskResultsByUsers", mock.Anything, mock.Anything)
+	producerMock.AssertNotCalled(t, "Produce", mock.Anything, mock.Anything)
+}
+
+func TestExportGradebookReturnsWriteError(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	writeErr := errors.New("client went away")
+	mockService.On("StreamGradebook", ctx, "9A", mock.Anything).
+		Return(func(_ context.Context, _ string, fn func(*domain.GradebookEntry) error) error {
+			return fn(&domain.GradebookEntry{StudentID: uuid.New(), Class: "9A"})
+		})
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	var written int
+	err := usecase.ExportGradebook(ctx, "9A", func(*domain.GradebookEntry) error {
+		written++
+		return writeErr
+	})
+
+	assert.ErrorIs(t, err, writeErr)
+	assert.Equal(t, 1, written)
+}
+
+func TestGetStudentTasksAppliesExtensions(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	studentID := uuid.New()
+	deadline := time.Date(2025, 1, 1, 13, 0, 0, 0, time.UTC)
+	extended := deadline.Add(48 * time.Hour)
+	tasks := []*domain.LessonTask{
+		{TaskID: uuid.New(), Deadline: &deadline},
+		{TaskID: uuid.New(), Deadline: &deadline},
+	}
+	mockService.On("GetTaskByClass", ctx, "9A").Return(tasks, nil)
+	mockService.On("GetStudentExtensions", ctx, "9A", studentID).Return(map[uuid.UUID]time.Time{tasks[1].TaskID: extended}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	result, err := usecase.GetStudentTasks(ctx, "9A", studentID)
+
+	require.NoError(t, err)
+	assert.Equal(t, deadline, *result[0].Deadline)
+	assert.Equal(t, extended, *result[1].Deadline)
+}
+
+func TestAuthorizeCalendarLink(t *testing.T) {
+	callerID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name      string
+		studentID *uuid.UUID
+		teaches   bool
+		wantErr   error
+	}{
+		{name: "own student calendar", studentID: &callerID},
+		{name: "student calendar of a teacher", studentID: &otherID, teaches: true},
+		{name: "student calendar of another student", studentID: &otherID, wantErr: domain.ErrCalendarForbidden},
+		{name: "class calendar of a teacher", teaches: true},
+		{name: "class calendar of a student", wantErr: domain.ErrCalendarForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := domain.ContextWithUserID(context.Background(), callerID)
+			mockService := new(repoMock.Database)
+			cacheMock := new(repoMock.Cache)
+			producerMock := new(repoMock.Producer)
+			mockService.On("TeachesClass", ctx, "9A", callerID).Return(tt.teaches, nil).Maybe()
+			logger := app.InitLogger()
+			usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+			err := usecase.AuthorizeCalendarLink(ctx, " 9a", tt.studentID)
+
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestAuthorizeCalendarLinkWithoutCallerIsForbidden(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	studentID := uuid.New()
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	err := usecase.AuthorizeCalendarLink(ctx, "9A", &studentID)
+
+	assert.ErrorIs(t, err, domain.ErrCalendarForbidden)
+	mockService.AssertNotCalled(t, "TeachesClass", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAssignTasksBulkSkipsTemplatesCallerCannotUse(t *testing.T) {
+	userID := uuid.New()
+	authorID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	usable := domain.BulkAssignment{TaskID: uuid.New(), Class: "9A", LessonID: uuid.New()}
+	viewOnly := domain.BulkAssignment{TaskID: uuid.New(), Class: "9A", LessonID: uuid.New()}
+	bulk := &domain.BulkAssignments{
+		Mode:  domain.BulkModeBestEffort,
+		Items: []domain.BulkAssignment{viewOnly, usable},
+		Draft: true,
+	}
+	created := domain.Assignment{AssignmentID: uuid.New(), Class: "9A", LessonID: usable.LessonID, Status: domain.AssignmentStatusDraft}
+
+	mockService.On("GetTaskAccess", ctx, []uuid.UUID{viewOnly.TaskID, usable.TaskID}, &userID).Return(map[uuid.UUID]domain.TaskAccess{
+		viewOnly.TaskID: {AuthorID: &authorID, Shared: domain.PermissionView},
+		usable.TaskID:   {AuthorID: &authorID, Shared: domain.PermissionUse},
+	}, nil)
+	validReferences(ctx, mockService)
+	mockService.On("CreateAssignmentsBulk", ctx, mock.MatchedBy(func(allowed *domain.BulkAssignments) bool {
+		return len(allowed.Items) == 1 && allowed.Items[0] == usable
+	})).Return([]domain.Assignment{created}, []error{nil}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	result, err := usecase.AssignTasksBulk(ctx, bulk)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.BulkItemFailed, result.Items[0].Status)
+	assert.ErrorIs(t, result.Items[0].Err, domain.ErrForbidden)
+	assert.Equal(t, domain.BulkItemDone, result.Items[1].Status)
+	assert.Equal(t, created.AssignmentID, result.Items[1].ID)
+}
+
+func TestUpdateTaskSharedForViewIsForbidden(t *testing.T) {
+	userID := uuid.New()
+	authorID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	task := &domain.Task{ID: uuid.New(), Payload: "5+5 = ?"}
+
+	mockService.On("GetTaskAccess", ctx, []uuid.UUID{task.ID}, &userID).Return(map[uuid.UUID]domain.TaskAccess{
+		task.ID: {AuthorID: &authorID, Shared: domain.PermissionView},
+	}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	_, err := usecase.UpdateTask(ctx, task)
+
+	assert.ErrorIs(t, err, domain.ErrForbidden)
+	mockService.AssertNotCalled(t, "UpdateTask", mock.Anything, mock.Anything)
+}
+
+func TestCreateAssignmentsRejectsLessonOfAnotherClass(t *testing.T) {
+	userID := uuid.New()
+	ctx := domain.ContextWithUserID(context.Background(), userID)
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	taskAssignments := &domain.TaskAsignments{
+		TaskID:   uuid.New(),
+		ToAssign: []domain.ClassLesson{{Class: " 9a", LessonID: uuid.New()}},
+	}
+
+	openTasks(ctx, mockService, &userID, taskAssignments.TaskID)
+	mockService.On("ValidateClassLessons", ctx, []domain.ClassLesson{{Class: "9A", LessonID: taskAssignments.ToAssign[0].LessonID}}).
+		Return([]error{domain.ErrLessonNotFound}, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	_, err := usecase.CreateAssignments(ctx, taskAssignments)
+
+	assert.ErrorIs(t, err, domain.ErrLessonNotFound)
+	mockService.AssertNotCalled(t, "CreateAssignments", mock.Anything, mock.Anything)
+}
+
+// validReferences makes every class and lesson reference valid.
+func validReferences(ctx context.Context, mockService *repoMock.Database) {
+	mockService.On("ValidateClassLessons", ctx, mock.Anything).Return(func(_ context.Context, pairs []domain.ClassLesson) []error {
+		return make([]error, len(pairs))
+	}, nil)
+}
+
+// openTasks makes the templates open to every user, as templates without an author are.
+func openTasks(ctx context.Context, mockService *repoMock.Database, callerID *uuid.UUID, ids ...uuid.UUID) {
+	access := make(map[uuid.UUID]domain.TaskAccess, len(ids))
+	for _, id := range ids {
+		access[id] = domain.TaskAccess{}
+	}
+	mockService.On("GetTaskAccess", ctx, ids, callerID).Return(access, nil)
+}
+
+func TestGetLessonsByClassesGroupsByNormalizedClass(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	lessons := []domain.Lesson{
+		{ID: uuid.New(), Class: "5A"},
+		{ID: uuid.New(), Class: "9A"},
+		{ID: uuid.New(), Class: "9A"},
+	}
+	mockService.On("GetLessonsByClasses", ctx, []string{"9A", "5A"}).Return(lessons, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	result, err := usecase.GetLessonsByClasses(ctx, []string{" 9a", "5A"})
+
+	require.NoError(t, err)
+	assert.Equal(t, lessons[1:], result["9A"])
+	assert.Equal(t, lessons[:1], result["5A"])
+}
+
+func TestGetMarksByAssignmentsGroupsByAssignment(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	first, second := uuid.New(), uuid.New()
+	marks := []domain.GradebookEntry{
+		{TaskID: first, StudentID: uuid.New()},
+		{TaskID: first, StudentID: uuid.New()},
+		{TaskID: second, StudentID: uuid.New()},
+	}
+	mockService.On("GetMarksByAssignments", ctx, []uuid.UUID{first, second}).Return(marks, nil)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	result, err := usecase.GetMarksByAssignments(ctx, []uuid.UUID{first, second})
+
+	require.NoError(t, err)
+	assert.Equal(t, marks[:2], result[first])
+	assert.Equal(t, marks[2:], result[second])
+}
+
+func TestGetAssignmentNotFound(t *testing.T) {
+	ctx := context.Background()
+	mockService := new(repoMock.Database)
+	cacheMock := new(repoMock.Cache)
+	producerMock := new(repoMock.Producer)
+	id := uuid.New()
+
+	mockService.On("GetAssignment", ctx, id).Return(nil, domain.ErrAssignmentNotFound)
+	logger := app.InitLogger()
+	usecase := services.New(logger, mockService, cacheMock, producerMock)
+
+	_, err := usecase.GetAssignment(ctx, id)
+
+	assert.ErrorIs(t, err, domain.ErrAssignmentNotFound)
+	domainErr, ok := domain.AsError(err)
+	require.True(t, ok)
+	assert.Equal(t, domain.KindNotFound, domainErr.Kind)
 }
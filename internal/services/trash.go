@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"task/internal/domain"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// GetTrash returns the deleted templates the caller may see and the deleted assignments of those templates.
+func (u *TaskService) GetTrash(ctx context.Context) (*domain.Trash, error) {
+	trash, err := u.db.GetTrash(ctx, callerID(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed get trash: %w", err)
+	}
+
+	return trash, nil
+}
+
+// RestoreTask takes the task out of the trash. Only the author restores a template.
+func (u *TaskService) RestoreTask(ctx context.Context, id uuid.UUID) error {
+	if err := u.authorize(ctx, id, domain.PermissionOwner); err != nil {
+		return err
+	}
+
+	err := u.db.RestoreTask(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed restore task: %w", err)
+	}
+
+	return nil
+}
+
+// RestoreAssignment takes the assignment out of the trash. It takes the same permission on the template
+// as assigning it anew.
+func (u *TaskService) RestoreAssignment(ctx context.Context, assignmentID uuid.UUID) error {
+	assignment, err := u.db.GetDeletedAssignment(ctx, assignmentID)
+	if err != nil {
+		return fmt.Errorf("failed get deleted assignment: %w", err)
+	}
+
+	if err := u.authorize(ctx, assignment.TaskID, domain.PermissionUse); err != nil {
+		return err
+	}
+
+	err = u.db.RestoreAssignment(ctx, assignmentID)
+	if err != nil {
+		return fmt.Errorf("failed restore assignment: %w", err)
+	}
+
+	return nil
+}
+
+// PurgeTrash permanently deletes tasks and assignments that have been in the trash longer than retention.
+func (u *TaskService) PurgeTrash(ctx context.Context, retention time.Duration) (int, error) {
+	purged, err := u.db.PurgeTrash(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed purge trash: %w", err)
+	}
+
+	return int(purged), nil
+}
@@ -0,0 +1,46 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type RecurrenceMaterializer interface {
+	MaterializeRecurrences(ctx context.Context, horizon time.Duration) (int, error)
+}
+
+// Materializer periodically creates upcoming occurrences of recurring assignments.
+type Materializer struct {
+	service  RecurrenceMaterializer
+	interval time.Duration
+	horizon  time.Duration
+	logger   *slog.Logger
+}
+
+func NewMaterializer(service RecurrenceMaterializer, interval, horizon time.Duration, logger *slog.Logger) *Materializer {
+	return &Materializer{
+		service:  service,
+		interval: interval,
+		horizon:  horizon,
+		logger:   logger,
+	}
+}
+
+func (m *Materializer) Run(ctx context.Context) error {
+	m.materialize(ctx)
+
+	return runPeriodically(ctx, m.interval, m.materialize)
+}
+
+func (m *Materializer) materialize(ctx context.Context) {
+	created, err := m.service.MaterializeRecurrences(ctx, m.horizon)
+	if err != nil {
+		m.logger.Error("failed to materialize recurrences", slog.String("error", err.Error()))
+		return
+	}
+
+	if created > 0 {
+		m.logger.Info("materialized recurring assignments", slog.Int("count", created))
+	}
+}
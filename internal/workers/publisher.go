@@ -0,0 +1,42 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type AssignmentPublisher interface {
+	PublishDueAssignments(ctx context.Context) (int, error)
+}
+
+// Publisher periodically publishes scheduled draft assignments.
+type Publisher struct {
+	service  AssignmentPublisher
+	interval time.Duration
+	logger   *slog.Logger
+}
+
+func NewPublisher(service AssignmentPublisher, interval time.Duration, logger *slog.Logger) *Publisher {
+	return &Publisher{
+		service:  service,
+		interval: interval,
+		logger:   logger,
+	}
+}
+
+func (p *Publisher) Run(ctx context.Context) error {
+	return runPeriodically(ctx, p.interval, p.publish)
+}
+
+func (p *Publisher) publish(ctx context.Context) {
+	published, err := p.service.PublishDueAssignments(ctx)
+	if err != nil {
+		p.logger.Error("failed to publish due assignments", slog.String("error", err.Error()))
+		return
+	}
+
+	if published > 0 {
+		p.logger.Info("published scheduled assignments", slog.Int("count", published))
+	}
+}
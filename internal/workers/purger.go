@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type TrashPurger interface {
+	PurgeTrash(ctx context.Context, retention time.Duration) (int, error)
+}
+
+// Purger periodically removes expired records from the trash.
+type Purger struct {
+	service   TrashPurger
+	interval  time.Duration
+	retention time.Duration
+	logger    *slog.Logger
+}
+
+func NewPurger(service TrashPurger, interval, retention time.Duration, logger *slog.Logger) *Purger {
+	return &Purger{
+		service:   service,
+		interval:  interval,
+		retention: retention,
+		logger:    logger,
+	}
+}
+
+func (p *Purger) Run(ctx context.Context) error {
+	return runPeriodically(ctx, p.interval, p.purge)
+}
+
+func (p *Purger) purge(ctx context.Context) {
+	purged, err := p.service.PurgeTrash(ctx, p.retention)
+	if err != nil {
+		p.logger.Error("failed to purge trash", slog.String("error", err.Error()))
+		return
+	}
+
+	if purged > 0 {
+		p.logger.Info("purged trash", slog.Int("count", purged))
+	}
+}
@@ -0,0 +1,44 @@
+package workers
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+type DeadlineReminderSender interface {
+	SendDeadlineReminders(ctx context.Context, windows []time.Duration) (int, error)
+}
+
+// Reminder periodically emits deadline reminder events.
+type Reminder struct {
+	service  DeadlineReminderSender
+	interval time.Duration
+	windows  []time.Duration
+	logger   *slog.Logger
+}
+
+func NewReminder(service DeadlineReminderSender, interval time.Duration, windows []time.Duration, logger *slog.Logger) *Reminder {
+	return &Reminder{
+		service:  service,
+		interval: interval,
+		windows:  windows,
+		logger:   logger,
+	}
+}
+
+func (r *Reminder) Run(ctx context.Context) error {
+	return runPeriodically(ctx, r.interval, r.remind)
+}
+
+func (r *Reminder) remind(ctx context.Context) {
+	sent, err := r.service.SendDeadlineReminders(ctx, r.windows)
+	if err != nil {
+		r.logger.Error("failed to send deadline reminders", slog.String("error", err.Error()))
+		return
+	}
+
+	if sent > 0 {
+		r.logger.Info("sent deadline reminders", slog.Int("count", sent))
+	}
+}
@@ -0,0 +1,21 @@
+package workers
+
+import (
+	"context"
+	"time"
+)
+
+// runPeriodically calls job every interval until ctx is cancelled.
+func runPeriodically(ctx context.Context, interval time.Duration, job func(ctx context.Context)) error {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			job(ctx)
+		}
+	}
+}
@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE usersMark
+    DROP COLUMN IF EXISTS penalty,
+    DROP COLUMN IF EXISTS submitted_at;
+
+DROP TABLE IF EXISTS deadline_extension;
+
+ALTER TABLE assignment
+    DROP COLUMN IF EXISTS late_penalty_per_day,
+    DROP COLUMN IF EXISTS late_policy;
+
+END;
@@ -0,0 +1,20 @@
+BEGIN;
+
+ALTER TABLE assignment
+    ADD COLUMN IF NOT EXISTS late_policy TEXT NOT NULL DEFAULT 'accept',
+    ADD COLUMN IF NOT EXISTS late_penalty_per_day int NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS deadline_extension(
+   assignment_id uuid NOT NULL,
+   user_id uuid NOT NULL,
+   deadline timestamptz NOT NULL,
+
+   FOREIGN KEY (assignment_id) REFERENCES assignment (id) ON DELETE CASCADE,
+   PRIMARY KEY (assignment_id, user_id)
+);
+
+ALTER TABLE usersMark
+    ADD COLUMN IF NOT EXISTS submitted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS penalty int NOT NULL DEFAULT 0;
+
+END;
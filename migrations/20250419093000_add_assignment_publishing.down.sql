@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP INDEX IF EXISTS assignment_status_publish_at_idx;
+
+ALTER TABLE assignment
+    DROP COLUMN IF EXISTS publish_at,
+    DROP COLUMN IF EXISTS status;
+
+END;
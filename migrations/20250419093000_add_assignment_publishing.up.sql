@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE assignment
+    ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published',
+    ADD COLUMN IF NOT EXISTS publish_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS assignment_status_publish_at_idx ON assignment (status, publish_at);
+
+END;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS assignment_deadline_idx;
+DROP TABLE IF EXISTS deadline_notification;
+
+END;
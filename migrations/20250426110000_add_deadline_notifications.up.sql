@@ -0,0 +1,19 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS deadline_notification(
+   assignment_id uuid NOT NULL,
+   kind TEXT NOT NULL,
+   sent_at timestamptz NOT NULL DEFAULT now(),
+
+   FOREIGN KEY (assignment_id) REFERENCES assignment (id) ON DELETE CASCADE,
+   PRIMARY KEY (assignment_id, kind)
+);
+
+CREATE INDEX IF NOT EXISTS assignment_deadline_idx ON assignment (deadline);
+
+-- Deadlines that passed before reminders existed must not produce a burst of stale events.
+INSERT INTO deadline_notification (assignment_id, kind)
+SELECT id, 'passed' FROM assignment WHERE deadline <= now()
+ON CONFLICT DO NOTHING;
+
+END;
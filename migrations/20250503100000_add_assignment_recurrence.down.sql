@@ -0,0 +1,20 @@
+BEGIN;
+
+DELETE FROM assignment WHERE recurrence_id IS NOT NULL;
+
+DROP INDEX IF EXISTS assignment_recurrence_occurrence_key;
+DROP INDEX IF EXISTS assignment_class_lesson_id_task_id_key;
+DROP INDEX IF EXISTS assignment_lesson_id_task_id_key;
+
+ALTER TABLE assignment
+    ADD CONSTRAINT assignment_lesson_id_task_id_key UNIQUE (lesson_id, task_id),
+    ADD CONSTRAINT assignment_class_lesson_id_task_id_key UNIQUE (class, lesson_id, task_id);
+
+ALTER TABLE assignment
+    DROP COLUMN IF EXISTS occurrence_at,
+    DROP COLUMN IF EXISTS recurrence_id;
+
+DROP TABLE IF EXISTS assignment_recurrence_exception;
+DROP TABLE IF EXISTS assignment_recurrence;
+
+END;
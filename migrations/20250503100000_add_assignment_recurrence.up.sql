@@ -0,0 +1,42 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS assignment_recurrence(
+   id uuid PRIMARY KEY,
+   task_id uuid NOT NULL,
+   class TEXT NOT NULL,
+   lesson_id uuid NOT NULL,
+   rrule TEXT NOT NULL,
+   starts_at timestamptz NOT NULL,
+   timezone TEXT NOT NULL DEFAULT 'UTC',
+   deadline_offset_seconds bigint,
+   materialized_until timestamptz,
+   stopped_at timestamptz,
+
+   FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS assignment_recurrence_exception(
+   recurrence_id uuid NOT NULL,
+   occurrence_at timestamptz NOT NULL,
+   skipped boolean NOT NULL DEFAULT false,
+   payload TEXT,
+   deadline timestamptz,
+
+   FOREIGN KEY (recurrence_id) REFERENCES assignment_recurrence (id) ON DELETE CASCADE,
+   PRIMARY KEY (recurrence_id, occurrence_at)
+);
+
+ALTER TABLE assignment
+    ADD COLUMN IF NOT EXISTS recurrence_id uuid REFERENCES assignment_recurrence (id) ON DELETE CASCADE,
+    ADD COLUMN IF NOT EXISTS occurrence_at timestamptz;
+
+-- Occurrences of one recurrence share class, lesson and task, so uniqueness moves to (recurrence_id, occurrence_at) for them.
+ALTER TABLE assignment
+    DROP CONSTRAINT IF EXISTS assignment_lesson_id_task_id_key,
+    DROP CONSTRAINT IF EXISTS assignment_class_lesson_id_task_id_key;
+
+CREATE UNIQUE INDEX IF NOT EXISTS assignment_lesson_id_task_id_key ON assignment (lesson_id, task_id) WHERE recurrence_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS assignment_class_lesson_id_task_id_key ON assignment (class, lesson_id, task_id) WHERE recurrence_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS assignment_recurrence_occurrence_key ON assignment (recurrence_id, occurrence_at) WHERE recurrence_id IS NOT NULL;
+
+END;
@@ -0,0 +1,25 @@
+BEGIN;
+
+DELETE FROM assignment WHERE deleted_at IS NOT NULL;
+DELETE FROM task WHERE deleted_at IS NOT NULL;
+
+DROP INDEX IF EXISTS assignment_recurrence_occurrence_key;
+DROP INDEX IF EXISTS assignment_class_lesson_id_task_id_key;
+DROP INDEX IF EXISTS assignment_lesson_id_task_id_key;
+
+CREATE UNIQUE INDEX assignment_lesson_id_task_id_key ON assignment (lesson_id, task_id) WHERE recurrence_id IS NULL;
+CREATE UNIQUE INDEX assignment_class_lesson_id_task_id_key ON assignment (class, lesson_id, task_id) WHERE recurrence_id IS NULL;
+CREATE UNIQUE INDEX assignment_recurrence_occurrence_key ON assignment (recurrence_id, occurrence_at) WHERE recurrence_id IS NOT NULL;
+
+DROP INDEX IF EXISTS assignment_deleted_at_idx;
+DROP INDEX IF EXISTS task_deleted_at_idx;
+
+ALTER TABLE assignment
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
+
+ALTER TABLE task
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
+
+END;
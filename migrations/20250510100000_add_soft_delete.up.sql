@@ -0,0 +1,23 @@
+BEGIN;
+
+ALTER TABLE task
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_by uuid;
+
+ALTER TABLE assignment
+    ADD COLUMN IF NOT EXISTS deleted_at timestamptz,
+    ADD COLUMN IF NOT EXISTS deleted_by uuid;
+
+CREATE INDEX IF NOT EXISTS task_deleted_at_idx ON task (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS assignment_deleted_at_idx ON assignment (deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- Assignments in the trash must not block assigning the same task to the same lesson again.
+DROP INDEX IF EXISTS assignment_lesson_id_task_id_key;
+DROP INDEX IF EXISTS assignment_class_lesson_id_task_id_key;
+DROP INDEX IF EXISTS assignment_recurrence_occurrence_key;
+
+CREATE UNIQUE INDEX assignment_lesson_id_task_id_key ON assignment (lesson_id, task_id) WHERE recurrence_id IS NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX assignment_class_lesson_id_task_id_key ON assignment (class, lesson_id, task_id) WHERE recurrence_id IS NULL AND deleted_at IS NULL;
+CREATE UNIQUE INDEX assignment_recurrence_occurrence_key ON assignment (recurrence_id, occurrence_at) WHERE recurrence_id IS NOT NULL AND deleted_at IS NULL;
+
+END;
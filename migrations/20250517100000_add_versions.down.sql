@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE assignment DROP COLUMN IF EXISTS version;
+ALTER TABLE task DROP COLUMN IF EXISTS version;
+
+END;
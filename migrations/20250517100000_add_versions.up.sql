@@ -0,0 +1,6 @@
+BEGIN;
+
+ALTER TABLE task ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1;
+ALTER TABLE assignment ADD COLUMN IF NOT EXISTS version int NOT NULL DEFAULT 1;
+
+END;
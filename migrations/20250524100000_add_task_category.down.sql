@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP INDEX IF EXISTS task_category_idx;
+ALTER TABLE task DROP COLUMN IF EXISTS category;
+
+END;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE task ADD COLUMN IF NOT EXISTS category TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS task_category_idx ON task (category) WHERE category <> '';
+
+END;
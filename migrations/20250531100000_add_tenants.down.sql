@@ -0,0 +1,13 @@
+BEGIN;
+
+DROP INDEX IF EXISTS task_tenant_id_idx;
+DROP INDEX IF EXISTS assignment_tenant_id_class_idx;
+DROP INDEX IF EXISTS usersmark_tenant_id_user_id_idx;
+DROP INDEX IF EXISTS assignment_recurrence_tenant_id_idx;
+
+ALTER TABLE task DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE assignment DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE usersMark DROP COLUMN IF EXISTS tenant_id;
+ALTER TABLE assignment_recurrence DROP COLUMN IF EXISTS tenant_id;
+
+END;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Rows created before schools were separated belong to the default school, the nil UUID.
+ALTER TABLE task ADD COLUMN IF NOT EXISTS tenant_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE assignment ADD COLUMN IF NOT EXISTS tenant_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE usersMark ADD COLUMN IF NOT EXISTS tenant_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+ALTER TABLE assignment_recurrence ADD COLUMN IF NOT EXISTS tenant_id uuid NOT NULL DEFAULT '00000000-0000-0000-0000-000000000000';
+
+-- New rows have to name their school.
+ALTER TABLE task ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE assignment ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE usersMark ALTER COLUMN tenant_id DROP DEFAULT;
+ALTER TABLE assignment_recurrence ALTER COLUMN tenant_id DROP DEFAULT;
+
+CREATE INDEX IF NOT EXISTS task_tenant_id_idx ON task (tenant_id);
+CREATE INDEX IF NOT EXISTS assignment_tenant_id_class_idx ON assignment (tenant_id, class);
+CREATE INDEX IF NOT EXISTS usersmark_tenant_id_user_id_idx ON usersMark (tenant_id, user_id);
+CREATE INDEX IF NOT EXISTS assignment_recurrence_tenant_id_idx ON assignment_recurrence (tenant_id);
+
+END;
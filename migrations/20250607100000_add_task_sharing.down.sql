@@ -0,0 +1,9 @@
+BEGIN;
+
+DROP TABLE IF EXISTS task_share;
+
+DROP INDEX IF EXISTS task_tenant_id_author_id_idx;
+
+ALTER TABLE task DROP COLUMN IF EXISTS author_id;
+
+END;
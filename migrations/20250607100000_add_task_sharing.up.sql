@@ -0,0 +1,21 @@
+BEGIN;
+
+-- Templates created before authorship was recorded have no author and stay open to everyone.
+ALTER TABLE task ADD COLUMN IF NOT EXISTS author_id uuid;
+
+CREATE INDEX IF NOT EXISTS task_tenant_id_author_id_idx ON task (tenant_id, author_id);
+
+-- A share with an empty user_id is granted to the whole school of the task.
+CREATE TABLE IF NOT EXISTS task_share(
+   task_id uuid NOT NULL,
+   user_id uuid,
+   permission TEXT NOT NULL CHECK (permission IN ('view', 'use', 'edit')),
+   created_at timestamptz NOT NULL DEFAULT now(),
+
+   FOREIGN KEY (task_id) REFERENCES task (id) ON DELETE CASCADE,
+   UNIQUE NULLS NOT DISTINCT (task_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS task_share_user_id_idx ON task_share (user_id);
+
+END;
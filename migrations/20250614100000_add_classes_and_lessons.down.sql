@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE assignment_recurrence DROP CONSTRAINT IF EXISTS assignment_recurrence_lesson_id_fkey;
+ALTER TABLE assignment DROP CONSTRAINT IF EXISTS assignment_lesson_id_fkey;
+
+DROP INDEX IF EXISTS assignment_class_id_idx;
+
+ALTER TABLE assignment DROP COLUMN IF EXISTS class_id;
+
+DROP TABLE IF EXISTS lesson;
+DROP TABLE IF EXISTS class;
+
+END;
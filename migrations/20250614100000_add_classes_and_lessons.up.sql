@@ -0,0 +1,77 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS class(
+   id uuid PRIMARY KEY,
+   tenant_id uuid NOT NULL,
+   name TEXT NOT NULL,
+   created_at timestamptz NOT NULL DEFAULT now(),
+
+   UNIQUE (tenant_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS lesson(
+   id uuid PRIMARY KEY,
+   tenant_id uuid NOT NULL,
+   class_id uuid NOT NULL,
+   subject TEXT NOT NULL DEFAULT '',
+   starts_at timestamptz,
+   created_at timestamptz NOT NULL DEFAULT now(),
+
+   FOREIGN KEY (class_id) REFERENCES class (id)
+);
+
+CREATE INDEX IF NOT EXISTS lesson_class_id_idx ON lesson (class_id);
+
+-- Class names were free text, so "5a" and " 5A" were different classes. They are merged under the trimmed
+-- upper case name. Active assignments are unique per (lesson_id, task_id), so merging can't make duplicates.
+UPDATE assignment SET class = upper(btrim(class)) WHERE class <> upper(btrim(class));
+UPDATE assignment_recurrence SET class = upper(btrim(class)) WHERE class <> upper(btrim(class));
+
+INSERT INTO class (id, tenant_id, name)
+SELECT gen_random_uuid(), tenant_id, class FROM (
+    SELECT tenant_id, class FROM assignment
+    UNION
+    SELECT tenant_id, class FROM assignment_recurrence
+) used
+ON CONFLICT DO NOTHING;
+
+CREATE TEMPORARY TABLE used_lesson ON COMMIT DROP AS
+SELECT lesson_id, tenant_id, class FROM assignment
+UNION
+SELECT lesson_id, tenant_id, class FROM assignment_recurrence;
+
+-- A lesson belongs to a single class now. A lesson used with several classes can't be given to one of them
+-- without moving the assignments and marks of the others, so the migration stops and names them all.
+DO $$
+DECLARE
+    conflicts text;
+BEGIN
+    SELECT string_agg(format('%s (%s)', lesson_id, classes), ', ') INTO conflicts FROM (
+        SELECT lesson_id, string_agg(class, ', ' ORDER BY class) AS classes FROM used_lesson
+        GROUP BY lesson_id HAVING count(*) > 1
+    ) shared;
+
+    IF conflicts IS NOT NULL THEN
+        RAISE EXCEPTION 'lessons used by several classes: %', conflicts
+            USING HINT = 'Give every class its own lesson ID in assignment, assignment_recurrence and usersMark, then migrate again.';
+    END IF;
+END $$;
+
+INSERT INTO lesson (id, tenant_id, class_id)
+SELECT used.lesson_id, used.tenant_id, c.id FROM used_lesson used
+JOIN class c ON c.tenant_id = used.tenant_id AND c.name = used.class
+ON CONFLICT DO NOTHING;
+
+-- assignment.class stays as the name of the class for reading, like task_payload does for the task.
+ALTER TABLE assignment ADD COLUMN IF NOT EXISTS class_id uuid REFERENCES class (id);
+
+UPDATE assignment a SET class_id = c.id FROM class c WHERE c.tenant_id = a.tenant_id AND c.name = a.class;
+
+ALTER TABLE assignment ALTER COLUMN class_id SET NOT NULL;
+
+ALTER TABLE assignment ADD CONSTRAINT assignment_lesson_id_fkey FOREIGN KEY (lesson_id) REFERENCES lesson (id);
+ALTER TABLE assignment_recurrence ADD CONSTRAINT assignment_recurrence_lesson_id_fkey FOREIGN KEY (lesson_id) REFERENCES lesson (id);
+
+CREATE INDEX IF NOT EXISTS assignment_class_id_idx ON assignment (class_id);
+
+END;
@@ -8,6 +8,8 @@ import (
 
 	mock "github.com/stretchr/testify/mock"
 
+	time "time"
+
 	uuid "github.com/google/uuid"
 )
 
@@ -24,6 +26,124 @@ func (_m *Database) EXPECT() *Database_Expecter {
 	return &Database_Expecter{mock: &_m.Mock}
 }
 
+// CheckTaskResults provides a mock function with given fields: ctx, taskResults
+func (_m *Database) CheckTaskResults(ctx context.Context, taskResults []*domain.TaskResult) ([]error, error) {
+	ret := _m.Called(ctx, taskResults)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CheckTaskResults")
+	}
+
+	var r0 []error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.TaskResult) ([]error, error)); ok {
+		return rf(ctx, taskResults)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.TaskResult) []error); ok {
+		r0 = rf(ctx, taskResults)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []*domain.TaskResult) error); ok {
+		r1 = rf(ctx, taskResults)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CheckTaskResults_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CheckTaskResults'
+type Database_CheckTaskResults_Call struct {
+	*mock.Call
+}
+
+// CheckTaskResults is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskResults []*domain.TaskResult
+func (_e *Database_Expecter) CheckTaskResults(ctx interface{}, taskResults interface{}) *Database_CheckTaskResults_Call {
+	return &Database_CheckTaskResults_Call{Call: _e.mock.On("CheckTaskResults", ctx, taskResults)}
+}
+
+func (_c *Database_CheckTaskResults_Call) Run(run func(ctx context.Context, taskResults []*domain.TaskResult)) *Database_CheckTaskResults_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*domain.TaskResult))
+	})
+	return _c
+}
+
+func (_c *Database_CheckTaskResults_Call) Return(_a0 []error, _a1 error) *Database_CheckTaskResults_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CheckTaskResults_Call) RunAndReturn(run func(context.Context, []*domain.TaskResult) ([]error, error)) *Database_CheckTaskResults_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ClaimDeadlineReminders provides a mock function with given fields: ctx, now, windows, send
+func (_m *Database) ClaimDeadlineReminders(ctx context.Context, now time.Time, windows []time.Duration, send func([]domain.DeadlineReminder) error) (int, error) {
+	ret := _m.Called(ctx, now, windows, send)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimDeadlineReminders")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, []time.Duration, func([]domain.DeadlineReminder) error) (int, error)); ok {
+		return rf(ctx, now, windows, send)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, []time.Duration, func([]domain.DeadlineReminder) error) int); ok {
+		r0 = rf(ctx, now, windows, send)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, []time.Duration, func([]domain.DeadlineReminder) error) error); ok {
+		r1 = rf(ctx, now, windows, send)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ClaimDeadlineReminders_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ClaimDeadlineReminders'
+type Database_ClaimDeadlineReminders_Call struct {
+	*mock.Call
+}
+
+// ClaimDeadlineReminders is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - windows []time.Duration
+//   - send func([]domain.DeadlineReminder) error
+func (_e *Database_Expecter) ClaimDeadlineReminders(ctx interface{}, now interface{}, windows interface{}, send interface{}) *Database_ClaimDeadlineReminders_Call {
+	return &Database_ClaimDeadlineReminders_Call{Call: _e.mock.On("ClaimDeadlineReminders", ctx, now, windows, send)}
+}
+
+func (_c *Database_ClaimDeadlineReminders_Call) Run(run func(ctx context.Context, now time.Time, windows []time.Duration, send func([]domain.DeadlineReminder) error)) *Database_ClaimDeadlineReminders_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].([]time.Duration), args[3].(func([]domain.DeadlineReminder) error))
+	})
+	return _c
+}
+
+func (_c *Database_ClaimDeadlineReminders_Call) Return(_a0 int, _a1 error) *Database_ClaimDeadlineReminders_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ClaimDeadlineReminders_Call) RunAndReturn(run func(context.Context, time.Time, []time.Duration, func([]domain.DeadlineReminder) error) (int, error)) *Database_ClaimDeadlineReminders_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateAssignments provides a mock function with given fields: ctx, taskAssignments
 func (_m *Database) CreateAssignments(ctx context.Context, taskAssignments *domain.TaskAsignments) ([]domain.Assignment, error) {
 	ret := _m.Called(ctx, taskAssignments)
@@ -83,6 +203,215 @@ func (_c *Database_CreateAssignments_Call) RunAndReturn(run func(context.Context
 	return _c
 }
 
+// CreateAssignmentsBulk provides a mock function with given fields: ctx, bulk
+func (_m *Database) CreateAssignmentsBulk(ctx context.Context, bulk *domain.BulkAssignments) ([]domain.Assignment, []error, error) {
+	ret := _m.Called(ctx, bulk)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAssignmentsBulk")
+	}
+
+	var r0 []domain.Assignment
+	var r1 []error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BulkAssignments) ([]domain.Assignment, []error, error)); ok {
+		return rf(ctx, bulk)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.BulkAssignments) []domain.Assignment); ok {
+		r0 = rf(ctx, bulk)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Assignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.BulkAssignments) []error); ok {
+		r1 = rf(ctx, bulk)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, *domain.BulkAssignments) error); ok {
+		r2 = rf(ctx, bulk)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
+// Database_CreateAssignmentsBulk_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateAssignmentsBulk'
+type Database_CreateAssignmentsBulk_Call struct {
+	*mock.Call
+}
+
+// CreateAssignmentsBulk is a helper method to define mock.On call
+//   - ctx context.Context
+//   - bulk *domain.BulkAssignments
+func (_e *Database_Expecter) CreateAssignmentsBulk(ctx interface{}, bulk interface{}) *Database_CreateAssignmentsBulk_Call {
+	return &Database_CreateAssignmentsBulk_Call{Call: _e.mock.On("CreateAssignmentsBulk", ctx, bulk)}
+}
+
+func (_c *Database_CreateAssignmentsBulk_Call) Run(run func(ctx context.Context, bulk *domain.BulkAssignments)) *Database_CreateAssignmentsBulk_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.BulkAssignments))
+	})
+	return _c
+}
+
+func (_c *Database_CreateAssignmentsBulk_Call) Return(_a0 []domain.Assignment, _a1 []error, _a2 error) *Database_CreateAssignmentsBulk_Call {
+	_c.Call.Return(_a0, _a1, _a2)
+	return _c
+}
+
+func (_c *Database_CreateAssignmentsBulk_Call) RunAndReturn(run func(context.Context, *domain.BulkAssignments) ([]domain.Assignment, []error, error)) *Database_CreateAssignmentsBulk_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateClass provides a mock function with given fields: ctx, class
+func (_m *Database) CreateClass(ctx context.Context, class *domain.Class) error {
+	ret := _m.Called(ctx, class)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateClass")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Class) error); ok {
+		r0 = rf(ctx, class)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateClass'
+type Database_CreateClass_Call struct {
+	*mock.Call
+}
+
+// CreateClass is a helper method to define mock.On call
+//   - ctx context.Context
+//   - class *domain.Class
+func (_e *Database_Expecter) CreateClass(ctx interface{}, class interface{}) *Database_CreateClass_Call {
+	return &Database_CreateClass_Call{Call: _e.mock.On("CreateClass", ctx, class)}
+}
+
+func (_c *Database_CreateClass_Call) Run(run func(ctx context.Context, class *domain.Class)) *Database_CreateClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Class))
+	})
+	return _c
+}
+
+func (_c *Database_CreateClass_Call) Return(_a0 error) *Database_CreateClass_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateClass_Call) RunAndReturn(run func(context.Context, *domain.Class) error) *Database_CreateClass_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateLesson provides a mock function with given fields: ctx, lesson
+func (_m *Database) CreateLesson(ctx context.Context, lesson *domain.Lesson) error {
+	ret := _m.Called(ctx, lesson)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateLesson")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Lesson) error); ok {
+		r0 = rf(ctx, lesson)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateLesson_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateLesson'
+type Database_CreateLesson_Call struct {
+	*mock.Call
+}
+
+// CreateLesson is a helper method to define mock.On call
+//   - ctx context.Context
+//   - lesson *domain.Lesson
+func (_e *Database_Expecter) CreateLesson(ctx interface{}, lesson interface{}) *Database_CreateLesson_Call {
+	return &Database_CreateLesson_Call{Call: _e.mock.On("CreateLesson", ctx, lesson)}
+}
+
+func (_c *Database_CreateLesson_Call) Run(run func(ctx context.Context, lesson *domain.Lesson)) *Database_CreateLesson_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Lesson))
+	})
+	return _c
+}
+
+func (_c *Database_CreateLesson_Call) Return(_a0 error) *Database_CreateLesson_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateLesson_Call) RunAndReturn(run func(context.Context, *domain.Lesson) error) *Database_CreateLesson_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateRecurrence provides a mock function with given fields: ctx, recurrence
+func (_m *Database) CreateRecurrence(ctx context.Context, recurrence *domain.Recurrence) error {
+	ret := _m.Called(ctx, recurrence)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateRecurrence")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.Recurrence) error); ok {
+		r0 = rf(ctx, recurrence)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateRecurrence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateRecurrence'
+type Database_CreateRecurrence_Call struct {
+	*mock.Call
+}
+
+// CreateRecurrence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - recurrence *domain.Recurrence
+func (_e *Database_Expecter) CreateRecurrence(ctx interface{}, recurrence interface{}) *Database_CreateRecurrence_Call {
+	return &Database_CreateRecurrence_Call{Call: _e.mock.On("CreateRecurrence", ctx, recurrence)}
+}
+
+func (_c *Database_CreateRecurrence_Call) Run(run func(ctx context.Context, recurrence *domain.Recurrence)) *Database_CreateRecurrence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Recurrence))
+	})
+	return _c
+}
+
+func (_c *Database_CreateRecurrence_Call) Return(_a0 error) *Database_CreateRecurrence_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateRecurrence_Call) RunAndReturn(run func(context.Context, *domain.Recurrence) error) *Database_CreateRecurrence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // CreateTask provides a mock function with given fields: ctx, task
 func (_m *Database) CreateTask(ctx context.Context, task *domain.Task) (uuid.UUID, error) {
 	ret := _m.Called(ctx, task)
@@ -125,93 +454,2071 @@ func (_e *Database_Expecter) CreateTask(ctx interface{}, task interface{}) *Data
 	return &Database_CreateTask_Call{Call: _e.mock.On("CreateTask", ctx, task)}
 }
 
-func (_c *Database_CreateTask_Call) Run(run func(ctx context.Context, task *domain.Task)) *Database_CreateTask_Call {
+func (_c *Database_CreateTask_Call) Run(run func(ctx context.Context, task *domain.Task)) *Database_CreateTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.Task))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTask_Call) Return(_a0 uuid.UUID, _a1 error) *Database_CreateTask_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateTask_Call) RunAndReturn(run func(context.Context, *domain.Task) (uuid.UUID, error)) *Database_CreateTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTaskWithAssignments provides a mock function with given fields: ctx, assignment
+func (_m *Database) CreateTaskWithAssignments(ctx context.Context, assignment *domain.TaskWithAsignment) (uuid.UUID, error) {
+	ret := _m.Called(ctx, assignment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTaskWithAssignments")
+	}
+
+	var r0 uuid.UUID
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskWithAsignment) (uuid.UUID, error)); ok {
+		return rf(ctx, assignment)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskWithAsignment) uuid.UUID); ok {
+		r0 = rf(ctx, assignment)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(uuid.UUID)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *domain.TaskWithAsignment) error); ok {
+		r1 = rf(ctx, assignment)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_CreateTaskWithAssignments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTaskWithAssignments'
+type Database_CreateTaskWithAssignments_Call struct {
+	*mock.Call
+}
+
+// CreateTaskWithAssignments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignment *domain.TaskWithAsignment
+func (_e *Database_Expecter) CreateTaskWithAssignments(ctx interface{}, assignment interface{}) *Database_CreateTaskWithAssignments_Call {
+	return &Database_CreateTaskWithAssignments_Call{Call: _e.mock.On("CreateTaskWithAssignments", ctx, assignment)}
+}
+
+func (_c *Database_CreateTaskWithAssignments_Call) Run(run func(ctx context.Context, assignment *domain.TaskWithAsignment)) *Database_CreateTaskWithAssignments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.TaskWithAsignment))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTaskWithAssignments_Call) Return(_a0 uuid.UUID, _a1 error) *Database_CreateTaskWithAssignments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_CreateTaskWithAssignments_Call) RunAndReturn(run func(context.Context, *domain.TaskWithAsignment) (uuid.UUID, error)) *Database_CreateTaskWithAssignments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// CreateTasks provides a mock function with given fields: ctx, tasks
+func (_m *Database) CreateTasks(ctx context.Context, tasks []*domain.Task) error {
+	ret := _m.Called(ctx, tasks)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateTasks")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*domain.Task) error); ok {
+		r0 = rf(ctx, tasks)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_CreateTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTasks'
+type Database_CreateTasks_Call struct {
+	*mock.Call
+}
+
+// CreateTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - tasks []*domain.Task
+func (_e *Database_Expecter) CreateTasks(ctx interface{}, tasks interface{}) *Database_CreateTasks_Call {
+	return &Database_CreateTasks_Call{Call: _e.mock.On("CreateTasks", ctx, tasks)}
+}
+
+func (_c *Database_CreateTasks_Call) Run(run func(ctx context.Context, tasks []*domain.Task)) *Database_CreateTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]*domain.Task))
+	})
+	return _c
+}
+
+func (_c *Database_CreateTasks_Call) Return(_a0 error) *Database_CreateTasks_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_CreateTasks_Call) RunAndReturn(run func(context.Context, []*domain.Task) error) *Database_CreateTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAssignment provides a mock function with given fields: ctx, assignmentID, version, deletedBy
+func (_m *Database) DeleteAssignment(ctx context.Context, assignmentID uuid.UUID, version int, deletedBy *uuid.UUID) error {
+	ret := _m.Called(ctx, assignmentID, version, deletedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAssignment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, *uuid.UUID) error); ok {
+		r0 = rf(ctx, assignmentID, version, deletedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAssignment'
+type Database_DeleteAssignment_Call struct {
+	*mock.Call
+}
+
+// DeleteAssignment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignmentID uuid.UUID
+//   - version int
+//   - deletedBy *uuid.UUID
+func (_e *Database_Expecter) DeleteAssignment(ctx interface{}, assignmentID interface{}, version interface{}, deletedBy interface{}) *Database_DeleteAssignment_Call {
+	return &Database_DeleteAssignment_Call{Call: _e.mock.On("DeleteAssignment", ctx, assignmentID, version, deletedBy)}
+}
+
+func (_c *Database_DeleteAssignment_Call) Run(run func(ctx context.Context, assignmentID uuid.UUID, version int, deletedBy *uuid.UUID)) *Database_DeleteAssignment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteAssignment_Call) Return(_a0 error) *Database_DeleteAssignment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteAssignment_Call) RunAndReturn(run func(context.Context, uuid.UUID, int, *uuid.UUID) error) *Database_DeleteAssignment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteAssignments provides a mock function with given fields: ctx, mode, assignmentIDs, deletedBy
+func (_m *Database) DeleteAssignments(ctx context.Context, mode domain.BulkMode, assignmentIDs []uuid.UUID, deletedBy *uuid.UUID) ([]error, error) {
+	ret := _m.Called(ctx, mode, assignmentIDs, deletedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteAssignments")
+	}
+
+	var r0 []error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkMode, []uuid.UUID, *uuid.UUID) ([]error, error)); ok {
+		return rf(ctx, mode, assignmentIDs, deletedBy)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkMode, []uuid.UUID, *uuid.UUID) []error); ok {
+		r0 = rf(ctx, mode, assignmentIDs, deletedBy)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BulkMode, []uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(ctx, mode, assignmentIDs, deletedBy)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_DeleteAssignments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAssignments'
+type Database_DeleteAssignments_Call struct {
+	*mock.Call
+}
+
+// DeleteAssignments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - mode domain.BulkMode
+//   - assignmentIDs []uuid.UUID
+//   - deletedBy *uuid.UUID
+func (_e *Database_Expecter) DeleteAssignments(ctx interface{}, mode interface{}, assignmentIDs interface{}, deletedBy interface{}) *Database_DeleteAssignments_Call {
+	return &Database_DeleteAssignments_Call{Call: _e.mock.On("DeleteAssignments", ctx, mode, assignmentIDs, deletedBy)}
+}
+
+func (_c *Database_DeleteAssignments_Call) Run(run func(ctx context.Context, mode domain.BulkMode, assignmentIDs []uuid.UUID, deletedBy *uuid.UUID)) *Database_DeleteAssignments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(domain.BulkMode), args[2].([]uuid.UUID), args[3].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteAssignments_Call) Return(_a0 []error, _a1 error) *Database_DeleteAssignments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_DeleteAssignments_Call) RunAndReturn(run func(context.Context, domain.BulkMode, []uuid.UUID, *uuid.UUID) ([]error, error)) *Database_DeleteAssignments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteDeadlineExtension provides a mock function with given fields: ctx, assignmentID, userID
+func (_m *Database) DeleteDeadlineExtension(ctx context.Context, assignmentID uuid.UUID, userID uuid.UUID) error {
+	ret := _m.Called(ctx, assignmentID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteDeadlineExtension")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, uuid.UUID) error); ok {
+		r0 = rf(ctx, assignmentID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteDeadlineExtension_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteDeadlineExtension'
+type Database_DeleteDeadlineExtension_Call struct {
+	*mock.Call
+}
+
+// DeleteDeadlineExtension is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignmentID uuid.UUID
+//   - userID uuid.UUID
+func (_e *Database_Expecter) DeleteDeadlineExtension(ctx interface{}, assignmentID interface{}, userID interface{}) *Database_DeleteDeadlineExtension_Call {
+	return &Database_DeleteDeadlineExtension_Call{Call: _e.mock.On("DeleteDeadlineExtension", ctx, assignmentID, userID)}
+}
+
+func (_c *Database_DeleteDeadlineExtension_Call) Run(run func(ctx context.Context, assignmentID uuid.UUID, userID uuid.UUID)) *Database_DeleteDeadlineExtension_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteDeadlineExtension_Call) Return(_a0 error) *Database_DeleteDeadlineExtension_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteDeadlineExtension_Call) RunAndReturn(run func(context.Context, uuid.UUID, uuid.UUID) error) *Database_DeleteDeadlineExtension_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTask provides a mock function with given fields: ctx, id, version, deletedBy
+func (_m *Database) DeleteTask(ctx context.Context, id uuid.UUID, version int, deletedBy *uuid.UUID) error {
+	ret := _m.Called(ctx, id, version, deletedBy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, int, *uuid.UUID) error); ok {
+		r0 = rf(ctx, id, version, deletedBy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTask'
+type Database_DeleteTask_Call struct {
+	*mock.Call
+}
+
+// DeleteTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+//   - version int
+//   - deletedBy *uuid.UUID
+func (_e *Database_Expecter) DeleteTask(ctx interface{}, id interface{}, version interface{}, deletedBy interface{}) *Database_DeleteTask_Call {
+	return &Database_DeleteTask_Call{Call: _e.mock.On("DeleteTask", ctx, id, version, deletedBy)}
+}
+
+func (_c *Database_DeleteTask_Call) Run(run func(ctx context.Context, id uuid.UUID, version int, deletedBy *uuid.UUID)) *Database_DeleteTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(int), args[3].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteTask_Call) Return(_a0 error) *Database_DeleteTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteTask_Call) RunAndReturn(run func(context.Context, uuid.UUID, int, *uuid.UUID) error) *Database_DeleteTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// DeleteTaskShare provides a mock function with given fields: ctx, taskID, userID
+func (_m *Database) DeleteTaskShare(ctx context.Context, taskID uuid.UUID, userID *uuid.UUID) error {
+	ret := _m.Called(ctx, taskID, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteTaskShare")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, *uuid.UUID) error); ok {
+		r0 = rf(ctx, taskID, userID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_DeleteTaskShare_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTaskShare'
+type Database_DeleteTaskShare_Call struct {
+	*mock.Call
+}
+
+// DeleteTaskShare is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID uuid.UUID
+//   - userID *uuid.UUID
+func (_e *Database_Expecter) DeleteTaskShare(ctx interface{}, taskID interface{}, userID interface{}) *Database_DeleteTaskShare_Call {
+	return &Database_DeleteTaskShare_Call{Call: _e.mock.On("DeleteTaskShare", ctx, taskID, userID)}
+}
+
+func (_c *Database_DeleteTaskShare_Call) Run(run func(ctx context.Context, taskID uuid.UUID, userID *uuid.UUID)) *Database_DeleteTaskShare_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_DeleteTaskShare_Call) Return(_a0 error) *Database_DeleteTaskShare_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_DeleteTaskShare_Call) RunAndReturn(run func(context.Context, uuid.UUID, *uuid.UUID) error) *Database_DeleteTaskShare_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignment provides a mock function with given fields: ctx, assignmentID
+func (_m *Database) GetAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.TaskAsignment, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignment")
+	}
+
+	var r0 *domain.TaskAsignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.TaskAsignment, error)); ok {
+		return rf(ctx, assignmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.TaskAsignment); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.TaskAsignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignment'
+type Database_GetAssignment_Call struct {
+	*mock.Call
+}
+
+// GetAssignment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignmentID uuid.UUID
+func (_e *Database_Expecter) GetAssignment(ctx interface{}, assignmentID interface{}) *Database_GetAssignment_Call {
+	return &Database_GetAssignment_Call{Call: _e.mock.On("GetAssignment", ctx, assignmentID)}
+}
+
+func (_c *Database_GetAssignment_Call) Run(run func(ctx context.Context, assignmentID uuid.UUID)) *Database_GetAssignment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetAssignment_Call) Return(_a0 *domain.TaskAsignment, _a1 error) *Database_GetAssignment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAssignment_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*domain.TaskAsignment, error)) *Database_GetAssignment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignmentAccess provides a mock function with given fields: ctx, assignmentIDs, userID
+func (_m *Database) GetAssignmentAccess(ctx context.Context, assignmentIDs []uuid.UUID, userID *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error) {
+	ret := _m.Called(ctx, assignmentIDs, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignmentAccess")
+	}
+
+	var r0 map[uuid.UUID]domain.TaskAccess
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error)); ok {
+		return rf(ctx, assignmentIDs, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *uuid.UUID) map[uuid.UUID]domain.TaskAccess); ok {
+		r0 = rf(ctx, assignmentIDs, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]domain.TaskAccess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(ctx, assignmentIDs, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAssignmentAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignmentAccess'
+type Database_GetAssignmentAccess_Call struct {
+	*mock.Call
+}
+
+// GetAssignmentAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignmentIDs []uuid.UUID
+//   - userID *uuid.UUID
+func (_e *Database_Expecter) GetAssignmentAccess(ctx interface{}, assignmentIDs interface{}, userID interface{}) *Database_GetAssignmentAccess_Call {
+	return &Database_GetAssignmentAccess_Call{Call: _e.mock.On("GetAssignmentAccess", ctx, assignmentIDs, userID)}
+}
+
+func (_c *Database_GetAssignmentAccess_Call) Run(run func(ctx context.Context, assignmentIDs []uuid.UUID, userID *uuid.UUID)) *Database_GetAssignmentAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID), args[2].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetAssignmentAccess_Call) Return(_a0 map[uuid.UUID]domain.TaskAccess, _a1 error) *Database_GetAssignmentAccess_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAssignmentAccess_Call) RunAndReturn(run func(context.Context, []uuid.UUID, *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error)) *Database_GetAssignmentAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetAssignmentDeadline provides a mock function with given fields: ctx, assignmentID
+func (_m *Database) GetAssignmentDeadline(ctx context.Context, assignmentID uuid.UUID) (*domain.AssignmentDeadline, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAssignmentDeadline")
+	}
+
+	var r0 *domain.AssignmentDeadline
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.AssignmentDeadline, error)); ok {
+		return rf(ctx, assignmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.AssignmentDeadline); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.AssignmentDeadline)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetAssignmentDeadline_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetAssignmentDeadline'
+type Database_GetAssignmentDeadline_Call struct {
+	*mock.Call
+}
+
+// GetAssignmentDeadline is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignmentID uuid.UUID
+func (_e *Database_Expecter) GetAssignmentDeadline(ctx interface{}, assignmentID interface{}) *Database_GetAssignmentDeadline_Call {
+	return &Database_GetAssignmentDeadline_Call{Call: _e.mock.On("GetAssignmentDeadline", ctx, assignmentID)}
+}
+
+func (_c *Database_GetAssignmentDeadline_Call) Run(run func(ctx context.Context, assignmentID uuid.UUID)) *Database_GetAssignmentDeadline_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetAssignmentDeadline_Call) Return(_a0 *domain.AssignmentDeadline, _a1 error) *Database_GetAssignmentDeadline_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetAssignmentDeadline_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*domain.AssignmentDeadline, error)) *Database_GetAssignmentDeadline_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetClasses provides a mock function with given fields: ctx
+func (_m *Database) GetClasses(ctx context.Context) ([]domain.Class, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetClasses")
+	}
+
+	var r0 []domain.Class
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]domain.Class, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []domain.Class); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Class)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetClasses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetClasses'
+type Database_GetClasses_Call struct {
+	*mock.Call
+}
+
+// GetClasses is a helper method to define mock.On call
+//   - ctx context.Context
+func (_e *Database_Expecter) GetClasses(ctx interface{}) *Database_GetClasses_Call {
+	return &Database_GetClasses_Call{Call: _e.mock.On("GetClasses", ctx)}
+}
+
+func (_c *Database_GetClasses_Call) Run(run func(ctx context.Context)) *Database_GetClasses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context))
+	})
+	return _c
+}
+
+func (_c *Database_GetClasses_Call) Return(_a0 []domain.Class, _a1 error) *Database_GetClasses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetClasses_Call) RunAndReturn(run func(context.Context) ([]domain.Class, error)) *Database_GetClasses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetDeletedAssignment provides a mock function with given fields: ctx, assignmentID
+func (_m *Database) GetDeletedAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.DeletedAssignment, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDeletedAssignment")
+	}
+
+	var r0 *domain.DeletedAssignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.DeletedAssignment, error)); ok {
+		return rf(ctx, assignmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.DeletedAssignment); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.DeletedAssignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetDeletedAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetDeletedAssignment'
+type Database_GetDeletedAssignment_Call struct {
+	*mock.Call
+}
+
+// GetDeletedAssignment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignmentID uuid.UUID
+func (_e *Database_Expecter) GetDeletedAssignment(ctx interface{}, assignmentID interface{}) *Database_GetDeletedAssignment_Call {
+	return &Database_GetDeletedAssignment_Call{Call: _e.mock.On("GetDeletedAssignment", ctx, assignmentID)}
+}
+
+func (_c *Database_GetDeletedAssignment_Call) Run(run func(ctx context.Context, assignmentID uuid.UUID)) *Database_GetDeletedAssignment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetDeletedAssignment_Call) Return(_a0 *domain.DeletedAssignment, _a1 error) *Database_GetDeletedAssignment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetDeletedAssignment_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*domain.DeletedAssignment, error)) *Database_GetDeletedAssignment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLessons provides a mock function with given fields: ctx, class
+func (_m *Database) GetLessons(ctx context.Context, class string) ([]domain.Lesson, error) {
+	ret := _m.Called(ctx, class)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessons")
+	}
+
+	var r0 []domain.Lesson
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]domain.Lesson, error)); ok {
+		return rf(ctx, class)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []domain.Lesson); ok {
+		r0 = rf(ctx, class)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Lesson)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, class)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetLessons_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLessons'
+type Database_GetLessons_Call struct {
+	*mock.Call
+}
+
+// GetLessons is a helper method to define mock.On call
+//   - ctx context.Context
+//   - class string
+func (_e *Database_Expecter) GetLessons(ctx interface{}, class interface{}) *Database_GetLessons_Call {
+	return &Database_GetLessons_Call{Call: _e.mock.On("GetLessons", ctx, class)}
+}
+
+func (_c *Database_GetLessons_Call) Run(run func(ctx context.Context, class string)) *Database_GetLessons_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLessons_Call) Return(_a0 []domain.Lesson, _a1 error) *Database_GetLessons_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetLessons_Call) RunAndReturn(run func(context.Context, string) ([]domain.Lesson, error)) *Database_GetLessons_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetLessonsByClasses provides a mock function with given fields: ctx, classes
+func (_m *Database) GetLessonsByClasses(ctx context.Context, classes []string) ([]domain.Lesson, error) {
+	ret := _m.Called(ctx, classes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetLessonsByClasses")
+	}
+
+	var r0 []domain.Lesson
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) ([]domain.Lesson, error)); ok {
+		return rf(ctx, classes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) []domain.Lesson); ok {
+		r0 = rf(ctx, classes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Lesson)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, classes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetLessonsByClasses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetLessonsByClasses'
+type Database_GetLessonsByClasses_Call struct {
+	*mock.Call
+}
+
+// GetLessonsByClasses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - classes []string
+func (_e *Database_Expecter) GetLessonsByClasses(ctx interface{}, classes interface{}) *Database_GetLessonsByClasses_Call {
+	return &Database_GetLessonsByClasses_Call{Call: _e.mock.On("GetLessonsByClasses", ctx, classes)}
+}
+
+func (_c *Database_GetLessonsByClasses_Call) Run(run func(ctx context.Context, classes []string)) *Database_GetLessonsByClasses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetLessonsByClasses_Call) Return(_a0 []domain.Lesson, _a1 error) *Database_GetLessonsByClasses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetLessonsByClasses_Call) RunAndReturn(run func(context.Context, []string) ([]domain.Lesson, error)) *Database_GetLessonsByClasses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetMarksByAssignments provides a mock function with given fields: ctx, assignmentIDs
+func (_m *Database) GetMarksByAssignments(ctx context.Context, assignmentIDs []uuid.UUID) ([]domain.GradebookEntry, error) {
+	ret := _m.Called(ctx, assignmentIDs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMarksByAssignments")
+	}
+
+	var r0 []domain.GradebookEntry
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) ([]domain.GradebookEntry, error)); ok {
+		return rf(ctx, assignmentIDs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID) []domain.GradebookEntry); ok {
+		r0 = rf(ctx, assignmentIDs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.GradebookEntry)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID) error); ok {
+		r1 = rf(ctx, assignmentIDs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetMarksByAssignments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetMarksByAssignments'
+type Database_GetMarksByAssignments_Call struct {
+	*mock.Call
+}
+
+// GetMarksByAssignments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignmentIDs []uuid.UUID
+func (_e *Database_Expecter) GetMarksByAssignments(ctx interface{}, assignmentIDs interface{}) *Database_GetMarksByAssignments_Call {
+	return &Database_GetMarksByAssignments_Call{Call: _e.mock.On("GetMarksByAssignments", ctx, assignmentIDs)}
+}
+
+func (_c *Database_GetMarksByAssignments_Call) Run(run func(ctx context.Context, assignmentIDs []uuid.UUID)) *Database_GetMarksByAssignments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetMarksByAssignments_Call) Return(_a0 []domain.GradebookEntry, _a1 error) *Database_GetMarksByAssignments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetMarksByAssignments_Call) RunAndReturn(run func(context.Context, []uuid.UUID) ([]domain.GradebookEntry, error)) *Database_GetMarksByAssignments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetRecurrence provides a mock function with given fields: ctx, id
+func (_m *Database) GetRecurrence(ctx context.Context, id uuid.UUID) (*domain.Recurrence, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRecurrence")
+	}
+
+	var r0 *domain.Recurrence
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Recurrence, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Recurrence); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Recurrence)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetRecurrence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetRecurrence'
+type Database_GetRecurrence_Call struct {
+	*mock.Call
+}
+
+// GetRecurrence is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *Database_Expecter) GetRecurrence(ctx interface{}, id interface{}) *Database_GetRecurrence_Call {
+	return &Database_GetRecurrence_Call{Call: _e.mock.On("GetRecurrence", ctx, id)}
+}
+
+func (_c *Database_GetRecurrence_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Database_GetRecurrence_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetRecurrence_Call) Return(_a0 *domain.Recurrence, _a1 error) *Database_GetRecurrence_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetRecurrence_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*domain.Recurrence, error)) *Database_GetRecurrence_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetStudentExtensions provides a mock function with given fields: ctx, class, studentID
+func (_m *Database) GetStudentExtensions(ctx context.Context, class string, studentID uuid.UUID) (map[uuid.UUID]time.Time, error) {
+	ret := _m.Called(ctx, class, studentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetStudentExtensions")
+	}
+
+	var r0 map[uuid.UUID]time.Time
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (map[uuid.UUID]time.Time, error)); ok {
+		return rf(ctx, class, studentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) map[uuid.UUID]time.Time); ok {
+		r0 = rf(ctx, class, studentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]time.Time)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, class, studentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetStudentExtensions_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetStudentExtensions'
+type Database_GetStudentExtensions_Call struct {
+	*mock.Call
+}
+
+// GetStudentExtensions is a helper method to define mock.On call
+//   - ctx context.Context
+//   - class string
+//   - studentID uuid.UUID
+func (_e *Database_Expecter) GetStudentExtensions(ctx interface{}, class interface{}, studentID interface{}) *Database_GetStudentExtensions_Call {
+	return &Database_GetStudentExtensions_Call{Call: _e.mock.On("GetStudentExtensions", ctx, class, studentID)}
+}
+
+func (_c *Database_GetStudentExtensions_Call) Run(run func(ctx context.Context, class string, studentID uuid.UUID)) *Database_GetStudentExtensions_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetStudentExtensions_Call) Return(_a0 map[uuid.UUID]time.Time, _a1 error) *Database_GetStudentExtensions_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetStudentExtensions_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (map[uuid.UUID]time.Time, error)) *Database_GetStudentExtensions_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTaskAccess provides a mock function with given fields: ctx, ids, userID
+func (_m *Database) GetTaskAccess(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error) {
+	ret := _m.Called(ctx, ids, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskAccess")
+	}
+
+	var r0 map[uuid.UUID]domain.TaskAccess
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error)); ok {
+		return rf(ctx, ids, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *uuid.UUID) map[uuid.UUID]domain.TaskAccess); ok {
+		r0 = rf(ctx, ids, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[uuid.UUID]domain.TaskAccess)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(ctx, ids, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTaskAccess_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskAccess'
+type Database_GetTaskAccess_Call struct {
+	*mock.Call
+}
+
+// GetTaskAccess is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+//   - userID *uuid.UUID
+func (_e *Database_Expecter) GetTaskAccess(ctx interface{}, ids interface{}, userID interface{}) *Database_GetTaskAccess_Call {
+	return &Database_GetTaskAccess_Call{Call: _e.mock.On("GetTaskAccess", ctx, ids, userID)}
+}
+
+func (_c *Database_GetTaskAccess_Call) Run(run func(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID)) *Database_GetTaskAccess_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID), args[2].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetTaskAccess_Call) Return(_a0 map[uuid.UUID]domain.TaskAccess, _a1 error) *Database_GetTaskAccess_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTaskAccess_Call) RunAndReturn(run func(context.Context, []uuid.UUID, *uuid.UUID) (map[uuid.UUID]domain.TaskAccess, error)) *Database_GetTaskAccess_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTaskByClass provides a mock function with given fields: ctx, class
+func (_m *Database) GetTaskByClass(ctx context.Context, class string) ([]*domain.LessonTask, error) {
+	ret := _m.Called(ctx, class)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskByClass")
+	}
+
+	var r0 []*domain.LessonTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.LessonTask, error)); ok {
+		return rf(ctx, class)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.LessonTask); ok {
+		r0 = rf(ctx, class)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.LessonTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, class)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTaskByClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskByClass'
+type Database_GetTaskByClass_Call struct {
+	*mock.Call
+}
+
+// GetTaskByClass is a helper method to define mock.On call
+//   - ctx context.Context
+//   - class string
+func (_e *Database_Expecter) GetTaskByClass(ctx interface{}, class interface{}) *Database_GetTaskByClass_Call {
+	return &Database_GetTaskByClass_Call{Call: _e.mock.On("GetTaskByClass", ctx, class)}
+}
+
+func (_c *Database_GetTaskByClass_Call) Run(run func(ctx context.Context, class string)) *Database_GetTaskByClass_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTaskByClass_Call) Return(_a0 []*domain.LessonTask, _a1 error) *Database_GetTaskByClass_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTaskByClass_Call) RunAndReturn(run func(context.Context, string) ([]*domain.LessonTask, error)) *Database_GetTaskByClass_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTaskByID provides a mock function with given fields: ctx, id
+func (_m *Database) GetTaskByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskByID")
+	}
+
+	var r0 *domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Task, error)); ok {
+		return rf(ctx, id)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Task); ok {
+		r0 = rf(ctx, id)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, id)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTaskByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskByID'
+type Database_GetTaskByID_Call struct {
+	*mock.Call
+}
+
+// GetTaskByID is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *Database_Expecter) GetTaskByID(ctx interface{}, id interface{}) *Database_GetTaskByID_Call {
+	return &Database_GetTaskByID_Call{Call: _e.mock.On("GetTaskByID", ctx, id)}
+}
+
+func (_c *Database_GetTaskByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Database_GetTaskByID_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetTaskByID_Call) Return(_a0 *domain.Task, _a1 error) *Database_GetTaskByID_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTaskByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*domain.Task, error)) *Database_GetTaskByID_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTaskShares provides a mock function with given fields: ctx, taskID
+func (_m *Database) GetTaskShares(ctx context.Context, taskID uuid.UUID) ([]domain.TaskShare, error) {
+	ret := _m.Called(ctx, taskID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTaskShares")
+	}
+
+	var r0 []domain.TaskShare
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) ([]domain.TaskShare, error)); ok {
+		return rf(ctx, taskID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) []domain.TaskShare); ok {
+		r0 = rf(ctx, taskID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskShare)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, taskID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTaskShares_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskShares'
+type Database_GetTaskShares_Call struct {
+	*mock.Call
+}
+
+// GetTaskShares is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskID uuid.UUID
+func (_e *Database_Expecter) GetTaskShares(ctx interface{}, taskID interface{}) *Database_GetTaskShares_Call {
+	return &Database_GetTaskShares_Call{Call: _e.mock.On("GetTaskShares", ctx, taskID)}
+}
+
+func (_c *Database_GetTaskShares_Call) Run(run func(ctx context.Context, taskID uuid.UUID)) *Database_GetTaskShares_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetTaskShares_Call) Return(_a0 []domain.TaskShare, _a1 error) *Database_GetTaskShares_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTaskShares_Call) RunAndReturn(run func(context.Context, uuid.UUID) ([]domain.TaskShare, error)) *Database_GetTaskShares_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTasks provides a mock function with given fields: ctx, userID
+func (_m *Database) GetTasks(ctx context.Context, userID *uuid.UUID) ([]*domain.Task, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTasks")
+	}
+
+	var r0 []*domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) ([]*domain.Task, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) []*domain.Task); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTasks'
+type Database_GetTasks_Call struct {
+	*mock.Call
+}
+
+// GetTasks is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID *uuid.UUID
+func (_e *Database_Expecter) GetTasks(ctx interface{}, userID interface{}) *Database_GetTasks_Call {
+	return &Database_GetTasks_Call{Call: _e.mock.On("GetTasks", ctx, userID)}
+}
+
+func (_c *Database_GetTasks_Call) Run(run func(ctx context.Context, userID *uuid.UUID)) *Database_GetTasks_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetTasks_Call) Return(_a0 []*domain.Task, _a1 error) *Database_GetTasks_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTasks_Call) RunAndReturn(run func(context.Context, *uuid.UUID) ([]*domain.Task, error)) *Database_GetTasks_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTasksByClasses provides a mock function with given fields: ctx, classes
+func (_m *Database) GetTasksByClasses(ctx context.Context, classes []string) (map[string][]*domain.LessonTask, error) {
+	ret := _m.Called(ctx, classes)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTasksByClasses")
+	}
+
+	var r0 map[string][]*domain.LessonTask
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) (map[string][]*domain.LessonTask, error)); ok {
+		return rf(ctx, classes)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []string) map[string][]*domain.LessonTask); ok {
+		r0 = rf(ctx, classes)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]*domain.LessonTask)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []string) error); ok {
+		r1 = rf(ctx, classes)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTasksByClasses_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTasksByClasses'
+type Database_GetTasksByClasses_Call struct {
+	*mock.Call
+}
+
+// GetTasksByClasses is a helper method to define mock.On call
+//   - ctx context.Context
+//   - classes []string
+func (_e *Database_Expecter) GetTasksByClasses(ctx interface{}, classes interface{}) *Database_GetTasksByClasses_Call {
+	return &Database_GetTasksByClasses_Call{Call: _e.mock.On("GetTasksByClasses", ctx, classes)}
+}
+
+func (_c *Database_GetTasksByClasses_Call) Run(run func(ctx context.Context, classes []string)) *Database_GetTasksByClasses_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]string))
+	})
+	return _c
+}
+
+func (_c *Database_GetTasksByClasses_Call) Return(_a0 map[string][]*domain.LessonTask, _a1 error) *Database_GetTasksByClasses_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTasksByClasses_Call) RunAndReturn(run func(context.Context, []string) (map[string][]*domain.LessonTask, error)) *Database_GetTasksByClasses_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTasksByIDs provides a mock function with given fields: ctx, ids, userID
+func (_m *Database) GetTasksByIDs(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID) ([]*domain.Task, error) {
+	ret := _m.Called(ctx, ids, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTasksByIDs")
+	}
+
+	var r0 []*domain.Task
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *uuid.UUID) ([]*domain.Task, error)); ok {
+		return rf(ctx, ids, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []uuid.UUID, *uuid.UUID) []*domain.Task); ok {
+		r0 = rf(ctx, ids, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*domain.Task)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []uuid.UUID, *uuid.UUID) error); ok {
+		r1 = rf(ctx, ids, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTasksByIDs_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTasksByIDs'
+type Database_GetTasksByIDs_Call struct {
+	*mock.Call
+}
+
+// GetTasksByIDs is a helper method to define mock.On call
+//   - ctx context.Context
+//   - ids []uuid.UUID
+//   - userID *uuid.UUID
+func (_e *Database_Expecter) GetTasksByIDs(ctx interface{}, ids interface{}, userID interface{}) *Database_GetTasksByIDs_Call {
+	return &Database_GetTasksByIDs_Call{Call: _e.mock.On("GetTasksByIDs", ctx, ids, userID)}
+}
+
+func (_c *Database_GetTasksByIDs_Call) Run(run func(ctx context.Context, ids []uuid.UUID, userID *uuid.UUID)) *Database_GetTasksByIDs_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]uuid.UUID), args[2].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetTasksByIDs_Call) Return(_a0 []*domain.Task, _a1 error) *Database_GetTasksByIDs_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTasksByIDs_Call) RunAndReturn(run func(context.Context, []uuid.UUID, *uuid.UUID) ([]*domain.Task, error)) *Database_GetTasksByIDs_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// GetTrash provides a mock function with given fields: ctx, userID
+func (_m *Database) GetTrash(ctx context.Context, userID *uuid.UUID) (*domain.Trash, error) {
+	ret := _m.Called(ctx, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 *domain.Trash
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) (*domain.Trash, error)); ok {
+		return rf(ctx, userID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID) *domain.Trash); ok {
+		r0 = rf(ctx, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Trash)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *uuid.UUID) error); ok {
+		r1 = rf(ctx, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_GetTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTrash'
+type Database_GetTrash_Call struct {
+	*mock.Call
+}
+
+// GetTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - userID *uuid.UUID
+func (_e *Database_Expecter) GetTrash(ctx interface{}, userID interface{}) *Database_GetTrash_Call {
+	return &Database_GetTrash_Call{Call: _e.mock.On("GetTrash", ctx, userID)}
+}
+
+func (_c *Database_GetTrash_Call) Run(run func(ctx context.Context, userID *uuid.UUID)) *Database_GetTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_GetTrash_Call) Return(_a0 *domain.Trash, _a1 error) *Database_GetTrash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_GetTrash_Call) RunAndReturn(run func(context.Context, *uuid.UUID) (*domain.Trash, error)) *Database_GetTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// MaterializeRecurrences provides a mock function with given fields: ctx, now, until
+func (_m *Database) MaterializeRecurrences(ctx context.Context, now time.Time, until time.Time) ([]domain.Assignment, error) {
+	ret := _m.Called(ctx, now, until)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MaterializeRecurrences")
+	}
+
+	var r0 []domain.Assignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) ([]domain.Assignment, error)); ok {
+		return rf(ctx, now, until)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, time.Time) []domain.Assignment); ok {
+		r0 = rf(ctx, now, until)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Assignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time, time.Time) error); ok {
+		r1 = rf(ctx, now, until)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_MaterializeRecurrences_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'MaterializeRecurrences'
+type Database_MaterializeRecurrences_Call struct {
+	*mock.Call
+}
+
+// MaterializeRecurrences is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+//   - until time.Time
+func (_e *Database_Expecter) MaterializeRecurrences(ctx interface{}, now interface{}, until interface{}) *Database_MaterializeRecurrences_Call {
+	return &Database_MaterializeRecurrences_Call{Call: _e.mock.On("MaterializeRecurrences", ctx, now, until)}
+}
+
+func (_c *Database_MaterializeRecurrences_Call) Run(run func(ctx context.Context, now time.Time, until time.Time)) *Database_MaterializeRecurrences_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time), args[2].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_MaterializeRecurrences_Call) Return(_a0 []domain.Assignment, _a1 error) *Database_MaterializeRecurrences_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_MaterializeRecurrences_Call) RunAndReturn(run func(context.Context, time.Time, time.Time) ([]domain.Assignment, error)) *Database_MaterializeRecurrences_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PatchAssignment provides a mock function with given fields: ctx, assignment
+func (_m *Database) PatchAssignment(ctx context.Context, assignment *domain.TaskAsignment) error {
+	ret := _m.Called(ctx, assignment)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchAssignment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskAsignment) error); ok {
+		r0 = rf(ctx, assignment)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_PatchAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PatchAssignment'
+type Database_PatchAssignment_Call struct {
+	*mock.Call
+}
+
+// PatchAssignment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignment *domain.TaskAsignment
+func (_e *Database_Expecter) PatchAssignment(ctx interface{}, assignment interface{}) *Database_PatchAssignment_Call {
+	return &Database_PatchAssignment_Call{Call: _e.mock.On("PatchAssignment", ctx, assignment)}
+}
+
+func (_c *Database_PatchAssignment_Call) Run(run func(ctx context.Context, assignment *domain.TaskAsignment)) *Database_PatchAssignment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.TaskAsignment))
+	})
+	return _c
+}
+
+func (_c *Database_PatchAssignment_Call) Return(_a0 error) *Database_PatchAssignment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_PatchAssignment_Call) RunAndReturn(run func(context.Context, *domain.TaskAsignment) error) *Database_PatchAssignment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishAssignment provides a mock function with given fields: ctx, assignmentID
+func (_m *Database) PublishAssignment(ctx context.Context, assignmentID uuid.UUID) (*domain.Assignment, error) {
+	ret := _m.Called(ctx, assignmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishAssignment")
+	}
+
+	var r0 *domain.Assignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Assignment, error)); ok {
+		return rf(ctx, assignmentID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Assignment); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*domain.Assignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
+		r1 = rf(ctx, assignmentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_PublishAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishAssignment'
+type Database_PublishAssignment_Call struct {
+	*mock.Call
+}
+
+// PublishAssignment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignmentID uuid.UUID
+func (_e *Database_Expecter) PublishAssignment(ctx interface{}, assignmentID interface{}) *Database_PublishAssignment_Call {
+	return &Database_PublishAssignment_Call{Call: _e.mock.On("PublishAssignment", ctx, assignmentID)}
+}
+
+func (_c *Database_PublishAssignment_Call) Run(run func(ctx context.Context, assignmentID uuid.UUID)) *Database_PublishAssignment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_PublishAssignment_Call) Return(_a0 *domain.Assignment, _a1 error) *Database_PublishAssignment_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_PublishAssignment_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*domain.Assignment, error)) *Database_PublishAssignment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PublishDueAssignments provides a mock function with given fields: ctx, now
+func (_m *Database) PublishDueAssignments(ctx context.Context, now time.Time) ([]domain.Assignment, error) {
+	ret := _m.Called(ctx, now)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishDueAssignments")
+	}
+
+	var r0 []domain.Assignment
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) ([]domain.Assignment, error)); ok {
+		return rf(ctx, now)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) []domain.Assignment); ok {
+		r0 = rf(ctx, now)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.Assignment)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, now)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_PublishDueAssignments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PublishDueAssignments'
+type Database_PublishDueAssignments_Call struct {
+	*mock.Call
+}
+
+// PublishDueAssignments is a helper method to define mock.On call
+//   - ctx context.Context
+//   - now time.Time
+func (_e *Database_Expecter) PublishDueAssignments(ctx interface{}, now interface{}) *Database_PublishDueAssignments_Call {
+	return &Database_PublishDueAssignments_Call{Call: _e.mock.On("PublishDueAssignments", ctx, now)}
+}
+
+func (_c *Database_PublishDueAssignments_Call) Run(run func(ctx context.Context, now time.Time)) *Database_PublishDueAssignments_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_PublishDueAssignments_Call) Return(_a0 []domain.Assignment, _a1 error) *Database_PublishDueAssignments_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_PublishDueAssignments_Call) RunAndReturn(run func(context.Context, time.Time) ([]domain.Assignment, error)) *Database_PublishDueAssignments_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// PurgeTrash provides a mock function with given fields: ctx, before
+func (_m *Database) PurgeTrash(ctx context.Context, before time.Time) (int64, error) {
+	ret := _m.Called(ctx, before)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, before)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, before)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, before)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_PurgeTrash_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'PurgeTrash'
+type Database_PurgeTrash_Call struct {
+	*mock.Call
+}
+
+// PurgeTrash is a helper method to define mock.On call
+//   - ctx context.Context
+//   - before time.Time
+func (_e *Database_Expecter) PurgeTrash(ctx interface{}, before interface{}) *Database_PurgeTrash_Call {
+	return &Database_PurgeTrash_Call{Call: _e.mock.On("PurgeTrash", ctx, before)}
+}
+
+func (_c *Database_PurgeTrash_Call) Run(run func(ctx context.Context, before time.Time)) *Database_PurgeTrash_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(time.Time))
+	})
+	return _c
+}
+
+func (_c *Database_PurgeTrash_Call) Return(_a0 int64, _a1 error) *Database_PurgeTrash_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_PurgeTrash_Call) RunAndReturn(run func(context.Context, time.Time) (int64, error)) *Database_PurgeTrash_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreAssignment provides a mock function with given fields: ctx, assignmentID
+func (_m *Database) RestoreAssignment(ctx context.Context, assignmentID uuid.UUID) error {
+	ret := _m.Called(ctx, assignmentID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreAssignment")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, assignmentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_RestoreAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreAssignment'
+type Database_RestoreAssignment_Call struct {
+	*mock.Call
+}
+
+// RestoreAssignment is a helper method to define mock.On call
+//   - ctx context.Context
+//   - assignmentID uuid.UUID
+func (_e *Database_Expecter) RestoreAssignment(ctx interface{}, assignmentID interface{}) *Database_RestoreAssignment_Call {
+	return &Database_RestoreAssignment_Call{Call: _e.mock.On("RestoreAssignment", ctx, assignmentID)}
+}
+
+func (_c *Database_RestoreAssignment_Call) Run(run func(ctx context.Context, assignmentID uuid.UUID)) *Database_RestoreAssignment_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_RestoreAssignment_Call) Return(_a0 error) *Database_RestoreAssignment_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_RestoreAssignment_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *Database_RestoreAssignment_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// RestoreTask provides a mock function with given fields: ctx, id
+func (_m *Database) RestoreTask(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreTask")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
+		r0 = rf(ctx, id)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_RestoreTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'RestoreTask'
+type Database_RestoreTask_Call struct {
+	*mock.Call
+}
+
+// RestoreTask is a helper method to define mock.On call
+//   - ctx context.Context
+//   - id uuid.UUID
+func (_e *Database_Expecter) RestoreTask(ctx interface{}, id interface{}) *Database_RestoreTask_Call {
+	return &Database_RestoreTask_Call{Call: _e.mock.On("RestoreTask", ctx, id)}
+}
+
+func (_c *Database_RestoreTask_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Database_RestoreTask_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(uuid.UUID))
+	})
+	return _c
+}
+
+func (_c *Database_RestoreTask_Call) Return(_a0 error) *Database_RestoreTask_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_RestoreTask_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *Database_RestoreTask_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetAssignmentLatePolicy provides a mock function with given fields: ctx, policy
+func (_m *Database) SetAssignmentLatePolicy(ctx context.Context, policy *domain.AssignmentLatePolicy) error {
+	ret := _m.Called(ctx, policy)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetAssignmentLatePolicy")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.AssignmentLatePolicy) error); ok {
+		r0 = rf(ctx, policy)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SetAssignmentLatePolicy_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetAssignmentLatePolicy'
+type Database_SetAssignmentLatePolicy_Call struct {
+	*mock.Call
+}
+
+// SetAssignmentLatePolicy is a helper method to define mock.On call
+//   - ctx context.Context
+//   - policy *domain.AssignmentLatePolicy
+func (_e *Database_Expecter) SetAssignmentLatePolicy(ctx interface{}, policy interface{}) *Database_SetAssignmentLatePolicy_Call {
+	return &Database_SetAssignmentLatePolicy_Call{Call: _e.mock.On("SetAssignmentLatePolicy", ctx, policy)}
+}
+
+func (_c *Database_SetAssignmentLatePolicy_Call) Run(run func(ctx context.Context, policy *domain.AssignmentLatePolicy)) *Database_SetAssignmentLatePolicy_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.AssignmentLatePolicy))
+	})
+	return _c
+}
+
+func (_c *Database_SetAssignmentLatePolicy_Call) Return(_a0 error) *Database_SetAssignmentLatePolicy_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SetAssignmentLatePolicy_Call) RunAndReturn(run func(context.Context, *domain.AssignmentLatePolicy) error) *Database_SetAssignmentLatePolicy_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetDeadlineExtension provides a mock function with given fields: ctx, extension
+func (_m *Database) SetDeadlineExtension(ctx context.Context, extension *domain.DeadlineExtension) error {
+	ret := _m.Called(ctx, extension)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetDeadlineExtension")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.DeadlineExtension) error); ok {
+		r0 = rf(ctx, extension)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SetDeadlineExtension_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetDeadlineExtension'
+type Database_SetDeadlineExtension_Call struct {
+	*mock.Call
+}
+
+// SetDeadlineExtension is a helper method to define mock.On call
+//   - ctx context.Context
+//   - extension *domain.DeadlineExtension
+func (_e *Database_Expecter) SetDeadlineExtension(ctx interface{}, extension interface{}) *Database_SetDeadlineExtension_Call {
+	return &Database_SetDeadlineExtension_Call{Call: _e.mock.On("SetDeadlineExtension", ctx, extension)}
+}
+
+func (_c *Database_SetDeadlineExtension_Call) Run(run func(ctx context.Context, extension *domain.DeadlineExtension)) *Database_SetDeadlineExtension_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.DeadlineExtension))
+	})
+	return _c
+}
+
+func (_c *Database_SetDeadlineExtension_Call) Return(_a0 error) *Database_SetDeadlineExtension_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SetDeadlineExtension_Call) RunAndReturn(run func(context.Context, *domain.DeadlineExtension) error) *Database_SetDeadlineExtension_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetOccurrenceException provides a mock function with given fields: ctx, exception
+func (_m *Database) SetOccurrenceException(ctx context.Context, exception *domain.OccurrenceException) error {
+	ret := _m.Called(ctx, exception)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetOccurrenceException")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.OccurrenceException) error); ok {
+		r0 = rf(ctx, exception)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SetOccurrenceException_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetOccurrenceException'
+type Database_SetOccurrenceException_Call struct {
+	*mock.Call
+}
+
+// SetOccurrenceException is a helper method to define mock.On call
+//   - ctx context.Context
+//   - exception *domain.OccurrenceException
+func (_e *Database_Expecter) SetOccurrenceException(ctx interface{}, exception interface{}) *Database_SetOccurrenceException_Call {
+	return &Database_SetOccurrenceException_Call{Call: _e.mock.On("SetOccurrenceException", ctx, exception)}
+}
+
+func (_c *Database_SetOccurrenceException_Call) Run(run func(ctx context.Context, exception *domain.OccurrenceException)) *Database_SetOccurrenceException_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(*domain.OccurrenceException))
+	})
+	return _c
+}
+
+func (_c *Database_SetOccurrenceException_Call) Return(_a0 error) *Database_SetOccurrenceException_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Database_SetOccurrenceException_Call) RunAndReturn(run func(context.Context, *domain.OccurrenceException) error) *Database_SetOccurrenceException_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// SetTaskResultsByUsers provides a mock function with given fields: ctx, taskResults
+func (_m *Database) SetTaskResultsByUsers(ctx context.Context, taskResults *domain.TaskResult) error {
+	ret := _m.Called(ctx, taskResults)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetTaskResultsByUsers")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskResult) error); ok {
+		r0 = rf(ctx, taskResults)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Database_SetTaskResultsByUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTaskResultsByUsers'
+type Database_SetTaskResultsByUsers_Call struct {
+	*mock.Call
+}
+
+// SetTaskResultsByUsers is a helper method to define mock.On call
+//   - ctx context.Context
+//   - taskResults *domain.TaskResult
+func (_e *Database_Expecter) SetTaskResultsByUsers(ctx interface{}, taskResults interface{}) *Database_SetTaskResultsByUsers_Call {
+	return &Database_SetTaskResultsByUsers_Call{Call: _e.mock.On("SetTaskResultsByUsers", ctx, taskResults)}
+}
+
+func (_c *Database_SetTaskResultsByUsers_Call) Run(run func(ctx context.Context, taskResults *domain.TaskResult)) *Database_SetTaskResultsByUsers_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.Task))
+		run(args[0].(context.Context), args[1].(*domain.TaskResult))
 	})
 	return _c
 }
 
-func (_c *Database_CreateTask_Call) Return(_a0 uuid.UUID, _a1 error) *Database_CreateTask_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_SetTaskResultsByUsers_Call) Return(_a0 error) *Database_SetTaskResultsByUsers_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateTask_Call) RunAndReturn(run func(context.Context, *domain.Task) (uuid.UUID, error)) *Database_CreateTask_Call {
+func (_c *Database_SetTaskResultsByUsers_Call) RunAndReturn(run func(context.Context, *domain.TaskResult) error) *Database_SetTaskResultsByUsers_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// CreateTaskWithAssignments provides a mock function with given fields: ctx, assignment
-func (_m *Database) CreateTaskWithAssignments(ctx context.Context, assignment *domain.TaskWithAsignment) (uuid.UUID, error) {
-	ret := _m.Called(ctx, assignment)
+// ShareTask provides a mock function with given fields: ctx, share
+func (_m *Database) ShareTask(ctx context.Context, share *domain.TaskShare) error {
+	ret := _m.Called(ctx, share)
 
 	if len(ret) == 0 {
-		panic("no return value specified for CreateTaskWithAssignments")
-	}
-
-	var r0 uuid.UUID
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskWithAsignment) (uuid.UUID, error)); ok {
-		return rf(ctx, assignment)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskWithAsignment) uuid.UUID); ok {
-		r0 = rf(ctx, assignment)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(uuid.UUID)
-		}
+		panic("no return value specified for ShareTask")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, *domain.TaskWithAsignment) error); ok {
-		r1 = rf(ctx, assignment)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskShare) error); ok {
+		r0 = rf(ctx, share)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_CreateTaskWithAssignments_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'CreateTaskWithAssignments'
-type Database_CreateTaskWithAssignments_Call struct {
+// Database_ShareTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ShareTask'
+type Database_ShareTask_Call struct {
 	*mock.Call
 }
 
-// CreateTaskWithAssignments is a helper method to define mock.On call
+// ShareTask is a helper method to define mock.On call
 //   - ctx context.Context
-//   - assignment *domain.TaskWithAsignment
-func (_e *Database_Expecter) CreateTaskWithAssignments(ctx interface{}, assignment interface{}) *Database_CreateTaskWithAssignments_Call {
-	return &Database_CreateTaskWithAssignments_Call{Call: _e.mock.On("CreateTaskWithAssignments", ctx, assignment)}
+//   - share *domain.TaskShare
+func (_e *Database_Expecter) ShareTask(ctx interface{}, share interface{}) *Database_ShareTask_Call {
+	return &Database_ShareTask_Call{Call: _e.mock.On("ShareTask", ctx, share)}
 }
 
-func (_c *Database_CreateTaskWithAssignments_Call) Run(run func(ctx context.Context, assignment *domain.TaskWithAsignment)) *Database_CreateTaskWithAssignments_Call {
+func (_c *Database_ShareTask_Call) Run(run func(ctx context.Context, share *domain.TaskShare)) *Database_ShareTask_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.TaskWithAsignment))
+		run(args[0].(context.Context), args[1].(*domain.TaskShare))
 	})
 	return _c
 }
 
-func (_c *Database_CreateTaskWithAssignments_Call) Return(_a0 uuid.UUID, _a1 error) *Database_CreateTaskWithAssignments_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_ShareTask_Call) Return(_a0 error) *Database_ShareTask_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_CreateTaskWithAssignments_Call) RunAndReturn(run func(context.Context, *domain.TaskWithAsignment) (uuid.UUID, error)) *Database_CreateTaskWithAssignments_Call {
+func (_c *Database_ShareTask_Call) RunAndReturn(run func(context.Context, *domain.TaskShare) error) *Database_ShareTask_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteAssignment provides a mock function with given fields: ctx, assignmentID
-func (_m *Database) DeleteAssignment(ctx context.Context, assignmentID uuid.UUID) error {
-	ret := _m.Called(ctx, assignmentID)
+// StopRecurrence provides a mock function with given fields: ctx, id
+func (_m *Database) StopRecurrence(ctx context.Context, id uuid.UUID) error {
+	ret := _m.Called(ctx, id)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteAssignment")
+		panic("no return value specified for StopRecurrence")
 	}
 
 	var r0 error
 	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, assignmentID)
+		r0 = rf(ctx, id)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -219,46 +2526,46 @@ func (_m *Database) DeleteAssignment(ctx context.Context, assignmentID uuid.UUID
 	return r0
 }
 
-// Database_DeleteAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteAssignment'
-type Database_DeleteAssignment_Call struct {
+// Database_StopRecurrence_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StopRecurrence'
+type Database_StopRecurrence_Call struct {
 	*mock.Call
 }
 
-// DeleteAssignment is a helper method to define mock.On call
+// StopRecurrence is a helper method to define mock.On call
 //   - ctx context.Context
-//   - assignmentID uuid.UUID
-func (_e *Database_Expecter) DeleteAssignment(ctx interface{}, assignmentID interface{}) *Database_DeleteAssignment_Call {
-	return &Database_DeleteAssignment_Call{Call: _e.mock.On("DeleteAssignment", ctx, assignmentID)}
+//   - id uuid.UUID
+func (_e *Database_Expecter) StopRecurrence(ctx interface{}, id interface{}) *Database_StopRecurrence_Call {
+	return &Database_StopRecurrence_Call{Call: _e.mock.On("StopRecurrence", ctx, id)}
 }
 
-func (_c *Database_DeleteAssignment_Call) Run(run func(ctx context.Context, assignmentID uuid.UUID)) *Database_DeleteAssignment_Call {
+func (_c *Database_StopRecurrence_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Database_StopRecurrence_Call {
 	_c.Call.Run(func(args mock.Arguments) {
 		run(args[0].(context.Context), args[1].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteAssignment_Call) Return(_a0 error) *Database_DeleteAssignment_Call {
+func (_c *Database_StopRecurrence_Call) Return(_a0 error) *Database_StopRecurrence_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteAssignment_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *Database_DeleteAssignment_Call {
+func (_c *Database_StopRecurrence_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *Database_StopRecurrence_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// DeleteTask provides a mock function with given fields: ctx, id
-func (_m *Database) DeleteTask(ctx context.Context, id uuid.UUID) error {
-	ret := _m.Called(ctx, id)
+// StreamGradebook provides a mock function with given fields: ctx, class, fn
+func (_m *Database) StreamGradebook(ctx context.Context, class string, fn func(*domain.GradebookEntry) error) error {
+	ret := _m.Called(ctx, class, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for DeleteTask")
+		panic("no return value specified for StreamGradebook")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) error); ok {
-		r0 = rf(ctx, id)
+	if rf, ok := ret.Get(0).(func(context.Context, string, func(*domain.GradebookEntry) error) error); ok {
+		r0 = rf(ctx, class, fn)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -266,176 +2573,153 @@ func (_m *Database) DeleteTask(ctx context.Context, id uuid.UUID) error {
 	return r0
 }
 
-// Database_DeleteTask_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeleteTask'
-type Database_DeleteTask_Call struct {
+// Database_StreamGradebook_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamGradebook'
+type Database_StreamGradebook_Call struct {
 	*mock.Call
 }
 
-// DeleteTask is a helper method to define mock.On call
+// StreamGradebook is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *Database_Expecter) DeleteTask(ctx interface{}, id interface{}) *Database_DeleteTask_Call {
-	return &Database_DeleteTask_Call{Call: _e.mock.On("DeleteTask", ctx, id)}
+//   - class string
+//   - fn func(*domain.GradebookEntry) error
+func (_e *Database_Expecter) StreamGradebook(ctx interface{}, class interface{}, fn interface{}) *Database_StreamGradebook_Call {
+	return &Database_StreamGradebook_Call{Call: _e.mock.On("StreamGradebook", ctx, class, fn)}
 }
 
-func (_c *Database_DeleteTask_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Database_DeleteTask_Call {
+func (_c *Database_StreamGradebook_Call) Run(run func(ctx context.Context, class string, fn func(*domain.GradebookEntry) error)) *Database_StreamGradebook_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(string), args[2].(func(*domain.GradebookEntry) error))
 	})
 	return _c
 }
 
-func (_c *Database_DeleteTask_Call) Return(_a0 error) *Database_DeleteTask_Call {
+func (_c *Database_StreamGradebook_Call) Return(_a0 error) *Database_StreamGradebook_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_DeleteTask_Call) RunAndReturn(run func(context.Context, uuid.UUID) error) *Database_DeleteTask_Call {
+func (_c *Database_StreamGradebook_Call) RunAndReturn(run func(context.Context, string, func(*domain.GradebookEntry) error) error) *Database_StreamGradebook_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTaskByClass provides a mock function with given fields: ctx, class
-func (_m *Database) GetTaskByClass(ctx context.Context, class string) ([]*domain.LessonTask, error) {
-	ret := _m.Called(ctx, class)
+// StreamStudentMarks provides a mock function with given fields: ctx, studentID, fn
+func (_m *Database) StreamStudentMarks(ctx context.Context, studentID uuid.UUID, fn func(*domain.GradebookEntry) error) error {
+	ret := _m.Called(ctx, studentID, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTaskByClass")
-	}
-
-	var r0 []*domain.LessonTask
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) ([]*domain.LessonTask, error)); ok {
-		return rf(ctx, class)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, string) []*domain.LessonTask); ok {
-		r0 = rf(ctx, class)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.LessonTask)
-		}
+		panic("no return value specified for StreamStudentMarks")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
-		r1 = rf(ctx, class)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID, func(*domain.GradebookEntry) error) error); ok {
+		r0 = rf(ctx, studentID, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetTaskByClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskByClass'
-type Database_GetTaskByClass_Call struct {
+// Database_StreamStudentMarks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamStudentMarks'
+type Database_StreamStudentMarks_Call struct {
 	*mock.Call
 }
 
-// GetTaskByClass is a helper method to define mock.On call
+// StreamStudentMarks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - class string
-func (_e *Database_Expecter) GetTaskByClass(ctx interface{}, class interface{}) *Database_GetTaskByClass_Call {
-	return &Database_GetTaskByClass_Call{Call: _e.mock.On("GetTaskByClass", ctx, class)}
+//   - studentID uuid.UUID
+//   - fn func(*domain.GradebookEntry) error
+func (_e *Database_Expecter) StreamStudentMarks(ctx interface{}, studentID interface{}, fn interface{}) *Database_StreamStudentMarks_Call {
+	return &Database_StreamStudentMarks_Call{Call: _e.mock.On("StreamStudentMarks", ctx, studentID, fn)}
 }
 
-func (_c *Database_GetTaskByClass_Call) Run(run func(ctx context.Context, class string)) *Database_GetTaskByClass_Call {
+func (_c *Database_StreamStudentMarks_Call) Run(run func(ctx context.Context, studentID uuid.UUID, fn func(*domain.GradebookEntry) error)) *Database_StreamStudentMarks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(string))
+		run(args[0].(context.Context), args[1].(uuid.UUID), args[2].(func(*domain.GradebookEntry) error))
 	})
 	return _c
 }
 
-func (_c *Database_GetTaskByClass_Call) Return(_a0 []*domain.LessonTask, _a1 error) *Database_GetTaskByClass_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_StreamStudentMarks_Call) Return(_a0 error) *Database_StreamStudentMarks_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTaskByClass_Call) RunAndReturn(run func(context.Context, string) ([]*domain.LessonTask, error)) *Database_GetTaskByClass_Call {
+func (_c *Database_StreamStudentMarks_Call) RunAndReturn(run func(context.Context, uuid.UUID, func(*domain.GradebookEntry) error) error) *Database_StreamStudentMarks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTaskByID provides a mock function with given fields: ctx, id
-func (_m *Database) GetTaskByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
-	ret := _m.Called(ctx, id)
+// StreamTasks provides a mock function with given fields: ctx, userID, fn
+func (_m *Database) StreamTasks(ctx context.Context, userID *uuid.UUID, fn func(*domain.Task) error) error {
+	ret := _m.Called(ctx, userID, fn)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTaskByID")
-	}
-
-	var r0 *domain.Task
-	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) (*domain.Task, error)); ok {
-		return rf(ctx, id)
-	}
-	if rf, ok := ret.Get(0).(func(context.Context, uuid.UUID) *domain.Task); ok {
-		r0 = rf(ctx, id)
-	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(*domain.Task)
-		}
+		panic("no return value specified for StreamTasks")
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, uuid.UUID) error); ok {
-		r1 = rf(ctx, id)
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *uuid.UUID, func(*domain.Task) error) error); ok {
+		r0 = rf(ctx, userID, fn)
 	} else {
-		r1 = ret.Error(1)
+		r0 = ret.Error(0)
 	}
 
-	return r0, r1
+	return r0
 }
 
-// Database_GetTaskByID_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTaskByID'
-type Database_GetTaskByID_Call struct {
+// Database_StreamTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'StreamTasks'
+type Database_StreamTasks_Call struct {
 	*mock.Call
 }
 
-// GetTaskByID is a helper method to define mock.On call
+// StreamTasks is a helper method to define mock.On call
 //   - ctx context.Context
-//   - id uuid.UUID
-func (_e *Database_Expecter) GetTaskByID(ctx interface{}, id interface{}) *Database_GetTaskByID_Call {
-	return &Database_GetTaskByID_Call{Call: _e.mock.On("GetTaskByID", ctx, id)}
+//   - userID *uuid.UUID
+//   - fn func(*domain.Task) error
+func (_e *Database_Expecter) StreamTasks(ctx interface{}, userID interface{}, fn interface{}) *Database_StreamTasks_Call {
+	return &Database_StreamTasks_Call{Call: _e.mock.On("StreamTasks", ctx, userID, fn)}
 }
 
-func (_c *Database_GetTaskByID_Call) Run(run func(ctx context.Context, id uuid.UUID)) *Database_GetTaskByID_Call {
+func (_c *Database_StreamTasks_Call) Run(run func(ctx context.Context, userID *uuid.UUID, fn func(*domain.Task) error)) *Database_StreamTasks_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(uuid.UUID))
+		run(args[0].(context.Context), args[1].(*uuid.UUID), args[2].(func(*domain.Task) error))
 	})
 	return _c
 }
 
-func (_c *Database_GetTaskByID_Call) Return(_a0 *domain.Task, _a1 error) *Database_GetTaskByID_Call {
-	_c.Call.Return(_a0, _a1)
+func (_c *Database_StreamTasks_Call) Return(_a0 error) *Database_StreamTasks_Call {
+	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_GetTaskByID_Call) RunAndReturn(run func(context.Context, uuid.UUID) (*domain.Task, error)) *Database_GetTaskByID_Call {
+func (_c *Database_StreamTasks_Call) RunAndReturn(run func(context.Context, *uuid.UUID, func(*domain.Task) error) error) *Database_StreamTasks_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// GetTasks provides a mock function with given fields: ctx
-func (_m *Database) GetTasks(ctx context.Context) ([]*domain.Task, error) {
-	ret := _m.Called(ctx)
+// TeachesClass provides a mock function with given fields: ctx, class, userID
+func (_m *Database) TeachesClass(ctx context.Context, class string, userID uuid.UUID) (bool, error) {
+	ret := _m.Called(ctx, class, userID)
 
 	if len(ret) == 0 {
-		panic("no return value specified for GetTasks")
+		panic("no return value specified for TeachesClass")
 	}
 
-	var r0 []*domain.Task
+	var r0 bool
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context) ([]*domain.Task, error)); ok {
-		return rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) (bool, error)); ok {
+		return rf(ctx, class, userID)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context) []*domain.Task); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, string, uuid.UUID) bool); ok {
+		r0 = rf(ctx, class, userID)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*domain.Task)
-		}
+		r0 = ret.Get(0).(bool)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, string, uuid.UUID) error); ok {
+		r1 = rf(ctx, class, userID)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -443,45 +2727,47 @@ func (_m *Database) GetTasks(ctx context.Context) ([]*domain.Task, error) {
 	return r0, r1
 }
 
-// Database_GetTasks_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'GetTasks'
-type Database_GetTasks_Call struct {
+// Database_TeachesClass_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'TeachesClass'
+type Database_TeachesClass_Call struct {
 	*mock.Call
 }
 
-// GetTasks is a helper method to define mock.On call
+// TeachesClass is a helper method to define mock.On call
 //   - ctx context.Context
-func (_e *Database_Expecter) GetTasks(ctx interface{}) *Database_GetTasks_Call {
-	return &Database_GetTasks_Call{Call: _e.mock.On("GetTasks", ctx)}
+//   - class string
+//   - userID uuid.UUID
+func (_e *Database_Expecter) TeachesClass(ctx interface{}, class interface{}, userID interface{}) *Database_TeachesClass_Call {
+	return &Database_TeachesClass_Call{Call: _e.mock.On("TeachesClass", ctx, class, userID)}
 }
 
-func (_c *Database_GetTasks_Call) Run(run func(ctx context.Context)) *Database_GetTasks_Call {
+func (_c *Database_TeachesClass_Call) Run(run func(ctx context.Context, class string, userID uuid.UUID)) *Database_TeachesClass_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context))
+		run(args[0].(context.Context), args[1].(string), args[2].(uuid.UUID))
 	})
 	return _c
 }
 
-func (_c *Database_GetTasks_Call) Return(_a0 []*domain.Task, _a1 error) *Database_GetTasks_Call {
+func (_c *Database_TeachesClass_Call) Return(_a0 bool, _a1 error) *Database_TeachesClass_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *Database_GetTasks_Call) RunAndReturn(run func(context.Context) ([]*domain.Task, error)) *Database_GetTasks_Call {
+func (_c *Database_TeachesClass_Call) RunAndReturn(run func(context.Context, string, uuid.UUID) (bool, error)) *Database_TeachesClass_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// SetTaskResultsByUsers provides a mock function with given fields: ctx, taskResults
-func (_m *Database) SetTaskResultsByUsers(ctx context.Context, taskResults *domain.TaskResult) error {
-	ret := _m.Called(ctx, taskResults)
+// UpdateAssignment provides a mock function with given fields: ctx, task
+func (_m *Database) UpdateAssignment(ctx context.Context, task *domain.TaskAsignment) error {
+	ret := _m.Called(ctx, task)
 
 	if len(ret) == 0 {
-		panic("no return value specified for SetTaskResultsByUsers")
+		panic("no return value specified for UpdateAssignment")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskResult) error); ok {
-		r0 = rf(ctx, taskResults)
+	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskAsignment) error); ok {
+		r0 = rf(ctx, task)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -489,78 +2775,100 @@ func (_m *Database) SetTaskResultsByUsers(ctx context.Context, taskResults *doma
 	return r0
 }
 
-// Database_SetTaskResultsByUsers_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'SetTaskResultsByUsers'
-type Database_SetTaskResultsByUsers_Call struct {
+// Database_UpdateAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAssignment'
+type Database_UpdateAssignment_Call struct {
 	*mock.Call
 }
 
-// SetTaskResultsByUsers is a helper method to define mock.On call
+// UpdateAssignment is a helper method to define mock.On call
 //   - ctx context.Context
-//   - taskResults *domain.TaskResult
-func (_e *Database_Expecter) SetTaskResultsByUsers(ctx interface{}, taskResults interface{}) *Database_SetTaskResultsByUsers_Call {
-	return &Database_SetTaskResultsByUsers_Call{Call: _e.mock.On("SetTaskResultsByUsers", ctx, taskResults)}
+//   - task *domain.TaskAsignment
+func (_e *Database_Expecter) UpdateAssignment(ctx interface{}, task interface{}) *Database_UpdateAssignment_Call {
+	return &Database_UpdateAssignment_Call{Call: _e.mock.On("UpdateAssignment", ctx, task)}
 }
 
-func (_c *Database_SetTaskResultsByUsers_Call) Run(run func(ctx context.Context, taskResults *domain.TaskResult)) *Database_SetTaskResultsByUsers_Call {
+func (_c *Database_UpdateAssignment_Call) Run(run func(ctx context.Context, task *domain.TaskAsignment)) *Database_UpdateAssignment_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.TaskResult))
+		run(args[0].(context.Context), args[1].(*domain.TaskAsignment))
 	})
 	return _c
 }
 
-func (_c *Database_SetTaskResultsByUsers_Call) Return(_a0 error) *Database_SetTaskResultsByUsers_Call {
+func (_c *Database_UpdateAssignment_Call) Return(_a0 error) *Database_UpdateAssignment_Call {
 	_c.Call.Return(_a0)
 	return _c
 }
 
-func (_c *Database_SetTaskResultsByUsers_Call) RunAndReturn(run func(context.Context, *domain.TaskResult) error) *Database_SetTaskResultsByUsers_Call {
+func (_c *Database_UpdateAssignment_Call) RunAndReturn(run func(context.Context, *domain.TaskAsignment) error) *Database_UpdateAssignment_Call {
 	_c.Call.Return(run)
 	return _c
 }
 
-// UpdateAssignment provides a mock function with given fields: ctx, task
-func (_m *Database) UpdateAssignment(ctx context.Context, task *domain.TaskAsignment) error {
-	ret := _m.Called(ctx, task)
+// UpdateDeadlines provides a mock function with given fields: ctx, mode, updates
+func (_m *Database) UpdateDeadlines(ctx context.Context, mode domain.BulkMode, updates []domain.DeadlineUpdate) ([]domain.TaskAsignment, []error, error) {
+	ret := _m.Called(ctx, mode, updates)
 
 	if len(ret) == 0 {
-		panic("no return value specified for UpdateAssignment")
+		panic("no return value specified for UpdateDeadlines")
 	}
 
-	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, *domain.TaskAsignment) error); ok {
-		r0 = rf(ctx, task)
+	var r0 []domain.TaskAsignment
+	var r1 []error
+	var r2 error
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkMode, []domain.DeadlineUpdate) ([]domain.TaskAsignment, []error, error)); ok {
+		return rf(ctx, mode, updates)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, domain.BulkMode, []domain.DeadlineUpdate) []domain.TaskAsignment); ok {
+		r0 = rf(ctx, mode, updates)
 	} else {
-		r0 = ret.Error(0)
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]domain.TaskAsignment)
+		}
 	}
 
-	return r0
+	if rf, ok := ret.Get(1).(func(context.Context, domain.BulkMode, []domain.DeadlineUpdate) []error); ok {
+		r1 = rf(ctx, mode, updates)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(2).(func(context.Context, domain.BulkMode, []domain.DeadlineUpdate) error); ok {
+		r2 = rf(ctx, mode, updates)
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
 }
 
-// Database_UpdateAssignment_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateAssignment'
-type Database_UpdateAssignment_Call struct {
+// Database_UpdateDeadlines_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'UpdateDeadlines'
+type Database_UpdateDeadlines_Call struct {
 	*mock.Call
 }
 
-// UpdateAssignment is a helper method to define mock.On call
+// UpdateDeadlines is a helper method to define mock.On call
 //   - ctx context.Context
-//   - task *domain.TaskAsignment
-func (_e *Database_Expecter) UpdateAssignment(ctx interface{}, task interface{}) *Database_UpdateAssignment_Call {
-	return &Database_UpdateAssignment_Call{Call: _e.mock.On("UpdateAssignment", ctx, task)}
+//   - mode domain.BulkMode
+//   - updates []domain.DeadlineUpdate
+func (_e *Database_Expecter) UpdateDeadlines(ctx interface{}, mode interface{}, updates interface{}) *Database_UpdateDeadlines_Call {
+	return &Database_UpdateDeadlines_Call{Call: _e.mock.On("UpdateDeadlines", ctx, mode, updates)}
 }
 
-func (_c *Database_UpdateAssignment_Call) Run(run func(ctx context.Context, task *domain.TaskAsignment)) *Database_UpdateAssignment_Call {
+func (_c *Database_UpdateDeadlines_Call) Run(run func(ctx context.Context, mode domain.BulkMode, updates []domain.DeadlineUpdate)) *Database_UpdateDeadlines_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(context.Context), args[1].(*domain.TaskAsignment))
+		run(args[0].(context.Context), args[1].(domain.BulkMode), args[2].([]domain.DeadlineUpdate))
 	})
 	return _c
 }
 
-func (_c *Database_UpdateAssignment_Call) Return(_a0 error) *Database_UpdateAssignment_Call {
-	_c.Call.Return(_a0)
+func (_c *Database_UpdateDeadlines_Call) Return(_a0 []domain.TaskAsignment, _a1 []error, _a2 error) *Database_UpdateDeadlines_Call {
+	_c.Call.Return(_a0, _a1, _a2)
 	return _c
 }
 
-func (_c *Database_UpdateAssignment_Call) RunAndReturn(run func(context.Context, *domain.TaskAsignment) error) *Database_UpdateAssignment_Call {
+func (_c *Database_UpdateDeadlines_Call) RunAndReturn(run func(context.Context, domain.BulkMode, []domain.DeadlineUpdate) ([]domain.TaskAsignment, []error, error)) *Database_UpdateDeadlines_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -612,6 +2920,65 @@ func (_c *Database_UpdateTask_Call) RunAndReturn(run func(context.Context, *doma
 	return _c
 }
 
+// ValidateClassLessons provides a mock function with given fields: ctx, pairs
+func (_m *Database) ValidateClassLessons(ctx context.Context, pairs []domain.ClassLesson) ([]error, error) {
+	ret := _m.Called(ctx, pairs)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ValidateClassLessons")
+	}
+
+	var r0 []error
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ClassLesson) ([]error, error)); ok {
+		return rf(ctx, pairs)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.ClassLesson) []error); ok {
+		r0 = rf(ctx, pairs)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]error)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, []domain.ClassLesson) error); ok {
+		r1 = rf(ctx, pairs)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Database_ValidateClassLessons_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ValidateClassLessons'
+type Database_ValidateClassLessons_Call struct {
+	*mock.Call
+}
+
+// ValidateClassLessons is a helper method to define mock.On call
+//   - ctx context.Context
+//   - pairs []domain.ClassLesson
+func (_e *Database_Expecter) ValidateClassLessons(ctx interface{}, pairs interface{}) *Database_ValidateClassLessons_Call {
+	return &Database_ValidateClassLessons_Call{Call: _e.mock.On("ValidateClassLessons", ctx, pairs)}
+}
+
+func (_c *Database_ValidateClassLessons_Call) Run(run func(ctx context.Context, pairs []domain.ClassLesson)) *Database_ValidateClassLessons_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.ClassLesson))
+	})
+	return _c
+}
+
+func (_c *Database_ValidateClassLessons_Call) Return(_a0 []error, _a1 error) *Database_ValidateClassLessons_Call {
+	_c.Call.Return(_a0, _a1)
+	return _c
+}
+
+func (_c *Database_ValidateClassLessons_Call) RunAndReturn(run func(context.Context, []domain.ClassLesson) ([]error, error)) *Database_ValidateClassLessons_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
 // NewDatabase creates a new instance of Database. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDatabase(t interface {
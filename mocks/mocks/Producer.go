@@ -3,6 +3,7 @@
 package mocks
 
 import (
+	context "context"
 	domain "task/internal/domain"
 
 	mock "github.com/stretchr/testify/mock"
@@ -21,17 +22,64 @@ func (_m *Producer) EXPECT() *Producer_Expecter {
 	return &Producer_Expecter{mock: &_m.Mock}
 }
 
-// Produce provides a mock function with given fields: event
-func (_m *Producer) Produce(event domain.Event) error {
-	ret := _m.Called(event)
+// DeliverBatch provides a mock function with given fields: ctx, events
+func (_m *Producer) DeliverBatch(ctx context.Context, events []domain.Event) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeliverBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Event) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Producer_DeliverBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'DeliverBatch'
+type Producer_DeliverBatch_Call struct {
+	*mock.Call
+}
+
+// DeliverBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []domain.Event
+func (_e *Producer_Expecter) DeliverBatch(ctx interface{}, events interface{}) *Producer_DeliverBatch_Call {
+	return &Producer_DeliverBatch_Call{Call: _e.mock.On("DeliverBatch", ctx, events)}
+}
+
+func (_c *Producer_DeliverBatch_Call) Run(run func(ctx context.Context, events []domain.Event)) *Producer_DeliverBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Event))
+	})
+	return _c
+}
+
+func (_c *Producer_DeliverBatch_Call) Return(_a0 error) *Producer_DeliverBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Producer_DeliverBatch_Call) RunAndReturn(run func(context.Context, []domain.Event) error) *Producer_DeliverBatch_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Produce provides a mock function with given fields: ctx, event
+func (_m *Producer) Produce(ctx context.Context, event domain.Event) error {
+	ret := _m.Called(ctx, event)
 
 	if len(ret) == 0 {
 		panic("no return value specified for Produce")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(domain.Event) error); ok {
-		r0 = rf(event)
+	if rf, ok := ret.Get(0).(func(context.Context, domain.Event) error); ok {
+		r0 = rf(ctx, event)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -45,14 +93,15 @@ type Producer_Produce_Call struct {
 }
 
 // Produce is a helper method to define mock.On call
+//   - ctx context.Context
 //   - event domain.Event
-func (_e *Producer_Expecter) Produce(event interface{}) *Producer_Produce_Call {
-	return &Producer_Produce_Call{Call: _e.mock.On("Produce", event)}
+func (_e *Producer_Expecter) Produce(ctx interface{}, event interface{}) *Producer_Produce_Call {
+	return &Producer_Produce_Call{Call: _e.mock.On("Produce", ctx, event)}
 }
 
-func (_c *Producer_Produce_Call) Run(run func(event domain.Event)) *Producer_Produce_Call {
+func (_c *Producer_Produce_Call) Run(run func(ctx context.Context, event domain.Event)) *Producer_Produce_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(domain.Event))
+		run(args[0].(context.Context), args[1].(domain.Event))
 	})
 	return _c
 }
@@ -62,7 +111,54 @@ func (_c *Producer_Produce_Call) Return(_a0 error) *Producer_Produce_Call {
 	return _c
 }
 
-func (_c *Producer_Produce_Call) RunAndReturn(run func(domain.Event) error) *Producer_Produce_Call {
+func (_c *Producer_Produce_Call) RunAndReturn(run func(context.Context, domain.Event) error) *Producer_Produce_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// ProduceBatch provides a mock function with given fields: ctx, events
+func (_m *Producer) ProduceBatch(ctx context.Context, events []domain.Event) error {
+	ret := _m.Called(ctx, events)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProduceBatch")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []domain.Event) error); ok {
+		r0 = rf(ctx, events)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Producer_ProduceBatch_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'ProduceBatch'
+type Producer_ProduceBatch_Call struct {
+	*mock.Call
+}
+
+// ProduceBatch is a helper method to define mock.On call
+//   - ctx context.Context
+//   - events []domain.Event
+func (_e *Producer_Expecter) ProduceBatch(ctx interface{}, events interface{}) *Producer_ProduceBatch_Call {
+	return &Producer_ProduceBatch_Call{Call: _e.mock.On("ProduceBatch", ctx, events)}
+}
+
+func (_c *Producer_ProduceBatch_Call) Run(run func(ctx context.Context, events []domain.Event)) *Producer_ProduceBatch_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].([]domain.Event))
+	})
+	return _c
+}
+
+func (_c *Producer_ProduceBatch_Call) Return(_a0 error) *Producer_ProduceBatch_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *Producer_ProduceBatch_Call) RunAndReturn(run func(context.Context, []domain.Event) error) *Producer_ProduceBatch_Call {
 	_c.Call.Return(run)
 	return _c
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/exaring/otelpgx"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
@@ -17,6 +18,9 @@ func NewPG(connURL string) (*Postgres, error) {
 		return nil, fmt.Errorf(": %w", err)
 	}
 
+	// Every query gets a span under the span of the request.
+	config.ConnConfig.Tracer = otelpgx.NewTracer()
+
 	pool, err := pgxpool.NewWithConfig(context.Background(), config)
 	if err != nil {
 		return nil, fmt.Errorf(": %w", err)
@@ -36,6 +40,9 @@ func(pg *Postgres) GetConn() *pgxpool.Pool{
 	return pg.db
 }
 
+func (pg *Postgres) Ping(ctx context.Context) error {
+	return pg.db.Ping(ctx)
+}
 
 func (pg *Postgres) Close() {
 	pg.db.Close()
@@ -0,0 +1,125 @@
+package health
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDraining = "draining"
+)
+
+// Check pings one dependency of the service. It must give up when ctx is done.
+type Check struct {
+	Name  string
+	Check func(ctx context.Context) error
+}
+
+// Result of one check.
+type Result struct {
+	Status   string
+	Error    string
+	Duration time.Duration
+}
+
+// Report is what the readiness probe answers with. Ready is false when any dependency is down
+// or the service is draining before shutdown.
+type Report struct {
+	Ready  bool
+	Status string
+	Checks map[string]Result
+}
+
+// Checker runs the checks of the dependencies, each one bounded by its own timeout
+// so that a hanging dependency doesn't hide the state of the others.
+type Checker struct {
+	checks   []Check
+	timeout  time.Duration
+	draining atomic.Bool
+}
+
+func New(timeout time.Duration, checks ...Check) *Checker {
+	return &Checker{
+		checks:  checks,
+		timeout: timeout,
+	}
+}
+
+// Drain makes the service report itself not ready, so load balancers stop sending it traffic before it shuts down.
+func (c *Checker) Drain() {
+	c.draining.Store(true)
+}
+
+func (c *Checker) Draining() bool {
+	return c.draining.Load()
+}
+
+// Ready runs all checks concurrently. The checks still run while draining, so the report shows
+// the state of the dependencies either way.
+func (c *Checker) Ready(ctx context.Context) *Report {
+	results := make([]Result, len(c.checks))
+
+	var wg sync.WaitGroup
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = c.run(ctx, check)
+		}()
+	}
+	wg.Wait()
+
+	report := &Report{
+		Ready:  true,
+		Status: StatusUp,
+		Checks: make(map[string]Result, len(c.checks)),
+	}
+	for i, check := range c.checks {
+		report.Checks[check.Name] = results[i]
+		if results[i].Status != StatusUp {
+			report.Ready = false
+			report.Status = StatusDown
+		}
+	}
+
+	if c.Draining() {
+		report.Ready = false
+		report.Status = StatusDraining
+	}
+
+	return report
+}
+
+func (c *Checker) run(ctx context.Context, check Check) Result {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	start := time.Now()
+	done := make(chan error, 1)
+	go func() {
+		done <- check.Check(ctx)
+	}()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		// The check ignored the deadline, don't wait for it.
+		err = ctx.Err()
+	}
+
+	result := Result{
+		Status:   StatusUp,
+		Duration: time.Since(start),
+	}
+	if err != nil {
+		result.Status = StatusDown
+		result.Error = err.Error()
+	}
+
+	return result
+}
@@ -0,0 +1,59 @@
+package health_test
+
+import (
+	"context"
+	"errors"
+	"task/pkg/health"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadyReportsEveryDependency(t *testing.T) {
+	checker := health.New(50*time.Millisecond,
+		health.Check{Name: "postgres", Check: func(context.Context) error { return nil }},
+		health.Check{Name: "redis", Check: func(context.Context) error { return errors.New("connection refused") }},
+	)
+
+	report := checker.Ready(context.Background())
+
+	assert.False(t, report.Ready)
+	assert.Equal(t, health.StatusDown, report.Status)
+	assert.Equal(t, health.StatusUp, report.Checks["postgres"].Status)
+	assert.Equal(t, health.StatusDown, report.Checks["redis"].Status)
+	assert.Equal(t, "connection refused", report.Checks["redis"].Error)
+}
+
+func TestReadyTimesOutHangingCheck(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+	checker := health.New(20*time.Millisecond,
+		health.Check{Name: "kafka", Check: func(context.Context) error {
+			<-release
+			return nil
+		}},
+		health.Check{Name: "postgres", Check: func(context.Context) error { return nil }},
+	)
+
+	start := time.Now()
+	report := checker.Ready(context.Background())
+
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Equal(t, health.StatusDown, report.Checks["kafka"].Status)
+	assert.Equal(t, context.DeadlineExceeded.Error(), report.Checks["kafka"].Error)
+	assert.Equal(t, health.StatusUp, report.Checks["postgres"].Status)
+}
+
+func TestReadyFailsWhileDraining(t *testing.T) {
+	checker := health.New(50*time.Millisecond,
+		health.Check{Name: "postgres", Check: func(context.Context) error { return nil }},
+	)
+
+	checker.Drain()
+	report := checker.Ready(context.Background())
+
+	assert.False(t, report.Ready)
+	assert.Equal(t, health.StatusDraining, report.Status)
+	assert.Equal(t, health.StatusUp, report.Checks["postgres"].Status)
+}
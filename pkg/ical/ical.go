@@ -0,0 +1,97 @@
+// Package ical writes iCalendar (RFC 5545) feeds of timed events.
+package ical
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	ContentType = "text/calendar; charset=utf-8"
+
+	// maxLineOctets is the longest content line RFC 5545 allows before it has to be folded.
+	maxLineOctets = 75
+	timeLayout    = "20060102T150405Z"
+)
+
+type Calendar struct {
+	ProdID string
+	Name   string
+	Events []Event
+}
+
+// Event is a point in time such as a deadline. Without DTEND the event takes no time.
+// Sequence has to grow whenever the event changes so subscribed clients pick up the change.
+type Event struct {
+	UID         string
+	Sequence    int
+	Start       time.Time
+	Summary     string
+	Description string
+}
+
+// Write encodes the calendar. All times are written in UTC, which every client converts to its own timezone,
+// so no VTIMEZONE definitions are needed. stamp is the DTSTAMP of the events.
+func Write(w io.Writer, calendar *Calendar, stamp time.Time) error {
+	out := bufio.NewWriter(w)
+	line := func(name, value string) {
+		writeLine(out, name+":"+value)
+	}
+
+	line("BEGIN", "VCALENDAR")
+	line("VERSION", "2.0")
+	line("PRODID", calendar.ProdID)
+	line("CALSCALE", "GREGORIAN")
+	line("METHOD", "PUBLISH")
+	if calendar.Name != "" {
+		line("X-WR-CALNAME", escape(calendar.Name))
+	}
+
+	for _, event := range calendar.Events {
+		line("BEGIN", "VEVENT")
+		line("UID", escape(event.UID))
+		line("SEQUENCE", strconv.Itoa(event.Sequence))
+		line("DTSTAMP", stamp.UTC().Format(timeLayout))
+		line("DTSTART", event.Start.UTC().Format(timeLayout))
+		line("SUMMARY", escape(event.Summary))
+		if event.Description != "" {
+			line("DESCRIPTION", escape(event.Description))
+		}
+		line("TRANSP", "TRANSPARENT")
+		line("END", "VEVENT")
+	}
+
+	line("END", "VCALENDAR")
+
+	return out.Flush()
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`, "\r", `\n`)
+
+func escape(text string) string {
+	return escaper.Replace(text)
+}
+
+// writeLine folds the line into chunks of at most 75 octets without splitting UTF-8 sequences.
+func writeLine(out *bufio.Writer, line string) {
+	limit := maxLineOctets
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(line[cut]) {
+			cut--
+		}
+
+		out.WriteString(line[:cut])
+		out.WriteString("\r\n ")
+		line = line[cut:]
+		// The leading space of a continuation line counts towards its length.
+		limit = maxLineOctets - 1
+	}
+
+	out.WriteString(line)
+	out.WriteString("\r\n")
+}
@@ -0,0 +1,40 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteFoldsAndEscapes(t *testing.T) {
+	deadline := time.Date(2025, 1, 1, 16, 0, 0, 0, time.FixedZone("MSK", 3*60*60))
+	calendar := &Calendar{
+		ProdID: "-//test//EN",
+		Name:   "9А",
+		Events: []Event{{
+			UID:         "42@test",
+			Sequence:    2,
+			Start:       deadline,
+			Summary:     "Решить задачи 1, 2; 3",
+			Description: strings.Repeat("длинное условие задачи ", 5) + "\nвторая строка",
+		}},
+	}
+
+	var out bytes.Buffer
+	err := Write(&out, calendar, deadline)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSuffix(out.String(), "\r\n"), "\r\n")
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), maxLineOctets)
+	}
+	assert.Contains(t, lines, "DTSTART:20250101T130000Z")
+	assert.Contains(t, lines, `SUMMARY:Решить задачи 1\, 2\; 3`)
+
+	unfolded := strings.ReplaceAll(out.String(), "\r\n ", "")
+	assert.Contains(t, unfolded, `\nвторая строка`+"\r\n")
+}
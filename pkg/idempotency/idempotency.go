@@ -0,0 +1,143 @@
+package idempotency
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"log/slog"
+	"net/http"
+	"task/pkg/problem"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	Header         = "Idempotency-Key"
+	ReplayedHeader = "Idempotent-Replayed"
+
+	// pendingTTL bounds how long a key stays locked by a request that never finished, e.g. after a crash.
+	pendingTTL = time.Minute
+)
+
+// replayedHeaders are the response headers stored with the response and sent again on a replay.
+var replayedHeaders = []string{"Content-Type", "ETag", "Location"}
+
+// Record is what is stored under an idempotency key: the fingerprint of the request body
+// and, once the request has finished, its response.
+type Record struct {
+	Fingerprint string            `json:"fingerprint"`
+	Completed   bool              `json:"completed"`
+	Status      int               `json:"status,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	Body        []byte            `json:"body,omitempty"`
+}
+
+type Store interface {
+	// Reserve stores a pending record under key unless the key is taken. When it is taken,
+	// it returns the stored record and false.
+	Reserve(ctx context.Context, key string, record *Record, ttl time.Duration) (*Record, bool, error)
+	Complete(ctx context.Context, key string, record *Record, ttl time.Duration) error
+	Release(ctx context.Context, key string) error
+}
+
+// Middleware makes POST requests with an Idempotency-Key header safe to retry. The first request with a key runs
+// the handler and stores its response for ttl, retries with the same body get the stored response, and
+// reusing the key with a different body is rejected with 422. Failed requests (5xx) release the key.
+// Keys are scoped per route, per school from the X-Tenant-ID header and per user from the X-User-ID header.
+func Middleware(store Store, ttl time.Duration, logger *slog.Logger) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		key := c.GetHeader(Header)
+		if key == "" {
+			c.Next()
+			return
+		}
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			logger.Error("failed to read body", slog.String("error", err.Error()))
+			problem.Abort(c, problem.New(http.StatusBadRequest, "failed to read request body"))
+			return
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+		ctx := c.Request.Context()
+		key = c.GetHeader("X-Tenant-ID") + ":" + c.GetHeader("X-User-ID") + ":" + c.Request.Method + ":" + c.FullPath() + ":" + key
+		sum := sha256.Sum256(body)
+		record := &Record{Fingerprint: hex.EncodeToString(sum[:])}
+
+		stored, reserved, err := store.Reserve(ctx, key, record, pendingTTL)
+		if err != nil {
+			logger.Error("idempotency store", slog.String("error", err.Error()))
+			problem.Abort(c, problem.New(http.StatusInternalServerError, "internal server error"))
+			return
+		}
+
+		if !reserved {
+			replay(c, stored, record.Fingerprint)
+			return
+		}
+
+		recorder := &responseRecorder{ResponseWriter: c.Writer}
+		c.Writer = recorder
+		c.Next()
+
+		if recorder.Status() >= http.StatusInternalServerError {
+			if err := store.Release(context.WithoutCancel(ctx), key); err != nil {
+				logger.Error("idempotency store", slog.String("error", err.Error()))
+			}
+			return
+		}
+
+		record.Completed = true
+		record.Status = recorder.Status()
+		record.Body = recorder.body.Bytes()
+		record.Headers = make(map[string]string, len(replayedHeaders))
+		for _, header := range replayedHeaders {
+			if value := recorder.Header().Get(header); value != "" {
+				record.Headers[header] = value
+			}
+		}
+
+		if err := store.Complete(context.WithoutCancel(ctx), key, record, ttl); err != nil {
+			logger.Error("idempotency store", slog.String("error", err.Error()))
+		}
+	}
+}
+
+func replay(c *gin.Context, stored *Record, fingerprint string) {
+	if stored.Fingerprint != fingerprint {
+		problem.Abort(c, problem.New(http.StatusUnprocessableEntity, Header+" has already been used with a different request body"))
+		return
+	}
+
+	if !stored.Completed {
+		problem.Abort(c, problem.New(http.StatusConflict, "a request with this "+Header+" is still being processed"))
+		return
+	}
+
+	for header, value := range stored.Headers {
+		c.Header(header, value)
+	}
+	c.Header(ReplayedHeader, "true")
+	c.Status(stored.Status)
+	_, _ = c.Writer.Write(stored.Body)
+	c.Abort()
+}
+
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
@@ -0,0 +1,112 @@
+package idempotency_test
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"task/pkg/idempotency"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[string]idempotency.Record
+}
+
+func (s *memoryStore) Reserve(_ context.Context, key string, record *idempotency.Record, _ time.Duration) (*idempotency.Record, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if stored, ok := s.records[key]; ok {
+		return &stored, false, nil
+	}
+	s.records[key] = *record
+
+	return nil, true, nil
+}
+
+func (s *memoryStore) Complete(_ context.Context, key string, record *idempotency.Record, _ time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.records[key] = *record
+
+	return nil
+}
+
+func (s *memoryStore) Release(_ context.Context, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, key)
+
+	return nil
+}
+
+func newRouter(status *int, calls *int) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	store := &memoryStore{records: make(map[string]idempotency.Record)}
+	router.POST("/task", idempotency.Middleware(store, time.Hour, slog.Default()), func(c *gin.Context) {
+		*calls++
+		c.Header("ETag", `"1"`)
+		c.Header("Location", "/api/v2/tasks/1")
+		c.JSON(*status, gin.H{"call": *calls})
+	})
+
+	return router
+}
+
+func post(router *gin.Engine, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/task", strings.NewReader(body))
+	req.Header.Set(idempotency.Header, key)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	return w
+}
+
+func TestMiddlewareReplaysResponse(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	router := newRouter(&status, &calls)
+
+	first := post(router, "key", `{"payload":"5+5 = ?"}`)
+	retry := post(router, "key", `{"payload":"5+5 = ?"}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, `"1"`, retry.Header().Get("ETag"))
+	assert.Equal(t, "/api/v2/tasks/1", retry.Header().Get("Location"))
+	assert.Equal(t, "true", retry.Header().Get(idempotency.ReplayedHeader))
+}
+
+func TestMiddlewareRejectsKeyReuseWithDifferentBody(t *testing.T) {
+	status, calls := http.StatusCreated, 0
+	router := newRouter(&status, &calls)
+
+	post(router, "key", `{"payload":"5+5 = ?"}`)
+	reuse := post(router, "key", `{"payload":"6+6 = ?"}`)
+
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusUnprocessableEntity, reuse.Code)
+}
+
+func TestMiddlewareReleasesKeyOnServerError(t *testing.T) {
+	status, calls := http.StatusInternalServerError, 0
+	router := newRouter(&status, &calls)
+
+	post(router, "key", `{"payload":"5+5 = ?"}`)
+	status = http.StatusCreated
+	retry := post(router, "key", `{"payload":"5+5 = ?"}`)
+
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+}
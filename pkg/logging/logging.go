@@ -0,0 +1,27 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+)
+
+type loggerKey struct{}
+
+// ContextWithLogger stores the logger of the request, which already carries its request ID, route, user and tenant.
+func ContextWithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, logger)
+}
+
+// FromContext returns the logger of the request, or fallback outside of requests, e.g. in background jobs.
+func FromContext(ctx context.Context, fallback *slog.Logger) *slog.Logger {
+	if logger, ok := ctx.Value(loggerKey{}).(*slog.Logger); ok {
+		return logger
+	}
+
+	return fallback
+}
+
+// With adds attributes to the logger of the request for everything logged further down.
+func With(ctx context.Context, fallback *slog.Logger, args ...any) context.Context {
+	return ContextWithLogger(ctx, FromContext(ctx, fallback).With(args...))
+}
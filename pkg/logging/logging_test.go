@@ -0,0 +1,33 @@
+package logging_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"task/pkg/logging"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithAddsAttributesToRequestLogger(t *testing.T) {
+	var out bytes.Buffer
+	fallback := slog.New(slog.NewJSONHandler(&out, nil))
+
+	ctx := logging.With(context.Background(), fallback, slog.String("request_id", "42"))
+	ctx = logging.With(ctx, fallback, slog.String("tenant_id", "school"))
+	logging.FromContext(ctx, fallback).Info("done")
+
+	var record map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &record))
+	assert.Equal(t, "42", record["request_id"])
+	assert.Equal(t, "school", record["tenant_id"])
+}
+
+func TestFromContextFallsBackOutsideRequests(t *testing.T) {
+	fallback := slog.New(slog.NewJSONHandler(&bytes.Buffer{}, nil))
+
+	assert.Same(t, fallback, logging.FromContext(context.Background(), fallback))
+}
@@ -0,0 +1,140 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+const namespace = "task"
+
+// unmatchedRoute labels requests no route matched, so scanning random paths can't blow up the number of series.
+const unmatchedRoute = "unmatched"
+
+var (
+	httpRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "http",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of HTTP requests by route template and status.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	grpcRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Subsystem: "grpc",
+		Name:      "request_duration_seconds",
+		Help:      "Duration of gRPC calls by full method name and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "code"})
+
+	cacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "cache",
+		Name:      "requests_total",
+		Help:      "Task cache lookups by result: hit, miss or error.",
+	}, []string{"result"})
+
+	rateLimitRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "rate_limiter",
+		Name:      "requests_total",
+		Help:      "Requests checked by the rate limiter by result: allowed, denied or error.",
+	}, []string{"result"})
+
+	producerMessages = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Subsystem: "producer",
+		Name:      "messages_total",
+		Help:      "Messages acknowledged by Kafka by result: success or failure.",
+	}, []string{"result"})
+
+	producerQueueDepth = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "producer",
+		Name:      "queue_depth",
+		Help:      "Messages handed to the producer and not yet acknowledged by Kafka.",
+	})
+)
+
+// HTTP records the duration of every request under its route template, e.g. /api/v1/task/:id.
+func HTTP() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+
+		httpRequestDuration.WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+// GRPC records the duration of every unary call under its full method name, e.g. /task.v1.TaskService/GetTask.
+func GRPC() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		start := time.Now()
+
+		resp, err := handler(ctx, req)
+
+		grpcRequestDuration.WithLabelValues(info.FullMethod, status.Code(err).String()).Observe(time.Since(start).Seconds())
+
+		return resp, err
+	}
+}
+
+// Handler serves the metrics in the Prometheus text format.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+func CacheHit() {
+	cacheRequests.WithLabelValues("hit").Inc()
+}
+
+func CacheMiss() {
+	cacheRequests.WithLabelValues("miss").Inc()
+}
+
+func CacheError() {
+	cacheRequests.WithLabelValues("error").Inc()
+}
+
+func RateLimitAllowed() {
+	rateLimitRequests.WithLabelValues("allowed").Inc()
+}
+
+func RateLimitDenied() {
+	rateLimitRequests.WithLabelValues("denied").Inc()
+}
+
+func RateLimitError() {
+	rateLimitRequests.WithLabelValues("error").Inc()
+}
+
+// ProducerQueued counts messages handed to the producer.
+func ProducerQueued(count int) {
+	producerQueueDepth.Add(float64(count))
+}
+
+func ProducerSucceeded() {
+	producerMessages.WithLabelValues("success").Inc()
+	producerQueueDepth.Dec()
+}
+
+func ProducerFailed() {
+	producerMessages.WithLabelValues("failure").Inc()
+	producerQueueDepth.Dec()
+}
@@ -0,0 +1,32 @@
+package metrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"task/pkg/metrics"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTPLabelsRequestsWithRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(metrics.HTTP())
+	router.GET("/task/:id", func(c *gin.Context) {
+		c.Status(http.StatusNoContent)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/task/42", nil))
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/wp-login.php", nil))
+
+	scrape := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(scrape, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	body := scrape.Body.String()
+	assert.Contains(t, body, `task_http_request_duration_seconds_count{method="GET",route="/task/:id",status="204"} 1`)
+	assert.Contains(t, body, `task_http_request_duration_seconds_count{method="GET",route="unmatched",status="404"} 1`)
+	assert.NotContains(t, body, "/task/42")
+	assert.NotContains(t, body, "wp-login")
+}
@@ -0,0 +1,181 @@
+package openapivalidator
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"task/pkg/problem"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+)
+
+// requestProblem answers an invalid request: a body that couldn't be read outweighs invalid fields,
+// as the fields of such a body are unknown.
+func requestProblem(err error) *problem.Details {
+	var fields []problem.FieldError
+	for _, err := range unwrapMulti(err) {
+		var requestErr *openapi3filter.RequestError
+		if !errors.As(err, &requestErr) {
+			return problem.New(http.StatusBadRequest, err.Error())
+		}
+
+		switch {
+		case requestErr.Parameter != nil:
+			fields = append(fields, schemaFields(requestErr.Err, requestErr.Parameter.Name)...)
+		case requestErr.Err == nil && strings.HasPrefix(requestErr.Reason, "header Content-Type"):
+			return problem.New(http.StatusUnsupportedMediaType, requestErr.Reason)
+		case errors.Is(requestErr.Err, openapi3filter.ErrInvalidRequired):
+			return problem.New(http.StatusBadRequest, "request body is required")
+		case isSchemaErr(requestErr.Err):
+			fields = append(fields, schemaFields(requestErr.Err, "")...)
+		default:
+			return problem.New(http.StatusBadRequest, requestErr.Error())
+		}
+	}
+
+	p := problem.New(http.StatusUnprocessableEntity, "request is not valid")
+	p.Errors = fields
+
+	return p
+}
+
+func schemaFields(err error, prefix string) []problem.FieldError {
+	if errors.Is(err, openapi3filter.ErrInvalidRequired) {
+		return []problem.FieldError{{Field: prefix, Message: "is required"}}
+	}
+
+	var fields []problem.FieldError
+	for _, err := range unwrapMulti(err) {
+		var schemaErr *openapi3.SchemaError
+		if !errors.As(err, &schemaErr) {
+			fields = append(fields, problem.FieldError{Field: prefix, Message: "is not valid"})
+			continue
+		}
+
+		pointer := schemaErr.JSONPointer()
+		if schemaErr.SchemaField == "properties" {
+			// The unknown property is only named in the reason.
+			if _, quoted, ok := strings.Cut(schemaErr.Reason, " "); ok {
+				if name, err := strconv.Unquote(strings.TrimSuffix(quoted, " is unsupported")); err == nil {
+					pointer = append(pointer, name)
+				}
+			}
+		}
+
+		fields = append(fields, problem.FieldError{Field: fieldPath(prefix, pointer), Message: message(schemaErr)})
+	}
+
+	return fields
+}
+
+// unwrapMulti flattens the errors of err when it is a MultiError itself, a wrapped one belongs to its wrapper.
+func unwrapMulti(err error) []error {
+	multi, ok := err.(openapi3.MultiError)
+	if !ok {
+		return []error{err}
+	}
+
+	var errs []error
+	for _, err := range multi {
+		errs = append(errs, unwrapMulti(err)...)
+	}
+
+	return errs
+}
+
+func isSchemaErr(err error) bool {
+	var schemaErr *openapi3.SchemaError
+	var multi openapi3.MultiError
+
+	return errors.As(err, &schemaErr) || errors.As(err, &multi)
+}
+
+// fieldPath names the field the way the request binding does, e.g. assign_to[1].lesson_id.
+func fieldPath(prefix string, pointer []string) string {
+	var path strings.Builder
+	path.WriteString(prefix)
+	for _, segment := range pointer {
+		if _, err := strconv.Atoi(segment); err == nil {
+			path.WriteString("[" + segment + "]")
+			continue
+		}
+		if path.Len() > 0 {
+			path.WriteByte('.')
+		}
+		path.WriteString(segment)
+	}
+
+	return path.String()
+}
+
+// message words the error the way pkg/validation does, so clients get the same messages from both.
+func message(err *openapi3.SchemaError) string {
+	schema := err.Schema
+	switch err.SchemaField {
+	case "required":
+		return "is required"
+	case "properties":
+		return "is not supported"
+	case "nullable":
+		return "can't be null"
+	case "type":
+		return "must be " + article(schema.Type.Slice())
+	case "format":
+		switch schema.Format {
+		case "uuid":
+			return "must be a UUID"
+		case "date-time":
+			return "must be a date-time, e.g. 2025-01-01T13:00:00Z"
+		}
+	case "enum":
+		values := make([]string, 0, len(schema.Enum))
+		for _, value := range schema.Enum {
+			values = append(values, fmt.Sprint(value))
+		}
+		return "must be one of " + strings.Join(values, ", ")
+	case "uniqueItems":
+		return "must not contain duplicates"
+	case "minLength":
+		return fmt.Sprintf("must be at least %d characters long", schema.MinLength)
+	case "maxLength":
+		if schema.MaxLength != nil {
+			return fmt.Sprintf("must be at most %d characters long", *schema.MaxLength)
+		}
+	case "minItems":
+		return fmt.Sprintf("must have at least %d items", schema.MinItems)
+	case "maxItems":
+		if schema.MaxItems != nil {
+			return fmt.Sprintf("must have at most %d items", *schema.MaxItems)
+		}
+	case "minimum":
+		if schema.Min != nil {
+			return "must be at least " + strconv.FormatFloat(*schema.Min, 'f', -1, 64)
+		}
+	case "maximum":
+		if schema.Max != nil {
+			return "must be at most " + strconv.FormatFloat(*schema.Max, 'f', -1, 64)
+		}
+	}
+
+	return "is not valid"
+}
+
+func article(types []string) string {
+	names := make([]string, 0, len(types))
+	for _, name := range types {
+		switch name {
+		case openapi3.TypeNull:
+			names = append(names, "null")
+		case openapi3.TypeArray, openapi3.TypeInteger, openapi3.TypeObject:
+			names = append(names, "an "+name)
+		default:
+			names = append(names, "a "+name)
+		}
+	}
+
+	return strings.Join(names, " or ")
+}
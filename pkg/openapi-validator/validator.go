@@ -0,0 +1,229 @@
+// Package openapivalidator checks requests, and optionally responses, against the OpenAPI specification of the API.
+package openapivalidator
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+
+	"task/pkg/problem"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers"
+	"github.com/gin-gonic/gin"
+)
+
+// uuidFormat is the format of the UUIDs the API accepts, the same one the request binding checks.
+const uuidFormat = `^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`
+
+type validator struct {
+	routes            map[string]*routers.Route
+	options           *openapi3filter.Options
+	validateResponses bool
+	logger            *slog.Logger
+}
+
+// Middleware rejects requests that don't match the operation of the route in the spec: 422 with every invalid field,
+// 415 for a body of a media type the operation doesn't accept and 400 for a body that couldn't be read.
+// With validateResponses it also buffers the responses and replaces those that don't match the spec with 500,
+// which is meant for tests, as it breaks streaming and costs a copy of every response.
+// Routes the spec doesn't describe are passed through.
+func Middleware(spec []byte, logger *slog.Logger, validateResponses bool) (gin.HandlerFunc, error) {
+	doc, err := openapi3.NewLoader().LoadFromData(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed load openapi spec with error: %w", err)
+	}
+
+	// The format is defined globally, as kin-openapi checks parameters with the global formats only.
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(uuidFormat))
+
+	v := &validator{
+		routes: make(map[string]*routers.Route),
+		options: &openapi3filter.Options{
+			MultiError:          true,
+			SkipSettingDefaults: true,
+			AuthenticationFunc:  openapi3filter.NoopAuthenticationFunc,
+		},
+		validateResponses: validateResponses,
+		logger:            logger,
+	}
+	for path, item := range doc.Paths.Map() {
+		for method, operation := range item.Operations() {
+			v.routes[method+" "+path] = &routers.Route{
+				Spec:      doc,
+				Path:      path,
+				PathItem:  item,
+				Method:    method,
+				Operation: operation,
+			}
+		}
+	}
+
+	return v.handle, nil
+}
+
+func (v *validator) handle(c *gin.Context) {
+	route, ok := v.routes[c.Request.Method+" "+specPath(c.FullPath())]
+	if !ok {
+		c.Next()
+		return
+	}
+
+	pathParams := make(map[string]string, len(c.Params))
+	for _, param := range c.Params {
+		pathParams[param.Key] = param.Value
+	}
+	input := &openapi3filter.RequestValidationInput{
+		Request:    c.Request,
+		PathParams: pathParams,
+		Route:      route,
+		Options:    v.options,
+	}
+
+	if err := openapi3filter.ValidateRequest(c.Request.Context(), input); err != nil {
+		problem.Abort(c, requestProblem(err))
+		return
+	}
+
+	if !v.validateResponses {
+		c.Next()
+		return
+	}
+
+	writer := &bufferedWriter{ResponseWriter: c.Writer}
+	c.Writer = writer
+	// Restored in a defer, so the recovery of a panic answers on the real writer.
+	defer func() { c.Writer = writer.ResponseWriter }()
+	c.Next()
+	c.Writer = writer.ResponseWriter
+
+	if err := v.validateResponse(c.Request.Context(), input, writer); err != nil {
+		v.logger.Error("response does not match the openapi spec",
+			slog.String("method", c.Request.Method),
+			slog.String("route", route.Path),
+			slog.Int("status", writer.Status()),
+			slog.String("error", err.Error()),
+		)
+		for _, header := range []string{"Content-Type", "Content-Disposition", "ETag", "Location"} {
+			c.Writer.Header().Del(header)
+		}
+		problem.Abort(c, problem.New(http.StatusInternalServerError, "response does not match the API specification"))
+		return
+	}
+
+	writer.flush()
+}
+
+func (v *validator) validateResponse(ctx context.Context, input *openapi3filter.RequestValidationInput, writer *bufferedWriter) error {
+	options := v.options
+	// Bodies of the media types kin-openapi can't decode, e.g. text/calendar, are left unchecked.
+	if mediaType, _, err := mime.ParseMediaType(writer.Header().Get("Content-Type")); err == nil &&
+		openapi3filter.RegisteredBodyDecoder(mediaType) == nil && !binary(input.Route, writer.Status(), mediaType) {
+		copied := *options
+		copied.ExcludeResponseBody = true
+		options = &copied
+	}
+
+	return openapi3filter.ValidateResponse(ctx, &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 writer.Status(),
+		Header:                 writer.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(writer.body.Bytes())),
+		Options:                options,
+	})
+}
+
+// binary tells whether the response is described as a file, which kin-openapi reads without a decoder.
+func binary(route *routers.Route, status int, mediaType string) bool {
+	response := route.Operation.Responses.Status(status)
+	if response == nil {
+		response = route.Operation.Responses.Default()
+	}
+	if response == nil || response.Value == nil {
+		return false
+	}
+
+	content := response.Value.Content.Get(mediaType)
+	if content == nil || content.Schema == nil || content.Schema.Value == nil {
+		return false
+	}
+
+	return content.Schema.Value.Type.Is(openapi3.TypeString) && content.Schema.Value.Format == "binary"
+}
+
+// specPath turns the gin route /task/:id into the spec path /task/{id}.
+func specPath(route string) string {
+	segments := strings.Split(route, "/")
+	for i, segment := range segments {
+		if strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "*") {
+			segments[i] = "{" + segment[1:] + "}"
+		}
+	}
+
+	return strings.Join(segments, "/")
+}
+
+// bufferedWriter holds the response back until it is validated. Like the writer of gin, it takes the status
+// until the body starts.
+type bufferedWriter struct {
+	gin.ResponseWriter
+	status  int
+	written bool
+	body    bytes.Buffer
+}
+
+func (w *bufferedWriter) WriteHeader(code int) {
+	if code > 0 && !w.written {
+		w.status = code
+	}
+}
+
+func (w *bufferedWriter) WriteHeaderNow() {
+	w.written = true
+}
+
+func (w *bufferedWriter) Write(data []byte) (int, error) {
+	w.WriteHeaderNow()
+	return w.body.Write(data)
+}
+
+func (w *bufferedWriter) WriteString(s string) (int, error) {
+	w.WriteHeaderNow()
+	return w.body.WriteString(s)
+}
+
+func (w *bufferedWriter) Status() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+func (w *bufferedWriter) Size() int {
+	if !w.written {
+		return -1
+	}
+
+	return w.body.Len()
+}
+
+func (w *bufferedWriter) Written() bool {
+	return w.written
+}
+
+func (w *bufferedWriter) Flush() {}
+
+func (w *bufferedWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.Status())
+	if w.written {
+		w.ResponseWriter.WriteHeaderNow()
+		_, _ = w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
@@ -0,0 +1,187 @@
+package openapivalidator_test
+
+import (
+	"encoding/json"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	openapivalidator "task/pkg/openapi-validator"
+	"task/pkg/problem"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const spec = `
+openapi: 3.1.0
+info:
+  title: test
+  version: "1"
+paths:
+  /marks/{id}:
+    put:
+      parameters:
+        - name: id
+          in: path
+          required: true
+          schema:
+            type: string
+            format: uuid
+        - name: scale
+          in: query
+          schema:
+            type: string
+            enum: [five, hundred]
+      requestBody:
+        required: true
+        content:
+          application/json:
+            schema:
+              type: object
+              required: [marks]
+              properties:
+                marks:
+                  type: array
+                  maxItems: 2
+                  items:
+                    type: object
+                    required: [mark]
+                    properties:
+                      mark:
+                        type: [integer, "null"]
+                        maximum: 100
+      responses:
+        "201":
+          description: Created
+          content:
+            application/json:
+              schema:
+                type: object
+                required: [id]
+                properties:
+                  id:
+                    type: string
+                    format: uuid
+  /calendar:
+    get:
+      responses:
+        "200":
+          description: Calendar
+          content:
+            text/calendar:
+              schema:
+                type: string
+`
+
+const id = "8a1f3c56-5d0e-4a43-9d55-0b8e4f0f6f0b"
+
+func newRouter(t *testing.T, validateResponses bool, created gin.H) *gin.Engine {
+	t.Helper()
+
+	validate, err := openapivalidator.Middleware([]byte(spec), slog.New(slog.NewJSONHandler(io.Discard, nil)), validateResponses)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(validate)
+	router.PUT("/marks/:id", func(c *gin.Context) {
+		c.Status(http.StatusCreated)
+		c.JSON(http.StatusCreated, created)
+	})
+	router.GET("/calendar", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/calendar", []byte("BEGIN:VCALENDAR"))
+	})
+	router.GET("/unknown", func(c *gin.Context) {
+		c.String(http.StatusOK, "unknown")
+	})
+
+	return router
+}
+
+func serve(router http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	if body != "" {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, req)
+
+	return rec
+}
+
+func TestRequestFields(t *testing.T) {
+	router := newRouter(t, false, gin.H{"id": id})
+
+	rec := serve(router, http.MethodPut, "/marks/1?scale=ten", `{"marks": [{"mark": 101}, {}, {"mark": null}]}`)
+
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	var details problem.Details
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &details))
+	assert.ElementsMatch(t, []problem.FieldError{
+		{Field: "id", Message: "must be a UUID"},
+		{Field: "scale", Message: "must be one of five, hundred"},
+		{Field: "marks", Message: "must have at most 2 items"},
+		{Field: "marks[0].mark", Message: "must be at most 100"},
+		{Field: "marks[1].mark", Message: "is required"},
+	}, details.Errors)
+}
+
+func TestRequestBody(t *testing.T) {
+	router := newRouter(t, false, gin.H{"id": id})
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{name: "valid", body: `{"marks": [{"mark": 5}]}`, status: http.StatusCreated},
+		{name: "missing", body: "", status: http.StatusBadRequest},
+		{name: "malformed", body: `{"marks": [`, status: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serve(router, http.MethodPut, "/marks/"+id, tt.body)
+
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestResponses(t *testing.T) {
+	tests := []struct {
+		name              string
+		validateResponses bool
+		created           gin.H
+		status            int
+	}{
+		{name: "valid", validateResponses: true, created: gin.H{"id": id}, status: http.StatusCreated},
+		{name: "invalid", validateResponses: true, created: gin.H{"id": "1"}, status: http.StatusInternalServerError},
+		{name: "not validated", validateResponses: false, created: gin.H{"id": "1"}, status: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := newRouter(t, tt.validateResponses, tt.created)
+
+			rec := serve(router, http.MethodPut, "/marks/"+id, `{"marks": []}`)
+
+			assert.Equal(t, tt.status, rec.Code, rec.Body.String())
+		})
+	}
+}
+
+func TestResponsesWithoutSchema(t *testing.T) {
+	router := newRouter(t, true, nil)
+
+	for _, target := range []string{"/calendar", "/unknown"} {
+		rec := serve(router, http.MethodGet, target, "")
+
+		assert.Equal(t, http.StatusOK, rec.Code, target)
+		assert.NotEmpty(t, rec.Body.String(), target)
+	}
+}
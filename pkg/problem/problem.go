@@ -0,0 +1,45 @@
+// Package problem writes error responses as problem details (RFC 7807).
+package problem
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const ContentType = "application/problem+json"
+
+// Details describes what went wrong with the request. Type is about:blank, so Title is the text of the status code.
+type Details struct {
+	Type     string `json:"type" example:"about:blank"`
+	Title    string `json:"title" example:"Unprocessable Entity"`
+	Status   int    `json:"status" example:"422"`
+	Detail   string `json:"detail,omitempty" example:"invalid recurrence"`
+	Instance string `json:"instance,omitempty" example:"/api/v1/recurrence"`
+	// Errors point at the invalid fields of the request.
+	Errors []FieldError `json:"errors,omitempty"`
+}
+
+type FieldError struct {
+	Field   string `json:"field" example:"rrule"`
+	Message string `json:"message" example:"unsupported FREQ \"HOURLY\""`
+}
+
+func New(status int, detail string) *Details {
+	return &Details{
+		Type:   "about:blank",
+		Title:  http.StatusText(status),
+		Status: status,
+		Detail: detail,
+	}
+}
+
+// Abort writes the problem as the response and stops the handler chain.
+func Abort(c *gin.Context, p *Details) {
+	if p.Instance == "" {
+		p.Instance = c.Request.URL.Path
+	}
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
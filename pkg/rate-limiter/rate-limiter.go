@@ -5,6 +5,8 @@ import (
 	"log/slog"
 	"net/http"
 	"strconv"
+	"task/pkg/metrics"
+	"task/pkg/problem"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -15,12 +17,14 @@ var ErrRateLimited = errors.New("rate limited")
 
 var Limiter *redis_rate.Limiter
 
-func RateLimit(logger *slog.Logger) gin.HandlerFunc {
+// RateLimit allows 10 requests per minute for every key returned by key, e.g. per user of a school.
+func RateLimit(logger *slog.Logger, key func(c *gin.Context) string) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		res, err := Limiter.Allow(c.Request.Context(), "task", redis_rate.PerMinute(10))
+		res, err := Limiter.Allow(c.Request.Context(), key(c), redis_rate.PerMinute(10))
 		if err != nil {
+			metrics.RateLimitError()
 			logger.Error("Rate limiter", slog.String("error", err.Error()))
-			c.AbortWithStatus(http.StatusInternalServerError)
+			problem.Abort(c, problem.New(http.StatusInternalServerError, "internal server error"))
 			return
 		}
 
@@ -28,16 +32,18 @@ func RateLimit(logger *slog.Logger) gin.HandlerFunc {
 
 		if res.Allowed == 0 {
 			// We are rate limited.
+			metrics.RateLimitDenied()
 
 			seconds := int(res.RetryAfter / time.Second)
 			c.Header("RateLimit-RetryAfter", strconv.Itoa(seconds))
 
 			// Stop processing and return the error.
-			c.AbortWithStatus(http.StatusTooManyRequests)
+			problem.Abort(c, problem.New(http.StatusTooManyRequests, "too many requests, retry in "+strconv.Itoa(seconds)+" seconds"))
 			return
 		}
 
 		// Continue processing as normal.
+		metrics.RateLimitAllowed()
 		c.Next()
 	}
 }
@@ -0,0 +1,211 @@
+package spreadsheet
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/xuri/excelize/v2"
+)
+
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+)
+
+const (
+	ContentTypeCSV  = "text/csv"
+	ContentTypeXLSX = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+)
+
+const (
+	// MaxSize is the largest file Read accepts.
+	MaxSize = 10 << 20
+	// maxUnzippedSize caps what an XLSX workbook may unpack to, so a small zip can't fill the memory.
+	maxUnzippedSize = 100 << 20
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported spreadsheet format, expected csv or xlsx")
+	ErrTooLarge          = fmt.Errorf("spreadsheet is larger than %d MB", MaxSize>>20)
+)
+
+// DetectFormat picks the format by the file extension and falls back to the content type.
+func DetectFormat(filename, contentType string) (Format, error) {
+	switch strings.ToLower(filepath.Ext(filename)) {
+	case ".csv":
+		return FormatCSV, nil
+	case ".xlsx":
+		return FormatXLSX, nil
+	}
+
+	switch strings.TrimSpace(strings.Split(contentType, ";")[0]) {
+	case ContentTypeCSV:
+		return FormatCSV, nil
+	case ContentTypeXLSX:
+		return FormatXLSX, nil
+	}
+
+	return "", ErrUnsupportedFormat
+}
+
+// Read returns the rows of a CSV file or of the first sheet of an XLSX workbook.
+// Rows that are completely empty are kept, so row numbers match the ones the user sees.
+// Files larger than MaxSize fail with ErrTooLarge.
+func Read(r io.Reader, format Format) ([][]string, error) {
+	r = &limitedReader{r: r, n: MaxSize}
+
+	switch format {
+	case FormatCSV:
+		reader := csv.NewReader(r)
+		reader.FieldsPerRecord = -1
+		reader.TrimLeadingSpace = true
+
+		rows, err := reader.ReadAll()
+		if errors.Is(err, ErrTooLarge) {
+			return nil, ErrTooLarge
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't read csv: %w", err)
+		}
+
+		return rows, nil
+	case FormatXLSX:
+		workbook, err := excelize.OpenReader(r, excelize.Options{UnzipSizeLimit: maxUnzippedSize})
+		if errors.Is(err, ErrTooLarge) {
+			return nil, ErrTooLarge
+		}
+		if err != nil {
+			return nil, fmt.Errorf("can't read xlsx: %w", err)
+		}
+		defer workbook.Close()
+
+		sheets := workbook.GetSheetList()
+		if len(sheets) == 0 {
+			return nil, nil
+		}
+
+		rows, err := workbook.GetRows(sheets[0])
+		if err != nil {
+			return nil, fmt.Errorf("can't read xlsx sheet %s: %w", sheets[0], err)
+		}
+
+		return rows, nil
+	}
+
+	return nil, ErrUnsupportedFormat
+}
+
+// limitedReader reads at most n bytes and fails with ErrTooLarge when there are more.
+type limitedReader struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedReader) Read(p []byte) (int, error) {
+	if int64(len(p)) > l.n+1 {
+		p = p[:l.n+1]
+	}
+
+	n, err := l.r.Read(p)
+	if int64(n) > l.n {
+		return int(l.n), ErrTooLarge
+	}
+	l.n -= int64(n)
+
+	return n, err
+}
+
+// Writer writes rows of a CSV file or of a single sheet XLSX workbook.
+// Cells are strings, integers or nil for an empty cell.
+type Writer interface {
+	Write(row []any) error
+	// Close writes what is left. An XLSX workbook is a zip archive, so it reaches
+	// the underlying writer only here; excelize keeps large sheets in a temporary file meanwhile.
+	Close() error
+}
+
+func NewWriter(w io.Writer, format Format, sheet string) (Writer, error) {
+	switch format {
+	case FormatCSV:
+		return &csvWriter{writer: csv.NewWriter(w)}, nil
+	case FormatXLSX:
+		workbook := excelize.NewFile()
+		if err := workbook.SetSheetName(workbook.GetSheetName(0), sheet); err != nil {
+			workbook.Close()
+			return nil, fmt.Errorf("can't name xlsx sheet %s: %w", sheet, err)
+		}
+
+		stream, err := workbook.NewStreamWriter(sheet)
+		if err != nil {
+			workbook.Close()
+			return nil, fmt.Errorf("can't write xlsx sheet %s: %w", sheet, err)
+		}
+
+		return &xlsxWriter{out: w, workbook: workbook, stream: stream}, nil
+	}
+
+	return nil, ErrUnsupportedFormat
+}
+
+type csvWriter struct {
+	writer *csv.Writer
+	record []string
+}
+
+func (w *csvWriter) Write(row []any) error {
+	w.record = w.record[:0]
+	for _, cell := range row {
+		switch value := cell.(type) {
+		case nil:
+			w.record = append(w.record, "")
+		case string:
+			w.record = append(w.record, value)
+		default:
+			w.record = append(w.record, fmt.Sprint(value))
+		}
+	}
+
+	return w.writer.Write(w.record)
+}
+
+func (w *csvWriter) Close() error {
+	w.writer.Flush()
+	return w.writer.Error()
+}
+
+type xlsxWriter struct {
+	out      io.Writer
+	workbook *excelize.File
+	stream   *excelize.StreamWriter
+	rows     int
+}
+
+func (w *xlsxWriter) Write(row []any) error {
+	w.rows++
+	cell, err := excelize.CoordinatesToCellName(1, w.rows)
+	if err != nil {
+		return err
+	}
+
+	return w.stream.SetRow(cell, row)
+}
+
+func (w *xlsxWriter) Close() error {
+	defer w.workbook.Close()
+
+	if err := w.stream.Flush(); err != nil {
+		return fmt.Errorf("can't write xlsx: %w", err)
+	}
+
+	if err := w.workbook.Write(w.out); err != nil {
+		return fmt.Errorf("can't write xlsx: %w", err)
+	}
+
+	return nil
+}
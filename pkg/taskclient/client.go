@@ -0,0 +1,47 @@
+package taskclient
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+const (
+	tenantHeader  = "X-Tenant-ID"
+	userHeader    = "X-User-ID"
+	gatewayHeader = "X-Gateway-Secret"
+)
+
+// WithGatewaySecret sends every request of the client with the secret shared with the gateway.
+// The service serves no other requests, calendar feeds aside.
+func WithGatewaySecret(secret string) ClientOption {
+	return WithRequestEditorFn(header(gatewayHeader, secret))
+}
+
+// WithTenant sends every request of the client on behalf of the school.
+func WithTenant(tenantID uuid.UUID) ClientOption {
+	return WithRequestEditorFn(Tenant(tenantID))
+}
+
+// WithUser sends every request of the client on behalf of the user.
+func WithUser(userID uuid.UUID) ClientOption {
+	return WithRequestEditorFn(User(userID))
+}
+
+// Tenant sends a single request on behalf of the school.
+func Tenant(tenantID uuid.UUID) RequestEditorFn {
+	return header(tenantHeader, tenantID.String())
+}
+
+// User sends a single request on behalf of the user.
+func User(userID uuid.UUID) RequestEditorFn {
+	return header(userHeader, userID.String())
+}
+
+func header(key, value string) RequestEditorFn {
+	return func(_ context.Context, req *http.Request) error {
+		req.Header.Set(key, value)
+		return nil
+	}
+}
@@ -0,0 +1,132 @@
+// Package validation adds the rules requests need to go-playground/validator and turns its errors
+// into messages for clients.
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError points at the invalid field by the name the client sends it with, e.g. assign_to[1].lesson_id.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Setup registers the custom rules and makes errors name the fields by their json or form tags:
+//   - future: the time is after now;
+//   - notblank: the string has something besides whitespace;
+//   - payload: the string is at most maxPayload characters long.
+func Setup(validate *validator.Validate, maxPayload int) error {
+	validate.RegisterTagNameFunc(fieldName)
+	validate.RegisterAlias("payload", fmt.Sprintf("max=%d", maxPayload))
+
+	rules := map[string]validator.Func{
+		"future":   future,
+		"notblank": notBlank,
+	}
+	for tag, rule := range rules {
+		if err := validate.RegisterValidation(tag, rule); err != nil {
+			return fmt.Errorf("register %s rule: %w", tag, err)
+		}
+	}
+
+	return nil
+}
+
+// Fields returns every invalid field of err, false when err isn't a validation error.
+func Fields(err error) ([]FieldError, bool) {
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		return nil, false
+	}
+
+	fields := make([]FieldError, 0, len(validationErrs))
+	for _, validationErr := range validationErrs {
+		fields = append(fields, FieldError{
+			Field:   fieldPath(validationErr),
+			Message: message(validationErr),
+		})
+	}
+
+	return fields, true
+}
+
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "form"} {
+		name, _, _ := strings.Cut(field.Tag.Get(tag), ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+// fieldPath is the path of the field without the name of the validated struct.
+func fieldPath(err validator.FieldError) string {
+	namespace := err.Namespace()
+	if i := strings.IndexByte(namespace, '.'); i >= 0 {
+		return namespace[i+1:]
+	}
+
+	return namespace
+}
+
+// message goes by the actual tag, so the aliases are reported like the rules they stand for.
+func message(err validator.FieldError) string {
+	switch err.ActualTag() {
+	case "required":
+		return "is required"
+	case "notblank":
+		return "can't be blank"
+	case "future":
+		return "must be in the future"
+	case "uuid":
+		return "must be a UUID"
+	case "unique":
+		return "must not contain duplicates"
+	case "timezone":
+		return "must be an IANA time zone, e.g. Europe/Moscow"
+	case "oneof":
+		return "must be one of " + strings.ReplaceAll(err.Param(), " ", ", ")
+	case "min":
+		return limit(err, "at least")
+	case "max":
+		return limit(err, "at most")
+	default:
+		return "is not valid"
+	}
+}
+
+func limit(err validator.FieldError, bound string) string {
+	switch err.Kind() {
+	case reflect.String:
+		return "must be " + bound + " " + err.Param() + " characters long"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return "must have " + bound + " " + err.Param() + " items"
+	default:
+		return "must be " + bound + " " + err.Param()
+	}
+}
+
+func future(fl validator.FieldLevel) bool {
+	t, ok := fl.Field().Interface().(time.Time)
+	if !ok {
+		return false
+	}
+
+	return t.After(time.Now())
+}
+
+func notBlank(fl validator.FieldLevel) bool {
+	return strings.TrimSpace(fl.Field().String()) != ""
+}
@@ -0,0 +1,90 @@
+package validation_test
+
+import (
+	"task/pkg/validation"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type lesson struct {
+	Class    string `json:"class" binding:"required,notblank,max=4"`
+	LessonID string `json:"lesson_id" binding:"required,uuid"`
+}
+
+type assignment struct {
+	ToAssign []lesson  `json:"assign_to" binding:"required,min=1,unique=LessonID,dive"`
+	Deadline time.Time `json:"deadline,omitempty" binding:"omitempty,future"`
+	Mark     *int      `json:"mark" binding:"required,min=0,max=100"`
+	Comment  string    `json:"comment" binding:"payload"`
+}
+
+func newValidator(t *testing.T) *validator.Validate {
+	validate := validator.New()
+	validate.SetTagName("binding")
+	require.NoError(t, validation.Setup(validate, 8))
+
+	return validate
+}
+
+func TestFieldsReportsEveryInvalidField(t *testing.T) {
+	validate := newValidator(t)
+	mark := 101
+
+	err := validate.Struct(assignment{
+		ToAssign: []lesson{
+			{Class: " ", LessonID: "0d7f3a4e-5b4c-4a55-9b1a-0f8a7a4b9c11"},
+			{Class: "LONG CLASS", LessonID: "42"},
+		},
+		Deadline: time.Now().Add(-time.Hour),
+		Mark:     &mark,
+		Comment:  "too long a comment",
+	})
+
+	fields, ok := validation.Fields(err)
+	require.True(t, ok)
+	assert.ElementsMatch(t, []validation.FieldError{
+		{Field: "assign_to[0].class", Message: "can't be blank"},
+		{Field: "assign_to[1].class", Message: "must be at most 4 characters long"},
+		{Field: "assign_to[1].lesson_id", Message: "must be a UUID"},
+		{Field: "deadline", Message: "must be in the future"},
+		{Field: "mark", Message: "must be at most 100"},
+		{Field: "comment", Message: "must be at most 8 characters long"},
+	}, fields)
+}
+
+func TestFieldsRejectsDuplicates(t *testing.T) {
+	validate := newValidator(t)
+	lessonID := "0d7f3a4e-5b4c-4a55-9b1a-0f8a7a4b9c11"
+	mark := 5
+
+	err := validate.Struct(assignment{
+		ToAssign: []lesson{{Class: "5A", LessonID: lessonID}, {Class: "5A", LessonID: lessonID}},
+		Mark:     &mark,
+	})
+
+	fields, ok := validation.Fields(err)
+	require.True(t, ok)
+	assert.Equal(t, []validation.FieldError{{Field: "assign_to", Message: "must not contain duplicates"}}, fields)
+}
+
+func TestFieldsAcceptsValidRequest(t *testing.T) {
+	validate := newValidator(t)
+	mark := 0
+
+	err := validate.Struct(assignment{
+		ToAssign: []lesson{{Class: "5A", LessonID: "0d7f3a4e-5b4c-4a55-9b1a-0f8a7a4b9c11"}},
+		Mark:     &mark,
+	})
+
+	assert.NoError(t, err)
+}
+
+func TestFieldsIgnoresOtherErrors(t *testing.T) {
+	_, ok := validation.Fields(assert.AnError)
+
+	assert.False(t, ok)
+}